@@ -55,16 +55,19 @@ func NewE2ETestSuite(t *testing.T) *E2ETestSuite {
 func (s *E2ETestSuite) Cleanup(t *testing.T) {
 	log.Printf("Cleaning up %d tunnels and %d hostnames", len(s.createdTunnels), len(s.createdHostnames))
 
-	// Clean up tunnels (this also removes associated hostnames)
+	// Test tunnel names are unique, so each one doubles as a gc prefix that
+	// also removes the DNS records pointing at it in the zone under test
 	for _, tunnelName := range s.createdTunnels {
-		if err := s.client.DeleteTunnel(s.ctx, tunnelName); err != nil {
+		report, err := s.client.GarbageCollect(s.ctx, tunnelName, s.client.GetZoneDomain(), nil, nil, false)
+		if err != nil {
 			t.Logf("Warning: Failed to cleanup tunnel %s: %v", tunnelName, err)
-		} else {
-			log.Printf("Cleaned up tunnel: %s", tunnelName)
+			continue
 		}
+		for _, err := range report.Errors {
+			t.Logf("Warning: %v", err)
+		}
+		log.Printf("Cleaned up tunnel %s (%d DNS records)", tunnelName, len(report.DNSRecords))
 	}
-
-	// Note: DNS cleanup is no longer needed as we use cloudflared CLI for DNS management
 }
 
 func (s *E2ETestSuite) CreateTestTunnel(t *testing.T, name string) *models.CLITunnel {
@@ -2,17 +2,23 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"tunnelman/models"
 	"tunnelman/views"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
 )
 
 var version = "dev"
@@ -45,10 +51,16 @@ func setupInteractiveConfig() (*models.Config, error) {
 	fmt.Println("2. Email address (optional, leave blank if using API token):")
 	email := promptUser("Enter your Cloudflare email (optional): ")
 
+	// Optional Zero Trust team
+	fmt.Println("")
+	fmt.Println("3. Zero Trust team name (optional, the <team> in <team>.cloudflareaccess.com):")
+	team := promptUser("Enter your Zero Trust team name (optional): ")
+
 	// Create config
 	config := &models.Config{
 		CloudflareAPIKey:   apiKey,
 		CloudflareEmail:    email,
+		ZeroTrustTeam:      team,
 		TunnelConfigPath:   "",
 		AutoRefreshSeconds: 30,
 		LogLevel:           "info",
@@ -73,7 +85,159 @@ func setupInteractiveConfig() (*models.Config, error) {
 	return config, nil
 }
 
-func runConfigCommand() {
+// promptSecret reads a line without echoing it when stdin is a terminal
+func promptSecret(prompt string) string {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return promptUser(prompt)
+	}
+	fmt.Print(prompt)
+	secret, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Println()
+	if err != nil {
+		log.Fatalf("❌ Failed to read input: %v", err)
+	}
+	return strings.TrimSpace(string(secret))
+}
+
+// bundlePassphrase returns the passphrase for a config bundle from
+// $TUNNELMAN_PASSPHRASE, or prompts for it (twice when confirm is set)
+func bundlePassphrase(confirm bool) []byte {
+	if passphrase := os.Getenv("TUNNELMAN_PASSPHRASE"); passphrase != "" {
+		return []byte(passphrase)
+	}
+	passphrase := promptSecret("Passphrase: ")
+	if passphrase == "" {
+		log.Fatalf("❌ A passphrase is required")
+	}
+	if confirm && promptSecret("Repeat passphrase: ") != passphrase {
+		log.Fatalf("❌ Passphrases don't match")
+	}
+	return []byte(passphrase)
+}
+
+// configPassphraseSource returns how the passphrase of an encrypted
+// config.json is read: from keyFile if given, then $TUNNELMAN_PASSPHRASE,
+// then a prompt
+func configPassphraseSource(keyFile string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if keyFile != "" {
+			data, err := os.ReadFile(keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read key file: %w", err)
+			}
+			return bytes.TrimSpace(data), nil
+		}
+		if passphrase := os.Getenv("TUNNELMAN_PASSPHRASE"); passphrase != "" {
+			return []byte(passphrase), nil
+		}
+		return []byte(promptSecret("🔒 Config passphrase: ")), nil
+	}
+}
+
+func runConfigEncryptCommand(keyFile string) {
+	if models.ConfigEncrypted() {
+		fmt.Println("config.json is already encrypted.")
+		return
+	}
+
+	var passphrase []byte
+	if keyFile != "" {
+		var err error
+		if passphrase, err = configPassphraseSource(keyFile)(); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		if len(passphrase) == 0 {
+			log.Fatalf("❌ Key file %s is empty", keyFile)
+		}
+	} else {
+		passphrase = bundlePassphrase(true)
+	}
+
+	if err := models.EncryptConfig(passphrase); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	fmt.Printf("🔒 Encrypted %s\n", models.GetConfigPath())
+	fmt.Println("   tunnelman will ask for the passphrase at startup (or use -key-file / $TUNNELMAN_PASSPHRASE)")
+}
+
+func runConfigDecryptCommand() {
+	if !models.ConfigEncrypted() {
+		fmt.Println("config.json is not encrypted.")
+		return
+	}
+	if err := models.DecryptConfig(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	fmt.Printf("🔓 Decrypted %s\n", models.GetConfigPath())
+}
+
+func runConfigExportCommand(args []string) {
+	fs := flag.NewFlagSet("config export", flag.ExitOnError)
+	output := fs.String("o", "tunnelman-config.bundle", "File to write the encrypted bundle to")
+	noToken := fs.Bool("no-token", false, "Leave the API token out of the bundle")
+	fs.Parse(args)
+
+	passphrase := bundlePassphrase(true)
+	sealed, manifest, err := models.ExportBundle(passphrase, *noToken)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if err := os.WriteFile(*output, sealed, 0600); err != nil {
+		log.Fatalf("❌ Failed to write %s: %v", *output, err)
+	}
+
+	fmt.Printf("📦 Wrote %d files to %s\n", len(manifest.Files), *output)
+	if manifest.TokenOmitted {
+		fmt.Println("   The API token was left out; import keeps the token configured on the new machine")
+	}
+}
+
+func runConfigImportCommand(args []string) {
+	fs := flag.NewFlagSet("config import", flag.ExitOnError)
+	force := fs.Bool("force", false, "Overwrite files that already exist")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("Usage: tunnelman config import [-force] <bundle>")
+	}
+
+	sealed, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("❌ Failed to read %s: %v", fs.Arg(0), err)
+	}
+
+	written, skipped, err := models.ImportBundle(sealed, bundlePassphrase(false), *force)
+	for _, path := range written {
+		fmt.Printf("✅ %s\n", path)
+	}
+	for _, path := range skipped {
+		fmt.Printf("⏭️  %s (exists, use -force to overwrite)\n", path)
+	}
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	fmt.Printf("📦 Imported %d files\n", len(written))
+}
+
+func runConfigCommand(args []string, keyFile string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "encrypt":
+			runConfigEncryptCommand(keyFile)
+			return
+		case "decrypt":
+			runConfigDecryptCommand()
+			return
+		case "export":
+			runConfigExportCommand(args[1:])
+			return
+		case "import":
+			runConfigImportCommand(args[1:])
+			return
+		}
+		log.Fatalf("Usage: tunnelman config [encrypt | decrypt | export [-o file] [-no-token] | import [-force] <bundle>]")
+	}
+
 	fmt.Println("🔧 Tunnelman Configuration")
 	fmt.Println("")
 
@@ -104,6 +268,551 @@ func runConfigCommand() {
 	fmt.Println("🎉 Configuration complete! You can now run 'tunnelman' to start the TUI.")
 }
 
+func runUptimeCommand(args []string) {
+	fs := flag.NewFlagSet("uptime", flag.ExitOnError)
+	days := fs.Int("days", 7, "Report window in days (max 30)")
+	fs.Parse(args)
+
+	uptimeLog, err := models.LoadUptimeLog("")
+	if err != nil {
+		log.Fatalf("Failed to load uptime log: %v", err)
+	}
+
+	reports := uptimeLog.Report(time.Duration(*days)*24*time.Hour, time.Now())
+	if len(reports) == 0 {
+		fmt.Println("No status history recorded yet. Uptime is tracked while the TUI is running.")
+		return
+	}
+
+	fmt.Printf("📈 Uptime over the last %d days\n\n", *days)
+	fmt.Printf("%-30s %-10s %-10s %s\n", "TUNNEL", "UPTIME", "OUTAGES", "LAST DOWNTIME")
+	for _, report := range reports {
+		lastDown := "never"
+		if !report.LastDowntime.IsZero() {
+			lastDown = report.LastDowntime.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-30s %-10s %-10d %s\n", report.TunnelName, fmt.Sprintf("%.2f%%", report.UptimePercent), report.Downtimes, lastDown)
+	}
+}
+
+// loadClient loads the saved configuration and returns a validated Cloudflare
+// client for non-interactive subcommands
+func loadClient() (*models.Config, *models.CloudflareClient) {
+	config, err := models.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	if config.CloudflareAPIKey == "" {
+		log.Fatalf("No Cloudflare API key configured - run 'tunnelman config' first")
+	}
+
+	client, err := models.NewCloudflareClient(config)
+	if err != nil {
+		log.Fatalf("❌ Failed to create Cloudflare client: %v", err)
+	}
+
+	if err := client.ValidateCredentials(context.Background()); err != nil {
+		log.Fatalf("❌ Authentication failed: %v", err)
+	}
+
+	return config, client
+}
+
+// eventFlushTimeout bounds how long exiting waits for queued change events
+const eventFlushTimeout = 10 * time.Second
+
+// flushEvents delivers the change events still queued before the process
+// exits
+func flushEvents(client *models.CloudflareClient) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventFlushTimeout)
+	defer cancel()
+	if err := client.CloseEvents(ctx); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+func runWatchCommand(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := fs.Duration("interval", time.Minute, "How often to check for expired hostnames")
+	fs.Parse(args)
+
+	config, client := loadClient()
+
+	fmt.Printf("👀 Watching for expired temporary hostnames every %s (Ctrl+C to stop)\n", *interval)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	for {
+		state, err := models.LoadAppState(config.TunnelConfigPath)
+		if err != nil {
+			log.Printf("Warning: Failed to load state: %v", err)
+		} else {
+			expireHostnames(client, state)
+		}
+		select {
+		case <-time.After(*interval):
+		case <-interrupt:
+			flushEvents(client)
+			return
+		}
+	}
+}
+
+func expireHostnames(client *models.CloudflareClient, state *models.AppState) {
+	expired := state.ExpiredHostnames(time.Now())
+	if len(expired) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	for _, temp := range expired {
+		if err := client.RemoveHostnameWithDNS(ctx, temp.TunnelID, temp.Hostname, temp.Path); err != nil {
+			log.Printf("❌ Failed to remove expired hostname %s: %v", temp.Hostname, err)
+			continue
+		}
+		state.RemoveTemporaryHostname(temp.Hostname)
+		log.Printf("⏳ Removed expired hostname %s", temp.Hostname)
+	}
+
+	if err := state.Save(); err != nil {
+		log.Printf("Warning: Failed to save state: %v", err)
+	}
+}
+
+func runGCCommand(args []string) {
+	fs := flag.NewFlagSet("gc", flag.ExitOnError)
+	prefix := fs.String("prefix", "", "Name prefix of tunnels, hostnames and config files to remove (required)")
+	zone := fs.String("zone", "", "Only look for DNS records in this zone (default every zone)")
+	dryRun := fs.Bool("dry-run", false, "List what would be removed without deleting anything")
+	fs.Parse(args)
+
+	if *prefix == "" {
+		log.Fatalf("gc requires -prefix")
+	}
+
+	_, client := loadClient()
+	defer flushEvents(client)
+	tunnelManager := models.NewTunnelManager(client, "")
+
+	var dockerManager *models.DockerManager
+	if dm, err := models.NewDockerManager(); err == nil && dm.IsDockerAvailable() {
+		dockerManager = dm
+		defer dm.Close()
+	} else {
+		fmt.Println("⚠️  Docker not available, skipping Traefik containers")
+	}
+
+	report, err := client.GarbageCollect(context.Background(), *prefix, *zone, tunnelManager, dockerManager, *dryRun)
+	if err != nil {
+		log.Fatalf("❌ Garbage collection failed: %v", err)
+	}
+
+	verb := "Removed"
+	if *dryRun {
+		verb = "Would remove"
+	}
+	printGCSection(verb, "DNS records", report.DNSRecords)
+	printGCSection(verb, "Traefik containers", report.Containers)
+	printGCSection(verb, "config files", report.ConfigFiles)
+	printGCSection(verb, "tunnels", report.Tunnels)
+
+	for _, err := range report.Errors {
+		fmt.Printf("❌ %v\n", err)
+	}
+	if len(report.Errors) > 0 {
+		flushEvents(client)
+		os.Exit(1)
+	}
+}
+
+func runDocsCommand(args []string) {
+	fs := flag.NewFlagSet("docs", flag.ExitOnError)
+	output := fs.String("o", "", "Write the Markdown to this file instead of stdout")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("Usage: tunnelman docs [-o file] <tunnel name or ID>")
+	}
+
+	_, client := loadClient()
+
+	var dockerManager *models.DockerManager
+	if dm, err := models.NewDockerManager(); err == nil && dm.IsDockerAvailable() {
+		dockerManager = dm
+		defer dm.Close()
+	}
+
+	docs, err := client.GenerateTunnelDocs(context.Background(), fs.Arg(0), dockerManager)
+	if err != nil {
+		log.Fatalf("❌ Failed to generate docs: %v", err)
+	}
+
+	if *output == "" {
+		fmt.Print(docs)
+		return
+	}
+
+	if err := os.WriteFile(*output, []byte(docs), 0644); err != nil {
+		log.Fatalf("❌ Failed to write %s: %v", *output, err)
+	}
+	fmt.Printf("📝 Wrote documentation to %s\n", *output)
+}
+
+func runAuditCommand(args []string) {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	fs.Parse(args)
+
+	_, client := loadClient()
+	ctx := context.Background()
+
+	tunnels, err := client.ListTunnels(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to list tunnels: %v", err)
+	}
+
+	report := client.Audit(ctx, tunnels)
+	for _, note := range report.Notes {
+		fmt.Printf("⚠️  %s\n", note)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Printf("🛡️  No risky exposures found across %d hostnames\n", report.Hostnames)
+		return
+	}
+
+	fmt.Printf("🛡️  %d issues across %d hostnames\n\n", len(report.Findings), report.Hostnames)
+	fmt.Printf("%-8s %-40s %-20s %s\n", "SEVERITY", "HOSTNAME", "TUNNEL", "ISSUE")
+	for _, finding := range report.Findings {
+		tunnel := finding.TunnelName
+		if tunnel == "" {
+			tunnel = "-"
+		}
+		fmt.Printf("%-8s %-40s %-20s %s\n", finding.Severity, finding.Hostname, tunnel, finding.Message)
+	}
+
+	// Fail scripted runs when anything high severity is exposed
+	if report.HighCount() > 0 {
+		os.Exit(1)
+	}
+}
+
+func runDNSCheckCommand(args []string) {
+	fs := flag.NewFlagSet("dns-check", flag.ExitOnError)
+	tunnelFlag := fs.String("tunnel", "", "Name or ID of the tunnel the hostname should point at (default the tunnel routing it)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("Usage: tunnelman dns-check [-tunnel name or ID] <hostname>")
+	}
+	hostname := strings.ToLower(fs.Arg(0))
+
+	_, client := loadClient()
+	ctx := context.Background()
+
+	var tunnelID, tunnelName string
+	if *tunnelFlag != "" {
+		tunnel, err := client.GetTunnelInfo(ctx, *tunnelFlag)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		tunnelID, tunnelName = tunnel.ID, tunnel.Name
+	} else {
+		tunnels, err := client.ListTunnels(ctx)
+		if err != nil {
+			log.Fatalf("❌ Failed to list tunnels: %v", err)
+		}
+		hostnames, _ := client.ListAllPublicHostnames(ctx, tunnels)
+		for _, h := range hostnames {
+			if strings.EqualFold(h.Hostname, hostname) {
+				tunnelID, tunnelName = h.TunnelID, h.TunnelName
+				break
+			}
+		}
+		if tunnelID == "" {
+			log.Fatalf("❌ No tunnel routes %s - pass -tunnel to say which one should", hostname)
+		}
+	}
+
+	verification, err := client.VerifyHostnameDNS(ctx, hostname, tunnelID)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	fmt.Printf("🔎 %s should point at tunnel %s (%s)\n", hostname, tunnelName, verification.Expected)
+	if record := verification.Record; record != nil {
+		fmt.Printf("   Account record: %s %s (proxied: %t)\n", record.Type, record.Content, record.Proxied)
+	} else {
+		fmt.Println("   Account record: none - the hostname's DNS is managed elsewhere")
+	}
+	fmt.Println()
+	for _, answer := range verification.Answers {
+		mark := "❌"
+		if answer.OK {
+			mark = "✅"
+		}
+		fmt.Printf("%s %-22s %s\n", mark, answer.Resolver, answer.Verdict)
+	}
+
+	if !verification.OK() {
+		os.Exit(1)
+	}
+}
+
+func runApplySnippetCommand(args []string) {
+	fs := flag.NewFlagSet("apply-snippet", flag.ExitOnError)
+	output := fs.String("o", "", "Write the YAML to this file instead of stdout")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("Usage: tunnelman apply-snippet [-o file] <tunnel name or ID>")
+	}
+
+	_, client := loadClient()
+	defer flushEvents(client)
+
+	snippet, err := client.GenerateApplySnippet(context.Background(), fs.Arg(0))
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	if *output == "" {
+		fmt.Print(snippet)
+		return
+	}
+
+	if err := os.WriteFile(*output, []byte(snippet), 0644); err != nil {
+		log.Fatalf("❌ Failed to write %s: %v", *output, err)
+	}
+	fmt.Printf("📋 Wrote apply YAML to %s\n", *output)
+}
+
+func runTerraformCommand(args []string) {
+	fs := flag.NewFlagSet("terraform", flag.ExitOnError)
+	output := fs.String("o", "", "Write the HCL to this file instead of stdout")
+	fs.Parse(args)
+
+	_, client := loadClient()
+
+	hcl, err := client.GenerateTerraform(context.Background(), fs.Args())
+	if err != nil {
+		log.Fatalf("❌ Failed to export Terraform: %v", err)
+	}
+
+	if *output == "" {
+		fmt.Print(hcl)
+		return
+	}
+
+	if err := os.WriteFile(*output, []byte(hcl), 0644); err != nil {
+		log.Fatalf("❌ Failed to write %s: %v", *output, err)
+	}
+	fmt.Printf("🏗  Wrote Terraform to %s\n", *output)
+}
+
+// handoffRecipients reads -to, which is an age recipient or SSH public key,
+// or a file of them such as ~/.ssh/id_ed25519.pub or github.com/<user>.keys
+func handoffRecipients(to string) []models.AgeRecipient {
+	data := []byte(to)
+	if contents, err := os.ReadFile(to); err == nil {
+		data = contents
+	}
+	recipients, err := models.ParseAgeRecipients(data)
+	if err != nil {
+		log.Fatalf("❌ Invalid -to: %v", err)
+	}
+	return recipients
+}
+
+// handoffIdentityFile returns the identity to open a handoff bundle with,
+// defaulting to the user's ed25519 then RSA SSH key
+func handoffIdentityFile(identity string) string {
+	if identity != "" {
+		return identity
+	}
+	home, _ := os.UserHomeDir()
+	for _, name := range []string{"id_ed25519", "id_rsa"} {
+		candidate := filepath.Join(home, ".ssh", name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	log.Fatalf("No SSH key found in ~/.ssh, pass the identity with -i")
+	return ""
+}
+
+func runHandoffOpenCommand(args []string) {
+	fs := flag.NewFlagSet("handoff open", flag.ExitOnError)
+	identity := fs.String("i", "", "age identity file or SSH private key (default ~/.ssh/id_ed25519 or ~/.ssh/id_rsa)")
+	dir := fs.String("dir", models.GetCloudflaredDir(), "Directory to write the config, credentials, token and docs to")
+	force := fs.Bool("force", false, "Overwrite files that already exist")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("Usage: tunnelman handoff open [-i identity] [-dir dir] [-force] <bundle>")
+	}
+
+	encrypted, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("❌ Failed to read %s: %v", fs.Arg(0), err)
+	}
+	identityFile := handoffIdentityFile(*identity)
+	keyData, err := os.ReadFile(identityFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to read %s: %v", identityFile, err)
+	}
+	identities, err := models.ParseAgeIdentities(keyData, func() ([]byte, error) {
+		return []byte(promptSecret("🔑 Passphrase for " + identityFile + ": ")), nil
+	})
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	manifest, written, skipped, err := models.OpenHandoffBundle(encrypted, identities, *dir, *force)
+	for _, path := range written {
+		fmt.Printf("✅ %s\n", path)
+	}
+	for _, path := range skipped {
+		fmt.Printf("⏭️  %s (exists, use -force to overwrite)\n", path)
+	}
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	fmt.Printf("🤝 Opened the handoff for %s (%d hostnames)\n", manifest.TunnelName, len(manifest.Hostnames))
+	fmt.Printf("   Run it with: cloudflared tunnel --config %s run\n", filepath.Join(*dir, manifest.TunnelName+".yml"))
+}
+
+func runHandoffCommand(args []string) {
+	if len(args) > 0 && args[0] == "open" {
+		runHandoffOpenCommand(args[1:])
+		return
+	}
+
+	fs := flag.NewFlagSet("handoff", flag.ExitOnError)
+	to := fs.String("to", "", "Teammate's age recipient or SSH public key, or a file of them (required)")
+	output := fs.String("o", "", "File to write the encrypted bundle to (default <tunnel>.handoff.age)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 || *to == "" {
+		log.Fatalf("Usage: tunnelman handoff -to <key or file> [-o file] <tunnel name or ID>")
+	}
+	recipients := handoffRecipients(*to)
+
+	_, client := loadClient()
+
+	var dockerManager *models.DockerManager
+	if dm, err := models.NewDockerManager(); err == nil && dm.IsDockerAvailable() {
+		dockerManager = dm
+		defer dm.Close()
+	}
+
+	encrypted, manifest, err := client.CreateHandoffBundle(context.Background(), fs.Arg(0), recipients, dockerManager)
+	if err != nil {
+		log.Fatalf("❌ Failed to create handoff: %v", err)
+	}
+	if *output == "" {
+		*output = manifest.TunnelName + ".handoff.age"
+	}
+	if err := os.WriteFile(*output, encrypted, 0600); err != nil {
+		log.Fatalf("❌ Failed to write %s: %v", *output, err)
+	}
+
+	fmt.Printf("🤝 Wrote the handoff for %s to %s, encrypted to:\n", manifest.TunnelName, *output)
+	for _, recipient := range manifest.Recipients {
+		fmt.Printf("   - %s\n", recipient)
+	}
+	fmt.Println("   Open it with `tunnelman handoff open` or `age -d -i <key>`")
+}
+
+func runShareCommand(args []string) {
+	fs := flag.NewFlagSet("share", flag.ExitOnError)
+	tunnel := fs.String("tunnel", "", "Name or ID of the tunnel to publish through (required)")
+	hostname := fs.String("hostname", "", "Hostname to use (default share-<random>.<zone>)")
+	zone := fs.String("zone", "", "Zone for the generated hostname (default the last selected domain)")
+	ttl := fs.Duration("ttl", models.DefaultShareTTL, "How long the link stays up")
+	user := fs.String("user", models.DefaultShareUsername, "Basic auth username")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 || *tunnel == "" {
+		log.Fatalf("Usage: tunnelman share -tunnel <name or ID> [-hostname H | -zone Z] [-ttl 1h] <file or folder>")
+	}
+
+	config, client := loadClient()
+	defer flushEvents(client)
+	ctx := context.Background()
+
+	tunnelInfo, err := client.GetTunnelInfo(ctx, *tunnel)
+	if err != nil {
+		log.Fatalf("❌ Failed to find tunnel %s: %v", *tunnel, err)
+	}
+
+	state, err := models.LoadAppState(config.TunnelConfigPath)
+	if err != nil {
+		log.Printf("Warning: Failed to load state: %v", err)
+		state = models.NewAppState()
+	}
+
+	if *hostname == "" {
+		if *zone == "" {
+			*zone = state.GetSelectedDomain()
+		}
+		if *zone == "" {
+			log.Fatalf("share needs -hostname or -zone")
+		}
+		if *hostname, err = models.ShareHostname(*zone); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+	}
+
+	tunnelManager := models.NewTunnelManager(client, "")
+	share, err := client.StartShare(ctx, tunnelManager, tunnelInfo.ID, *hostname, fs.Arg(0), *user, *ttl)
+	if err != nil {
+		log.Fatalf("❌ Failed to share %s: %v", fs.Arg(0), err)
+	}
+
+	// Record the expiry so `tunnelman watch` or the TUI cleans up if we're killed
+	state.AddTemporaryHostname(models.TemporaryHostname{
+		Hostname:  share.Hostname,
+		TunnelID:  share.TunnelID,
+		ExpiresAt: share.ExpiresAt,
+	})
+	if err := state.Save(); err != nil {
+		log.Printf("Warning: Failed to save state: %v", err)
+	}
+
+	fmt.Printf("📤 Sharing %s\n", share.Root)
+	fmt.Printf("   URL:      %s\n", share.URL())
+	fmt.Printf("   Username: %s\n", share.Username)
+	fmt.Printf("   Password: %s\n", share.Password)
+	fmt.Printf("   Expires:  %s (Ctrl+C to stop sharing now)\n", share.ExpiresAt.Format("Jan 2 15:04"))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	select {
+	case <-time.After(time.Until(share.ExpiresAt)):
+		fmt.Println("⏳ Share expired")
+	case <-interrupt:
+		fmt.Println("🛑 Stopping share")
+	}
+
+	if err := client.StopShare(ctx, tunnelManager, share); err != nil {
+		flushEvents(client)
+		log.Fatalf("❌ %v", err)
+	}
+	state.RemoveTemporaryHostname(share.Hostname)
+	if err := state.Save(); err != nil {
+		log.Printf("Warning: Failed to save state: %v", err)
+	}
+	fmt.Printf("🧹 Removed %s and its DNS record\n", share.Hostname)
+}
+
+func printGCSection(verb, label string, items []string) {
+	fmt.Printf("🧹 %s %d %s\n", verb, len(items), label)
+	for _, item := range items {
+		fmt.Printf("   - %s\n", item)
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -114,18 +823,58 @@ func min(a, b int) int {
 func main() {
 	versionFlag := flag.Bool("version", false, "Show version information")
 	helpFlag := flag.Bool("help", false, "Show help information")
+	configDirFlag := flag.String("config-dir", "", "Directory holding config.json (default $XDG_CONFIG_HOME/tunnelman or ~/.tunnelman)")
+	stateDirFlag := flag.String("state-dir", "", "Directory for tunnelman state (default $XDG_STATE_HOME/tunnelman or ~/.tunnelman)")
+	cloudflaredDirFlag := flag.String("cloudflared-dir", "", "Directory holding cloudflared configs and credentials (default ~/.cloudflared)")
+	keyFileFlag := flag.String("key-file", "", "File holding the passphrase of an encrypted config.json")
+	noStoreFlag := flag.Bool("no-store", false, "Prompt for the API token and keep it in memory only")
 	flag.Parse()
 
+	models.SetConfigDir(*configDirFlag)
+	models.SetStateDir(*stateDirFlag)
+	models.SetCloudflaredDir(*cloudflaredDirFlag)
+	models.SetConfigPassphraseSource(configPassphraseSource(*keyFileFlag))
+
 	// Check for subcommands
 	args := flag.Args()
 	if len(args) > 0 {
 		switch args[0] {
 		case "config":
-			runConfigCommand()
+			runConfigCommand(args[1:], *keyFileFlag)
+			return
+		case "uptime":
+			runUptimeCommand(args[1:])
+			return
+		case "watch":
+			runWatchCommand(args[1:])
+			return
+		case "gc":
+			runGCCommand(args[1:])
+			return
+		case "docs":
+			runDocsCommand(args[1:])
+			return
+		case "share":
+			runShareCommand(args[1:])
+			return
+		case "audit":
+			runAuditCommand(args[1:])
+			return
+		case "apply-snippet":
+			runApplySnippetCommand(args[1:])
+			return
+		case "dns-check":
+			runDNSCheckCommand(args[1:])
+			return
+		case "terraform":
+			runTerraformCommand(args[1:])
+			return
+		case "handoff":
+			runHandoffCommand(args[1:])
 			return
 		default:
 			fmt.Printf("Unknown command: %s\n", args[0])
-			fmt.Println("Available commands: config")
+			fmt.Println("Available commands: config, uptime, watch, gc, docs, share, apply-snippet, audit, dns-check, terraform, handoff")
 			os.Exit(1)
 		}
 	}
@@ -136,14 +885,34 @@ func main() {
 		fmt.Println("Usage:")
 		fmt.Println("  tunnelman [options]")
 		fmt.Println("  tunnelman config         Interactive configuration setup")
+		fmt.Println("  tunnelman config encrypt Encrypt config.json with a passphrase (or -key-file)")
+		fmt.Println("  tunnelman config decrypt Store config.json as plain JSON again")
+		fmt.Println("  tunnelman config export  Bundle config, state and cloudflared YAMLs into an encrypted file (-o file, -no-token)")
+		fmt.Println("  tunnelman config import <bundle>  Unpack an exported bundle on this machine (-force)")
+		fmt.Println("  tunnelman uptime         Show tunnel uptime report (-days N)")
+		fmt.Println("  tunnelman watch          Remove temporary hostnames when they expire")
+		fmt.Println("  tunnelman docs <tunnel>  Print Markdown documentation for a tunnel's ingress (-o file)")
+		fmt.Println("  tunnelman gc             Remove tunnels, DNS, configs and containers by name (-prefix P, -zone Z, -dry-run)")
+		fmt.Println("  tunnelman share <path>   Share a file or folder on a password-protected hostname (-tunnel T, -ttl 1h)")
+		fmt.Println("  tunnelman apply-snippet <tunnel>  Print the tunnel's hostnames as apply YAML (-o file)")
+		fmt.Println("  tunnelman audit          Flag risky exposures; exits 1 on high severity findings")
+		fmt.Println("  tunnelman dns-check <hostname>  Compare public resolvers' answers with the tunnel CNAME (-tunnel T)")
+		fmt.Println("  tunnelman terraform [tunnel...]  Print tunnels, ingress and DNS records as Terraform HCL with import blocks (-o file)")
+		fmt.Println("  tunnelman handoff -to <key> <tunnel>  Encrypt a tunnel's token, config and docs to a teammate's age or SSH key (-o file)")
+		fmt.Println("  tunnelman handoff open <bundle>  Decrypt a handoff into ~/.cloudflared (-i identity, -dir D, -force)")
 		fmt.Println()
 		fmt.Println("Options:")
-		fmt.Println("  -help      Show this help information")
-		fmt.Println("  -version   Show version information")
+		fmt.Println("  -help                 Show this help information")
+		fmt.Println("  -version              Show version information")
+		fmt.Println("  -config-dir DIR       Read config.json from DIR")
+		fmt.Println("  -state-dir DIR        Keep the uptime log and other state in DIR")
+		fmt.Println("  -cloudflared-dir DIR  Use DIR instead of ~/.cloudflared")
+		fmt.Println("  -key-file FILE        Read the passphrase of an encrypted config.json from FILE")
+		fmt.Println("  -no-store             Prompt for the API token at startup and never write it to disk")
 		fmt.Println()
 		fmt.Println("Configuration:")
 		fmt.Println("  Run 'tunnelman config' for interactive setup")
-		fmt.Println("  Or edit ~/.tunnelman/config.json manually")
+		fmt.Println("  Or edit " + models.GetConfigPath() + " manually")
 		fmt.Println()
 		fmt.Println("For more information, visit: https://github.com/justingosan/tunnelman")
 		os.Exit(0)
@@ -155,10 +924,22 @@ func main() {
 	}
 	config, err := models.LoadConfig()
 	if err != nil {
+		// Falling back to defaults would offer setup and overwrite the encrypted file
+		if models.ConfigEncrypted() {
+			log.Fatalf("❌ %v", err)
+		}
 		log.Printf("Warning: Failed to load config: %v", err)
 		config = models.DefaultConfig()
 	}
 
+	if *noStoreFlag {
+		token := promptSecret("🔑 Cloudflare API token (kept in memory only): ")
+		if token == "" {
+			log.Fatalf("❌ An API token is required with -no-store")
+		}
+		config.UseSessionToken(token)
+	}
+
 	// Check if we need to prompt for configuration
 	needsConfig := config.CloudflareAPIKey == ""
 
@@ -179,6 +960,11 @@ func main() {
 		}
 	}
 
+	// Interactive setup saves the token, which -no-store promises not to do
+	if needsConfig && config.SessionToken() {
+		log.Fatalf("❌ The API token was rejected; nothing was saved. Run again with a valid token.")
+	}
+
 	// If config is needed, prompt for interactive setup
 	if needsConfig {
 		fmt.Println("")
@@ -216,13 +1002,33 @@ func main() {
 		fmt.Println("")
 	}
 
-	state := models.NewAppState()
+	state, err := models.LoadAppState(config.TunnelConfigPath)
+	if err != nil {
+		log.Printf("Warning: Failed to load state: %v", err)
+		state = models.NewAppState()
+		state.ConfigPath = config.TunnelConfigPath
+	}
 	tunnelManager := models.NewTunnelManager(client, "")
+	if err := tunnelManager.ConfigureSupervisors(config.TunnelSupervisors); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := tunnelManager.ConfigureLogLevels(config.LogLevel, config.TunnelLogLevels); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := tunnelManager.ConfigureRunArgs(config.TunnelRunArgs); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
-	model := views.NewModel(state, client, tunnelManager)
+	model := views.NewModel(state, client, tunnelManager, config)
 
 	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	if !config.SkipConfigDiff {
+		client.SetConfigUpdateConfirmer(views.ConfigDiffConfirmer(p))
+	}
+	client.SetWarningReporter(views.WarningReporter(p))
+	_, err = p.Run()
+	flushEvents(client)
+	if err != nil {
 		log.Fatal(err)
 	}
 }
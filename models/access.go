@@ -0,0 +1,128 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Access application types. "ssh" and "vnc" applications are rendered in the
+// browser by Cloudflare, giving an in-browser terminal or VNC viewer.
+const (
+	AccessAppSelfHosted = "self_hosted"
+	AccessAppSSH        = "ssh"
+	AccessAppVNC        = "vnc"
+)
+
+type AccessApplication struct {
+	ID              string `json:"id,omitempty"`
+	Name            string `json:"name"`
+	Domain          string `json:"domain"`
+	Type            string `json:"type"`
+	SessionDuration string `json:"session_duration,omitempty"`
+}
+
+// BrowserRenderingType returns the Access application type used to render the
+// given service in the browser, or "" if the service can't be browser rendered
+func BrowserRenderingType(service string) string {
+	switch {
+	case strings.HasPrefix(service, "ssh://"):
+		return AccessAppSSH
+	case strings.HasPrefix(service, "vnc://"):
+		return AccessAppVNC
+	}
+	return ""
+}
+
+// ListAccessApplications returns all Access applications for the account
+func (c *CloudflareClient) ListAccessApplications(ctx context.Context) ([]AccessApplication, error) {
+	if c.accountID == "" {
+		return nil, fmt.Errorf("account ID not available")
+	}
+
+	var apps []AccessApplication
+	path := fmt.Sprintf("/accounts/%s/access/apps", c.accountID)
+	if err := c.apiRequest(ctx, "GET", path, nil, &apps); err != nil {
+		return nil, fmt.Errorf("failed to list Access applications: %w", err)
+	}
+
+	return apps, nil
+}
+
+// findAccessApplication returns the Access application protecting hostname, if any
+func findAccessApplication(apps []AccessApplication, hostname string) *AccessApplication {
+	for i := range apps {
+		if strings.TrimSuffix(apps[i].Domain, "/") == hostname {
+			return &apps[i]
+		}
+	}
+	return nil
+}
+
+// AnnotateBrowserRendering sets BrowserRendering on hostnames whose Access
+// application is browser rendered. Lookup failures are ignored since the
+// Access API requires extra token permissions.
+func (c *CloudflareClient) AnnotateBrowserRendering(ctx context.Context, hostnames []PublicHostname) {
+	needsLookup := false
+	for _, hostname := range hostnames {
+		if BrowserRenderingType(hostname.Service) != "" {
+			needsLookup = true
+			break
+		}
+	}
+	if !needsLookup {
+		return
+	}
+
+	apps, err := c.ListAccessApplications(ctx)
+	if err != nil {
+		return
+	}
+
+	for i := range hostnames {
+		app := findAccessApplication(apps, hostnames[i].Hostname)
+		hostnames[i].BrowserRendering = app != nil && (app.Type == AccessAppSSH || app.Type == AccessAppVNC)
+	}
+}
+
+// ToggleBrowserRendering switches the Access application for an ssh:// or
+// vnc:// hostname between browser rendered and plain self-hosted. The API
+// replaces the whole application on update, so it's fetched as raw JSON and
+// written back with only its type changed, keeping policies, identity
+// providers and the rest. Hostnames without an application are refused: a
+// new one would have no policy and lock everyone out.
+func (c *CloudflareClient) ToggleBrowserRendering(ctx context.Context, hostname PublicHostname) (*PublicHostname, error) {
+	renderType := BrowserRenderingType(hostname.Service)
+	if renderType == "" {
+		return nil, fmt.Errorf("browser rendering is only available for ssh:// and vnc:// services")
+	}
+
+	apps, err := c.ListAccessApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	app := findAccessApplication(apps, hostname.Hostname)
+	if app == nil {
+		return nil, fmt.Errorf("no Access application protects %s - create one with a policy in Zero Trust first", hostname.Hostname)
+	}
+
+	path := fmt.Sprintf("/accounts/%s/access/apps/%s", c.accountID, app.ID)
+	var full map[string]interface{}
+	if err := c.apiRequest(ctx, "GET", path, nil, &full); err != nil {
+		return nil, fmt.Errorf("failed to get Access application: %w", err)
+	}
+
+	newType := renderType
+	if full["type"] == AccessAppSSH || full["type"] == AccessAppVNC {
+		newType = AccessAppSelfHosted
+	}
+	full["type"] = newType
+
+	if err := c.apiRequest(ctx, "PUT", path, full, nil); err != nil {
+		return nil, fmt.Errorf("failed to update Access application: %w", err)
+	}
+
+	hostname.BrowserRendering = newType != AccessAppSelfHosted
+	return &hostname, nil
+}
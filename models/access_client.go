@@ -0,0 +1,138 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// accessProcessPrefix namespaces access clients in the TunnelManager process
+// table so they can't collide with tunnel names
+const accessProcessPrefix = "access:"
+
+// AccessProcessKey returns the process table key for hostname's access client
+func AccessProcessKey(hostname string) string {
+	return accessProcessPrefix + hostname
+}
+
+// StartAccessTCP runs `cloudflared access tcp` for a tcp:// hostname,
+// forwarding localhost:localPort to it. If localPort is 0 the service's own
+// port is used when free, otherwise a random free port is picked.
+func (tm *TunnelManager) StartAccessTCP(hostname, service string, localPort int) (*TunnelProcess, error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	key := AccessProcessKey(hostname)
+	if process, exists := tm.processes[key]; exists && process.IsRunning() {
+		return process, nil
+	}
+
+	if localPort == 0 {
+		port, err := pickAccessPort(service)
+		if err != nil {
+			return nil, err
+		}
+		localPort = port
+	}
+
+	args := []string{"access", "tcp", "--hostname", hostname, "--url", fmt.Sprintf("localhost:%d", localPort)}
+	// The access client must outlive the command that started it
+	cmd := exec.CommandContext(context.Background(), "cloudflared", args...)
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start access client: %w", err)
+	}
+
+	process := &TunnelProcess{
+		PID:       cmd.Process.Pid,
+		TunnelID:  hostname,
+		Name:      key,
+		Command:   append([]string{"cloudflared"}, args...),
+		StartTime: time.Now(),
+		Status:    StatusActive,
+		Process:   cmd.Process,
+	}
+
+	tm.processes[key] = process
+
+	go tm.monitorProcess(key, cmd)
+
+	return process, nil
+}
+
+// StopAccessTCP stops the access client for hostname if one is running
+func (tm *TunnelManager) StopAccessTCP(hostname string) error {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	key := AccessProcessKey(hostname)
+	process, exists := tm.processes[key]
+	if !exists {
+		return nil
+	}
+
+	delete(tm.processes, key)
+	if !process.IsRunning() {
+		return nil
+	}
+	return tm.stopProcess(process)
+}
+
+// GetAccessProcess returns the running access client for hostname, if any
+func (tm *TunnelManager) GetAccessProcess(hostname string) (*TunnelProcess, bool) {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+
+	process, exists := tm.processes[AccessProcessKey(hostname)]
+	if !exists || !process.IsRunning() {
+		return nil, false
+	}
+	return process, true
+}
+
+// StopAccessProcesses stops every access client, e.g. when the TUI exits
+func (tm *TunnelManager) StopAccessProcesses() {
+	for key := range tm.GetAllProcesses() {
+		if strings.HasPrefix(key, accessProcessPrefix) {
+			tm.StopAccessTCP(strings.TrimPrefix(key, accessProcessPrefix))
+		}
+	}
+}
+
+// LocalAddress returns the localhost address an access client listens on
+func (tp *TunnelProcess) LocalAddress() string {
+	for i, arg := range tp.Command {
+		if arg == "--url" && i+1 < len(tp.Command) {
+			return tp.Command[i+1]
+		}
+	}
+	return ""
+}
+
+// pickAccessPort prefers the port from the service URL so clients can keep
+// their usual port, falling back to any free port
+func pickAccessPort(service string) (int, error) {
+	if u, err := url.Parse(service); err == nil && u.Port() != "" {
+		if port, err := strconv.Atoi(u.Port()); err == nil {
+			if listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port)); err == nil {
+				listener.Close()
+				return port, nil
+			}
+		}
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find available port: %w", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/agessh"
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrNoMatchingIdentity is returned when none of the identities given can
+// open an age file
+var ErrNoMatchingIdentity = errors.New("no identity matched any of the file's recipients")
+
+// AgeRecipient is a public key an age file key can be wrapped to, with the
+// key it was parsed from for display
+type AgeRecipient struct {
+	age.Recipient
+	text string
+}
+
+func (r AgeRecipient) String() string { return r.text }
+
+// AgeIdentity is a private key that can unwrap an age file key
+type AgeIdentity = age.Identity
+
+// ParseAgeRecipient parses an age1… X25519 recipient or an ssh-ed25519 or
+// ssh-rsa public key in authorized_keys format
+func ParseAgeRecipient(key string) (AgeRecipient, error) {
+	key = strings.TrimSpace(key)
+	if strings.HasPrefix(key, "age1") {
+		recipient, err := age.ParseX25519Recipient(key)
+		if err != nil {
+			return AgeRecipient{}, fmt.Errorf("malformed age recipient: %w", err)
+		}
+		return AgeRecipient{Recipient: recipient, text: key}, nil
+	}
+
+	pubKey, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return AgeRecipient{}, fmt.Errorf("not an age recipient or SSH public key: %w", err)
+	}
+	recipient, err := agessh.ParseRecipient(string(ssh.MarshalAuthorizedKey(pubKey)))
+	if err != nil {
+		return AgeRecipient{}, fmt.Errorf("unsupported SSH key, use ssh-ed25519 or ssh-rsa: %w", err)
+	}
+	text := pubKey.Type()
+	if comment != "" {
+		text += " " + comment
+	}
+	return AgeRecipient{Recipient: recipient, text: text}, nil
+}
+
+// ParseAgeRecipients parses one recipient per line, skipping blank lines and
+// # comments, as in an age recipients file or an authorized_keys file
+func ParseAgeRecipients(data []byte) ([]AgeRecipient, error) {
+	var recipients []AgeRecipient
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		recipient, err := ParseAgeRecipient(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		recipients = append(recipients, recipient)
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipients found")
+	}
+	return recipients, nil
+}
+
+// ParseAgeIdentities parses an age identity file (AGE-SECRET-KEY-1… lines)
+// or an OpenSSH ed25519 or RSA private key. passphrase is called when the
+// SSH key is encrypted.
+func ParseAgeIdentities(data []byte, passphrase func() ([]byte, error)) ([]AgeIdentity, error) {
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		identities, err := age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse age identities: %w", err)
+		}
+		return identities, nil
+	}
+
+	key, err := ssh.ParseRawPrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == nil {
+			return nil, fmt.Errorf("SSH key is encrypted")
+		}
+		secret, perr := passphrase()
+		if perr != nil {
+			return nil, perr
+		}
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(data, secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH key: %w", err)
+	}
+
+	var identity AgeIdentity
+	switch k := key.(type) {
+	case *ed25519.PrivateKey:
+		identity, err = agessh.NewEd25519Identity(*k)
+	case ed25519.PrivateKey:
+		identity, err = agessh.NewEd25519Identity(k)
+	case *rsa.PrivateKey:
+		identity, err = agessh.NewRSAIdentity(k)
+	default:
+		return nil, fmt.Errorf("unsupported SSH key type %T, use ed25519 or RSA", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH key: %w", err)
+	}
+	return []AgeIdentity{identity}, nil
+}
+
+// AgeEncrypt encrypts data to every recipient
+func AgeEncrypt(data []byte, recipients ...AgeRecipient) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipients given")
+	}
+	ageRecipients := make([]age.Recipient, len(recipients))
+	for i, recipient := range recipients {
+		ageRecipients[i] = recipient.Recipient
+	}
+
+	var out bytes.Buffer
+	w, err := age.Encrypt(&out, ageRecipients...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// AgeDecrypt decrypts an age file with the first identity that matches one
+// of its recipients
+func AgeDecrypt(data []byte, identities ...AgeIdentity) ([]byte, error) {
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, ErrNoMatchingIdentity
+		}
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ApplySpec is the declarative form of tunnels and their public hostnames
+type ApplySpec struct {
+	Tunnels []ApplyTunnel `yaml:"tunnels"`
+}
+
+// ApplyTunnel lists the hostnames a tunnel should serve
+type ApplyTunnel struct {
+	Name      string          `yaml:"name"`
+	Hostnames []ApplyHostname `yaml:"hostnames"`
+}
+
+// ApplyHostname is one public hostname of an ApplyTunnel. Service is the real
+// origin; auth-guarded hostnames point at their Traefik proxy in the tunnel
+// config, which is recreated from Auth rather than recorded here.
+type ApplyHostname struct {
+	Hostname         string `yaml:"hostname"`
+	Path             string `yaml:"path,omitempty"`
+	Service          string `yaml:"service"`
+	Auth             bool   `yaml:"auth,omitempty"`
+	BrowserRendering bool   `yaml:"browser_rendering,omitempty"`
+}
+
+// NewApplyTunnel describes a tunnel's current hostnames as an ApplyTunnel
+func NewApplyTunnel(tunnelName string, hostnames []PublicHostname) ApplyTunnel {
+	tunnel := ApplyTunnel{Name: tunnelName, Hostnames: []ApplyHostname{}}
+	for _, hostname := range hostnames {
+		service := hostname.Service
+		if hostname.AuthEnabled && hostname.OriginalService != "" {
+			service = hostname.OriginalService
+		}
+		tunnel.Hostnames = append(tunnel.Hostnames, ApplyHostname{
+			Hostname:         hostname.Hostname,
+			Path:             hostname.Path,
+			Service:          service,
+			Auth:             hostname.AuthEnabled,
+			BrowserRendering: hostname.BrowserRendering,
+		})
+	}
+	return tunnel
+}
+
+// ApplySnippet renders a tunnel's current hostnames as tunnelman apply YAML,
+// ready to paste into an existing spec or use as a new one
+func ApplySnippet(tunnelName string, hostnames []PublicHostname) (string, error) {
+	spec := ApplySpec{Tunnels: []ApplyTunnel{NewApplyTunnel(tunnelName, hostnames)}}
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to render apply YAML: %w", err)
+	}
+	return string(data), nil
+}
+
+// GenerateApplySnippet looks up a tunnel by name or ID and renders its
+// hostnames with ApplySnippet
+func (c *CloudflareClient) GenerateApplySnippet(ctx context.Context, nameOrID string) (string, error) {
+	tunnel, err := c.GetTunnelInfo(ctx, nameOrID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find tunnel %s: %w", nameOrID, err)
+	}
+
+	hostnames, err := c.GetPublicHostnames(ctx, tunnel.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostnames for %s: %w", tunnel.Name, err)
+	}
+
+	return ApplySnippet(tunnel.Name, hostnames)
+}
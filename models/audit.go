@@ -0,0 +1,202 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AuditSeverity ranks how risky an audit finding is
+type AuditSeverity int
+
+const (
+	AuditLow AuditSeverity = iota
+	AuditMedium
+	AuditHigh
+)
+
+func (s AuditSeverity) String() string {
+	switch s {
+	case AuditHigh:
+		return "HIGH"
+	case AuditMedium:
+		return "MEDIUM"
+	}
+	return "LOW"
+}
+
+// AuditFinding is one risky exposure found by Audit. TunnelID is empty for
+// findings about DNS records rather than tunnel hostnames.
+type AuditFinding struct {
+	Severity   AuditSeverity
+	Hostname   string
+	TunnelID   string
+	TunnelName string
+	Message    string
+}
+
+// AuditReport is the result of Audit. Notes lists checks that couldn't run,
+// typically because the API token lacks a permission.
+type AuditReport struct {
+	Findings  []AuditFinding
+	Notes     []string
+	Hostnames int
+}
+
+// HighCount returns the number of high severity findings
+func (r *AuditReport) HighCount() int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == AuditHigh {
+			count++
+		}
+	}
+	return count
+}
+
+// adminPorts are well-known ports of remote access, database and management
+// services that shouldn't be reachable without Access in front of them
+var adminPorts = map[int]string{
+	22:    "SSH",
+	2375:  "Docker API",
+	2376:  "Docker API",
+	3306:  "MySQL",
+	3389:  "RDP",
+	5432:  "PostgreSQL",
+	5900:  "VNC",
+	6379:  "Redis",
+	6443:  "Kubernetes API",
+	8006:  "Proxmox",
+	9000:  "Portainer",
+	9090:  "Cockpit",
+	9200:  "Elasticsearch",
+	10000: "Webmin",
+	27017: "MongoDB",
+}
+
+// defaultServicePorts are the ports implied by service schemes that don't
+// spell one out
+var defaultServicePorts = map[string]int{
+	"ssh": 22,
+	"rdp": 3389,
+	"vnc": 5900,
+}
+
+// serviceAdminPort returns the port and name of the admin service a tunnel
+// service points at, or an empty name if it isn't one
+func serviceAdminPort(service string) (int, string) {
+	u, err := url.Parse(service)
+	if err != nil || u.Host == "" {
+		return 0, ""
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		port = defaultServicePorts[u.Scheme]
+	}
+	if name, ok := adminPorts[port]; ok {
+		return port, name
+	}
+	return 0, ""
+}
+
+// accessAppCovers reports whether an Access application protects hostname,
+// either exactly or through a wildcard domain such as *.example.com
+func accessAppCovers(apps []AccessApplication, hostname string) bool {
+	hostname = strings.ToLower(hostname)
+	for _, app := range apps {
+		domain := strings.ToLower(strings.TrimSuffix(app.Domain, "/"))
+		if strings.Contains(domain, "/") {
+			// Path-scoped apps leave the rest of the hostname open
+			continue
+		}
+		if domain == hostname {
+			return true
+		}
+		if strings.HasPrefix(domain, "*.") && strings.HasSuffix(hostname, domain[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Audit reviews the public hostnames of tunnels and the DNS records of every
+// zone for risky exposures: hostnames with neither Access nor basic auth,
+// services on well-known admin ports, wildcard hostnames, and unproxied
+// records that publish an origin address
+func (c *CloudflareClient) Audit(ctx context.Context, tunnels []CLITunnel) *AuditReport {
+	report := &AuditReport{}
+
+	hostnames, failures := c.ListAllPublicHostnames(ctx, tunnels)
+	report.Hostnames = len(hostnames)
+	for tunnelID, err := range failures {
+		report.Notes = append(report.Notes, fmt.Sprintf("skipped tunnel %s: %v", tunnelID, err))
+	}
+
+	apps, err := c.ListAccessApplications(ctx)
+	accessChecked := err == nil
+	if err != nil {
+		report.Notes = append(report.Notes, fmt.Sprintf("Access applications not checked: %v", err))
+	}
+
+	for _, hostname := range hostnames {
+		add := func(severity AuditSeverity, message string) {
+			report.Findings = append(report.Findings, AuditFinding{
+				Severity:   severity,
+				Hostname:   hostname.Hostname + hostname.Path,
+				TunnelID:   hostname.TunnelID,
+				TunnelName: hostname.TunnelName,
+				Message:    message,
+			})
+		}
+
+		service := hostname.Service
+		if hostname.AuthEnabled && hostname.OriginalService != "" {
+			service = hostname.OriginalService
+		}
+		protected := accessChecked && accessAppCovers(apps, hostname.Hostname)
+
+		if port, name := serviceAdminPort(service); name != "" && !protected {
+			add(AuditHigh, fmt.Sprintf("%s (port %d) exposed without Access: %s", name, port, service))
+		}
+		if accessChecked && !protected && !hostname.AuthEnabled {
+			add(AuditMedium, "no Access application or basic auth")
+		}
+		if strings.HasPrefix(hostname.Hostname, "*.") {
+			add(AuditMedium, "wildcard hostname routes every subdomain to "+service)
+		}
+	}
+
+	zones, err := c.ListDNSZones(ctx)
+	if err != nil {
+		report.Notes = append(report.Notes, fmt.Sprintf("DNS records not checked: %v", err))
+	} else {
+		for _, zone := range zones {
+			records, err := c.ListZoneDNSRecords(ctx, zone.ID)
+			if err != nil {
+				report.Notes = append(report.Notes, fmt.Sprintf("DNS records of %s not checked: %v", zone.Name, err))
+				continue
+			}
+			for _, record := range records {
+				if record.CanBeProxied() && !record.Proxied {
+					report.Findings = append(report.Findings, AuditFinding{
+						Severity: AuditLow,
+						Hostname: record.Name,
+						Message:  fmt.Sprintf("unproxied %s record publishes %s", record.Type, record.Content),
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		if report.Findings[i].Severity != report.Findings[j].Severity {
+			return report.Findings[i].Severity > report.Findings[j].Severity
+		}
+		return report.Findings[i].Hostname < report.Findings[j].Hostname
+	})
+
+	return report
+}
@@ -3,10 +3,69 @@ package models
 import (
 	"crypto/rand"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// AuthUsername is the login created when auth is first enabled for a hostname
+const AuthUsername = "tunnelman"
+
+// AuthUser is one basic auth login of a protected hostname. Only the bcrypt
+// hash is kept; the password is shown once, when it is generated.
+type AuthUser struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+}
+
+// NewAuthUser generates a password for name, returning the user and the
+// plain password
+func NewAuthUser(name string) (AuthUser, string, error) {
+	password, err := GenerateRandomPassword(6)
+	if err != nil {
+		return AuthUser{}, "", err
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return AuthUser{}, "", err
+	}
+	return AuthUser{Name: name, Hash: hash}, password, nil
+}
+
+// ValidateAuthUsername rejects names basic auth can't carry and names
+// already in users
+func ValidateAuthUsername(name string, users []AuthUser) error {
+	if name == "" {
+		return fmt.Errorf("username is required")
+	}
+	if strings.ContainsAny(name, ": \t") {
+		return fmt.Errorf("username can't contain colons or spaces")
+	}
+	for _, user := range users {
+		if user.Name == name {
+			return fmt.Errorf("%s already has a login", name)
+		}
+	}
+	return nil
+}
+
+// AuthUsersFor returns the logins recorded for a protected hostname
+func (s *AppState) AuthUsersFor(hostname string) []AuthUser {
+	return s.AuthUsers[hostname]
+}
+
+// SetAuthUsers records a hostname's logins, forgetting it when users is empty
+func (s *AppState) SetAuthUsers(hostname string, users []AuthUser) {
+	if len(users) == 0 {
+		delete(s.AuthUsers, hostname)
+		return
+	}
+	if s.AuthUsers == nil {
+		s.AuthUsers = make(map[string][]AuthUser)
+	}
+	s.AuthUsers[hostname] = users
+}
+
 // GenerateRandomPassword generates a random password of specified length
 func GenerateRandomPassword(length int) (string, error) {
 	if length < 4 {
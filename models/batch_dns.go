@@ -0,0 +1,276 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+const (
+	// DefaultDNSWorkers is the number of DNS records created concurrently during a batch
+	DefaultDNSWorkers = 4
+	// dnsRequestInterval spaces out DNS API calls to stay well under Cloudflare's
+	// 1200 requests per 5 minutes limit
+	dnsRequestInterval = 250 * time.Millisecond
+	// dnsMaxRetries is how many times a rate-limited record is retried before giving up
+	dnsMaxRetries = 3
+)
+
+// DNSResult is the outcome of creating the DNS record for a single hostname
+type DNSResult struct {
+	Hostname string
+	Err      error
+}
+
+// dnsLimiter hands out evenly spaced request slots shared by all batch workers
+type dnsLimiter struct {
+	ticker *time.Ticker
+}
+
+func newDNSLimiter(interval time.Duration) *dnsLimiter {
+	return &dnsLimiter{ticker: time.NewTicker(interval)}
+}
+
+// Wait blocks until the next request slot or until the context is cancelled
+func (l *dnsLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-l.ticker.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *dnsLimiter) Stop() {
+	l.ticker.Stop()
+}
+
+// CreateTunnelDNSRecords creates CNAME records pointing every hostname at the tunnel.
+// Records are created in parallel through a shared rate limiter, and rate-limited
+// requests are retried with backoff. One result is returned per hostname, in order.
+func (c *CloudflareClient) CreateTunnelDNSRecords(ctx context.Context, tunnelID string, hostnames []string, overwrite bool) []DNSResult {
+	results := make([]DNSResult, len(hostnames))
+	for i, hostname := range hostnames {
+		results[i].Hostname = hostname
+	}
+	if len(hostnames) == 0 {
+		return results
+	}
+
+	// Resolve zones up front with a single zone listing
+	zones, err := c.api.ListZones(ctx)
+	if err != nil {
+		for i := range results {
+			results[i].Err = fmt.Errorf("failed to list zones: %w", err)
+		}
+		return results
+	}
+
+	limiter := newDNSLimiter(dnsRequestInterval)
+	defer limiter.Stop()
+
+	workers := DefaultDNSWorkers
+	if len(hostnames) < workers {
+		workers = len(hostnames)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				hostname := hostnames[i]
+				zoneID, _ := matchZone(zones, hostname)
+				if zoneID == "" {
+					results[i].Err = fmt.Errorf("no zone found for hostname: %s", hostname)
+					continue
+				}
+				results[i].Err = c.createTunnelCNAMEWithRetry(ctx, limiter, zoneID, hostname, tunnelID, overwrite)
+			}
+		}()
+	}
+
+	for i := range hostnames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// createTunnelCNAMEWithRetry creates a tunnel CNAME, backing off and retrying when rate limited
+func (c *CloudflareClient) createTunnelCNAMEWithRetry(ctx context.Context, limiter *dnsLimiter, zoneID, hostname, tunnelID string, overwrite bool) error {
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		err := c.createTunnelCNAME(ctx, limiter, zoneID, hostname, tunnelID, overwrite)
+		if err == nil || !IsRateLimitError(err) || attempt >= dnsMaxRetries {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// createTunnelCNAME creates the CNAME for hostname in zoneID pointing at the tunnel,
+// replacing an existing record only when overwrite is set. When limiter is non-nil
+// every API call waits for a request slot first.
+func (c *CloudflareClient) createTunnelCNAME(ctx context.Context, limiter *dnsLimiter, zoneID, hostname, tunnelID string, overwrite bool) error {
+	wait := func() error {
+		if limiter == nil {
+			return nil
+		}
+		return limiter.Wait(ctx)
+	}
+
+	if err := wait(); err != nil {
+		return err
+	}
+	existingRecords, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
+		Name: hostname,
+		Type: "CNAME",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check existing DNS records: %w", err)
+	}
+
+	if len(existingRecords) > 0 {
+		if !overwrite {
+			return fmt.Errorf("DNS record for %s already exists. Use overwrite option to replace it", hostname)
+		}
+		// Delete existing record
+		for _, record := range existingRecords {
+			if err := wait(); err != nil {
+				return err
+			}
+			if err := c.api.DeleteDNSRecord(ctx, cloudflare.ZoneIdentifier(zoneID), record.ID); err != nil {
+				return fmt.Errorf("failed to delete existing DNS record: %w", err)
+			}
+			c.emit(ChangeEvent{Type: EventDNSDeleted, TunnelID: tunnelIDFromCNAME(record.Content), Hostname: hostname, Record: record.Content})
+		}
+	}
+
+	if err := wait(); err != nil {
+		return err
+	}
+	record := cloudflare.CreateDNSRecordParams{
+		Type:    "CNAME",
+		Name:    hostname,
+		Content: fmt.Sprintf("%s.cfargotunnel.com", tunnelID),
+		TTL:     1, // Auto TTL
+	}
+	if _, err := c.api.CreateDNSRecord(ctx, cloudflare.ZoneIdentifier(zoneID), record); err != nil {
+		return fmt.Errorf("failed to create DNS record: %w", err)
+	}
+	c.emit(ChangeEvent{Type: EventDNSCreated, TunnelID: tunnelID, Hostname: hostname, Record: record.Content})
+
+	return nil
+}
+
+// AddPublicHostnames adds several hostnames to a tunnel with a single configuration
+// update, then creates their DNS records in a batch. The configuration error is
+// returned directly; DNS failures are reported per hostname in the results,
+// one per entry in hostnames.
+func (c *CloudflareClient) AddPublicHostnames(ctx context.Context, tunnelID string, hostnames []PublicHostname, overwriteDNS bool) ([]DNSResult, error) {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxID := 0
+	existing := make(map[string]bool)
+	for _, ingress := range config.Config.Ingress {
+		if id, err := strconv.Atoi(ingress.ID); err == nil && id > maxID {
+			maxID = id
+		}
+		existing[ingress.Hostname+"|"+normalizeIngressPath(ingress.Path)] = true
+	}
+
+	var newRules []TunnelConfigIngress
+	var names []string
+	for _, h := range hostnames {
+		path := normalizeIngressPath(h.Path)
+		service := h.Service
+		if service == "" {
+			service = "http://localhost:8080"
+		}
+
+		key := h.Hostname + "|" + path
+		if existing[key] {
+			return nil, fmt.Errorf("hostname %s with path %s already exists", h.Hostname, path)
+		}
+		existing[key] = true
+
+		maxID++
+		rule := TunnelConfigIngress{
+			ID:            strconv.Itoa(maxID),
+			Hostname:      h.Hostname,
+			Service:       service,
+			OriginRequest: map[string]interface{}{},
+		}
+		if path != "*" {
+			rule.Path = path
+		}
+		newRules = append(newRules, rule)
+		names = append(names, h.Hostname)
+	}
+
+	if len(newRules) == 0 {
+		return nil, nil
+	}
+
+	// Insert before the catch-all rule, or append when there is none
+	catchAllIndex := len(config.Config.Ingress)
+	for i, ingress := range config.Config.Ingress {
+		if ingress.Hostname == "" {
+			catchAllIndex = i
+			break
+		}
+	}
+	ingress := append([]TunnelConfigIngress{}, config.Config.Ingress[:catchAllIndex]...)
+	ingress = append(ingress, newRules...)
+	config.Config.Ingress = append(ingress, config.Config.Ingress[catchAllIndex:]...)
+
+	if err := c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config); err != nil {
+		return nil, err
+	}
+
+	// A hostname routed on several paths needs only one record, which each
+	// of its rules reports
+	var unique []string
+	index := make(map[string]int)
+	for _, name := range names {
+		if _, seen := index[strings.ToLower(name)]; !seen {
+			index[strings.ToLower(name)] = len(unique)
+			unique = append(unique, name)
+		}
+	}
+	created := c.CreateTunnelDNSRecords(ctx, tunnelID, unique, overwriteDNS)
+
+	results := make([]DNSResult, len(names))
+	for i, name := range names {
+		results[i] = created[index[strings.ToLower(name)]]
+		results[i].Hostname = name
+	}
+	return results, nil
+}
+
+// normalizeIngressPath maps an empty path to the "*" wildcard used throughout the UI
+func normalizeIngressPath(path string) string {
+	if path == "" {
+		return "*"
+	}
+	return path
+}
@@ -0,0 +1,257 @@
+package models
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Top level directories of a config bundle
+const (
+	bundleConfigDir      = "config"
+	bundleStateDir       = "state"
+	bundleCloudflaredDir = "cloudflared"
+	bundleManifest       = "manifest.json"
+)
+
+// BundleManifest describes a config bundle. StateDir is where the state
+// came from, so paths in config.json that point into it can be moved.
+type BundleManifest struct {
+	ExportedAt   time.Time `json:"exported_at"`
+	StateDir     string    `json:"state_dir"`
+	TokenOmitted bool      `json:"token_omitted,omitempty"`
+	Files        []string  `json:"files"`
+}
+
+// ExportBundle packs config.json, the state directory (hostname state,
+// uptime log, Traefik configs) and the cloudflared YAML configs into a tar.gz
+// sealed with passphrase. The API token is blanked when omitToken is set.
+func ExportBundle(passphrase []byte, omitToken bool) ([]byte, *BundleManifest, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if omitToken {
+		config.CloudflareAPIKey = ""
+	}
+	configData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	manifest := &BundleManifest{ExportedAt: time.Now(), StateDir: GetStateDir(), TokenOmitted: omitToken}
+	files := map[string][]byte{path.Join(bundleConfigDir, DefaultConfigFile): configData}
+
+	configPath := GetConfigPath()
+	err = filepath.WalkDir(GetStateDir(), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// The config is added above, and the tunnel cache is rebuilt on first launch
+		if d.IsDir() || p == configPath || d.Name() == DefaultTunnelCacheFile {
+			return nil
+		}
+		return addBundleFile(files, bundleStateDir, GetStateDir(), p)
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to read state directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(GetCloudflaredDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to read cloudflared directory: %w", err)
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		if err := addBundleFile(files, bundleCloudflaredDir, GetCloudflaredDir(), filepath.Join(GetCloudflaredDir(), entry.Name())); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for name := range files {
+		manifest.Files = append(manifest.Files, name)
+	}
+	sort.Strings(manifest.Files)
+
+	archive, err := writeBundleArchive(manifest, files)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed, err := Seal(archive, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sealed, manifest, nil
+}
+
+func addBundleFile(files map[string][]byte, prefix, root, p string) error {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", p, err)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", p, err)
+	}
+	files[path.Join(prefix, filepath.ToSlash(rel))] = data
+	return nil
+}
+
+func writeBundleArchive(manifest *BundleManifest, files map[string][]byte) ([]byte, error) {
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	files[bundleManifest] = manifestData
+	return writeArchive(append([]string{bundleManifest}, manifest.Files...), files, manifest.ExportedAt)
+}
+
+// writeArchive tars and gzips files in the order of names
+func writeArchive(names []string, files map[string][]byte, modTime time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	write := func(name string, data []byte) error {
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: modTime}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	for _, name := range names {
+		if err := write(name, files[name]); err != nil {
+			return nil, fmt.Errorf("failed to write bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportBundle unpacks a bundle made by ExportBundle into this machine's
+// config, state and cloudflared directories. Existing files are left alone
+// and reported as skipped unless overwrite is set. A token omitted from the
+// bundle is taken from the existing config.
+func ImportBundle(sealed, passphrase []byte, overwrite bool) (written, skipped []string, err error) {
+	archive, err := Open(sealed, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest, files, err := readBundleArchive(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(files[path.Join(bundleConfigDir, DefaultConfigFile)], &config); err != nil {
+		return nil, nil, fmt.Errorf("bundle has no valid config.json: %w", err)
+	}
+	if _, statErr := os.Stat(GetConfigPath()); statErr == nil {
+		if existing, err := LoadConfig(); err == nil && config.CloudflareAPIKey == "" {
+			config.CloudflareAPIKey = existing.CloudflareAPIKey
+		}
+	}
+	// Directories configured on this machine win; otherwise the bundle's
+	// config.json decides where the rest is unpacked
+	config.applyDirectories()
+	if rel, err := filepath.Rel(manifest.StateDir, config.TunnelConfigPath); err == nil && !strings.HasPrefix(rel, "..") {
+		config.TunnelConfigPath = filepath.Join(GetStateDir(), rel)
+	}
+
+	roots := map[string]string{
+		bundleStateDir:       GetStateDir(),
+		bundleCloudflaredDir: GetCloudflaredDir(),
+	}
+	for _, name := range manifest.Files {
+		prefix, rel, _ := strings.Cut(name, "/")
+		root, ok := roots[prefix]
+		if !ok {
+			continue
+		}
+		target := filepath.Join(root, filepath.FromSlash(rel))
+		if !strings.HasPrefix(target, filepath.Clean(root)+string(filepath.Separator)) {
+			return written, skipped, fmt.Errorf("bundle entry %s escapes %s", name, root)
+		}
+		if _, err := os.Stat(target); err == nil && !overwrite {
+			skipped = append(skipped, target)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return written, skipped, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(target, files[name], 0600); err != nil {
+			return written, skipped, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		written = append(written, target)
+	}
+
+	if _, err := os.Stat(GetConfigPath()); err == nil && !overwrite {
+		skipped = append(skipped, GetConfigPath())
+	} else {
+		if err := config.Save(); err != nil {
+			return written, skipped, err
+		}
+		written = append(written, GetConfigPath())
+	}
+
+	return written, skipped, nil
+}
+
+func readBundleArchive(archive []byte) (*BundleManifest, map[string][]byte, error) {
+	files, err := readArchive(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var manifest BundleManifest
+	if err := json.Unmarshal(files[bundleManifest], &manifest); err != nil {
+		return nil, nil, fmt.Errorf("bundle has no valid manifest: %w", err)
+	}
+	return &manifest, files, nil
+}
+
+// readArchive unpacks a tar.gz into its files by name
+func readArchive(archive []byte) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		files[header.Name] = data
+	}
+	return files, nil
+}
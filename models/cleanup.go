@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultStaleTunnelDays is how long a tunnel must be idle before it is
+// offered for cleanup
+const DefaultStaleTunnelDays = 7
+
+// StaleAfter returns the configured idle period after which unused tunnels
+// are considered stale
+func (c *Config) StaleAfter() time.Duration {
+	days := DefaultStaleTunnelDays
+	if c != nil && c.StaleTunnelDays > 0 {
+		days = c.StaleTunnelDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// LastActivity returns the most recent time a tunnel was known to be in use:
+// its newest connection, or its creation time if it never connected
+func (t CLITunnel) LastActivity() time.Time {
+	last := t.CreatedAt
+	for _, conn := range t.Connections {
+		if conn.OpenedAt.After(last) {
+			last = conn.OpenedAt
+		}
+	}
+	return last
+}
+
+// FindStaleTunnels returns tunnels with no public hostnames, no connections
+// and no activity within olderThan. Tunnels whose hostname count is unknown
+// are never considered stale.
+func FindStaleTunnels(tunnels []CLITunnel, hostnameCounts map[string]int, olderThan time.Duration, now time.Time) []CLITunnel {
+	cutoff := now.Add(-olderThan)
+
+	var stale []CLITunnel
+	for _, tunnel := range tunnels {
+		count, known := hostnameCounts[tunnel.ID]
+		if !known || count > 0 || len(tunnel.Connections) > 0 {
+			continue
+		}
+		if tunnel.LastActivity().After(cutoff) {
+			continue
+		}
+		stale = append(stale, tunnel)
+	}
+
+	return stale
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNoClipboard is returned when there's no local clipboard to copy to,
+// such as over SSH or on a headless machine. Callers can fall back to OSC52.
+var ErrNoClipboard = errors.New("no system clipboard")
+
+// clipboardCommand returns the command that reads text for the system
+// clipboard from stdin, or nil when there isn't one
+func clipboardCommand() []string {
+	// Over SSH the local tools would copy on the remote machine
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return nil
+	}
+
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		if os.Getenv("DISPLAY") != "" {
+			candidates = append(candidates, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err == nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// CopyToClipboard puts text on the system clipboard through pbcopy, clip,
+// wl-copy, xclip or xsel, whichever this machine has
+func CopyToClipboard(text string) error {
+	command := clipboardCommand()
+	if command == nil {
+		return ErrNoClipboard
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("%s failed: %s", command[0], message)
+		}
+		return fmt.Errorf("%s failed: %w", command[0], err)
+	}
+	return nil
+}
+
+// OSC52 returns the escape sequence that asks the terminal to put text on
+// its clipboard, which works over SSH in terminals that allow it. Inside
+// tmux the sequence is wrapped so tmux passes it through.
+func OSC52(text string) string {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if os.Getenv("TMUX") != "" {
+		return "\x1bPtmux;\x1b" + sequence + "\x1b\\"
+	}
+	return sequence
+}
@@ -1,13 +1,16 @@
 package models
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudflare/cloudflare-go"
@@ -18,6 +21,18 @@ type CloudflareClient struct {
 	accountID      string
 	config         *Config
 	selectedDomain string
+	// confirmConfigUpdate, when set, must approve every ingress change
+	confirmConfigUpdate ConfigUpdateConfirmer
+	// teamName caches the Zero Trust team looked up from the API
+	teamName string
+	// reportWarning receives non-fatal problems instead of stdout
+	reportWarning WarningReporter
+	// events queues change events for the configured sinks; eventsDone is
+	// closed once the queue is closed and drained
+	eventsMu     sync.Mutex
+	events       chan ChangeEvent
+	eventsDone   chan struct{}
+	eventsClosed bool
 }
 
 type TunnelResponse struct {
@@ -30,10 +45,17 @@ type CLITunnel struct {
 	ID          string                `json:"id"`
 	Name        string                `json:"name"`
 	CreatedAt   time.Time             `json:"created_at"`
+	DeletedAt   time.Time             `json:"deleted_at"`
 	Connections []CLITunnelConnection `json:"conns,omitempty"`
 }
 
+// IsDeleted reports whether the tunnel was deleted but is still listed
+func (t CLITunnel) IsDeleted() bool {
+	return !t.DeletedAt.IsZero()
+}
+
 type CLITunnelConnection struct {
+	ClientID           string    `json:"client_id"`
 	ColoName           string    `json:"colo_name"`
 	ID                 string    `json:"id"`
 	IsPendingReconnect bool      `json:"is_pending_reconnect"`
@@ -67,13 +89,14 @@ type WarpRouting struct {
 }
 
 type PublicHostname struct {
-	ID              string `json:"id"`
-	Hostname        string `json:"hostname"`
-	Path            string `json:"path"`
-	Service         string `json:"service"`
-	AuthEnabled     bool   `json:"auth_enabled,omitempty"`
-	AuthPassword    string `json:"auth_password,omitempty"`
-	OriginalService string `json:"original_service,omitempty"`
+	ID               string `json:"id"`
+	Hostname         string `json:"hostname"`
+	Path             string `json:"path"`
+	Service          string `json:"service"`
+	AuthEnabled      bool   `json:"auth_enabled,omitempty"`
+	AuthPassword     string `json:"auth_password,omitempty"`
+	OriginalService  string `json:"original_service,omitempty"`
+	BrowserRendering bool   `json:"browser_rendering,omitempty"`
 }
 
 type DNSRecordRequest struct {
@@ -184,17 +207,24 @@ func (c *CloudflareClient) GetAccountID() string {
 
 // Tunnel Management via CLI
 
-func (c *CloudflareClient) execCommand(name string, args ...string) ([]byte, error) {
-	cmd := exec.Command(name, args...)
+func (c *CloudflareClient) execCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		if name == "cloudflared" && isNotLoggedInOutput(string(output)) {
+			return output, fmt.Errorf("%s %v: %w", name, args, ErrNotLoggedIn)
+		}
 		return output, fmt.Errorf("command failed: %s %v - %s", name, args, string(output))
 	}
 	return output, nil
 }
 
 func (c *CloudflareClient) ListTunnels(ctx context.Context) ([]CLITunnel, error) {
-	output, err := c.execCommand("cloudflared", "tunnel", "--output", "json", "list")
+	if c.config.APIOnly {
+		return c.listTunnelsAPI(ctx)
+	}
+
+	output, err := c.execCommand(ctx, "cloudflared", "tunnel", "--output", "json", "list")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tunnels: %w", err)
 	}
@@ -204,11 +234,23 @@ func (c *CloudflareClient) ListTunnels(ctx context.Context) ([]CLITunnel, error)
 		return nil, fmt.Errorf("failed to parse tunnel list: %w", err)
 	}
 
-	return tunnels, nil
+	// Recently deleted tunnels can still show up in the list
+	live := tunnels[:0]
+	for _, tunnel := range tunnels {
+		if !tunnel.IsDeleted() {
+			live = append(live, tunnel)
+		}
+	}
+
+	return live, nil
 }
 
 func (c *CloudflareClient) CreateTunnel(ctx context.Context, name string) (*CLITunnel, error) {
-	output, err := c.execCommand("cloudflared", "tunnel", "--output", "json", "create", name)
+	if c.config.APIOnly {
+		return nil, fmt.Errorf("creating tunnels needs cloudflared credentials - run 'cloudflared tunnel login' or create the tunnel in the dashboard")
+	}
+
+	output, err := c.execCommand(ctx, "cloudflared", "tunnel", "--output", "json", "create", name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tunnel: %w", err)
 	}
@@ -222,7 +264,11 @@ func (c *CloudflareClient) CreateTunnel(ctx context.Context, name string) (*CLIT
 }
 
 func (c *CloudflareClient) DeleteTunnel(ctx context.Context, nameOrID string) error {
-	_, err := c.execCommand("cloudflared", "tunnel", "delete", nameOrID)
+	if c.config.APIOnly {
+		return c.deleteTunnelAPI(ctx, nameOrID)
+	}
+
+	_, err := c.execCommand(ctx, "cloudflared", "tunnel", "delete", nameOrID)
 	if err != nil {
 		return fmt.Errorf("failed to delete tunnel: %w", err)
 	}
@@ -230,7 +276,11 @@ func (c *CloudflareClient) DeleteTunnel(ctx context.Context, nameOrID string) er
 }
 
 func (c *CloudflareClient) GetTunnelInfo(ctx context.Context, nameOrID string) (*CLITunnel, error) {
-	output, err := c.execCommand("cloudflared", "tunnel", "--output", "json", "info", nameOrID)
+	if c.config.APIOnly {
+		return c.findTunnelAPI(ctx, nameOrID)
+	}
+
+	output, err := c.execCommand(ctx, "cloudflared", "tunnel", "--output", "json", "info", nameOrID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tunnel info: %w", err)
 	}
@@ -263,10 +313,11 @@ func (c *CloudflareClient) StopTunnel(ctx context.Context, nameOrID string) erro
 }
 
 func (c *CloudflareClient) RouteDNS(ctx context.Context, tunnelName, hostname string) error {
-	_, err := c.execCommand("cloudflared", "tunnel", "route", "dns", tunnelName, hostname)
+	_, err := c.execCommand(ctx, "cloudflared", "tunnel", "route", "dns", tunnelName, hostname)
 	if err != nil {
 		return fmt.Errorf("failed to route DNS: %w", err)
 	}
+	c.emit(ChangeEvent{Type: EventDNSCreated, Hostname: hostname})
 	return nil
 }
 
@@ -287,68 +338,23 @@ func (c *CloudflareClient) createDNSRecordAPI(ctx context.Context, tunnelName, h
 		return fmt.Errorf("failed to get tunnel ID for %s: %w", tunnelName, err)
 	}
 
-	// Use the selected domain to determine the zone
-	if c.selectedDomain == "" {
-		return fmt.Errorf("no domain selected - please select a domain first")
-	}
-
-	// Get zone ID for the selected domain
-	zoneID, err := c.GetZoneID(ctx, c.selectedDomain)
+	// Determine the zone from the hostname so a tunnel can serve hostnames
+	// across several zones
+	zoneID, _, err := c.ZoneForHostname(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to get zone ID for domain %s: %w", c.selectedDomain, err)
+		return fmt.Errorf("failed to get zone for %s: %w", hostname, err)
 	}
 
 	// Create the CNAME record pointing to the tunnel
-	tunnelTarget := fmt.Sprintf("%s.cfargotunnel.com", tunnelID)
-
-	// Check if record already exists
-	existingRecords, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
-		Name: hostname,
-		Type: "CNAME",
-	})
-	if err != nil {
-		return fmt.Errorf("failed to check existing DNS records: %w", err)
-	}
-
-	if len(existingRecords) > 0 {
-		if !overwrite {
-			return fmt.Errorf("DNS record for %s already exists. Use overwrite option to replace it", hostname)
-		}
-		// Delete existing record
-		for _, record := range existingRecords {
-			err := c.api.DeleteDNSRecord(ctx, cloudflare.ZoneIdentifier(zoneID), record.ID)
-			if err != nil {
-				return fmt.Errorf("failed to delete existing DNS record: %w", err)
-			}
-		}
-	}
-
-	// Create new CNAME record
-	record := cloudflare.CreateDNSRecordParams{
-		Type:    "CNAME",
-		Name:    hostname,
-		Content: tunnelTarget,
-		TTL:     1, // Auto TTL
-	}
-
-	_, err = c.api.CreateDNSRecord(ctx, cloudflare.ZoneIdentifier(zoneID), record)
-	if err != nil {
-		return fmt.Errorf("failed to create DNS record: %w", err)
-	}
-
-	return nil
+	return c.createTunnelCNAME(ctx, nil, zoneID, hostname, tunnelID, overwrite)
 }
 
 func (c *CloudflareClient) deleteDNSRecordAPI(ctx context.Context, hostname string) error {
-	// Use the selected domain to determine the zone
-	if c.selectedDomain == "" {
-		return fmt.Errorf("no domain selected - please select a domain first")
-	}
-
-	// Get zone ID for the selected domain
-	zoneID, err := c.GetZoneID(ctx, c.selectedDomain)
+	// Determine the zone from the hostname itself so records outside the
+	// selected domain can be cleaned up too
+	zoneID, _, err := c.ZoneForHostname(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to get zone ID for domain %s: %w", c.selectedDomain, err)
+		return fmt.Errorf("failed to get zone for %s: %w", hostname, err)
 	}
 
 	// Find DNS records for the hostname
@@ -360,7 +366,7 @@ func (c *CloudflareClient) deleteDNSRecordAPI(ctx context.Context, hostname stri
 	}
 
 	if len(records) == 0 {
-		return fmt.Errorf("no DNS record found for %s", hostname)
+		return fmt.Errorf("no DNS record for %s: %w", hostname, ErrNotFound)
 	}
 
 	// Delete all matching records
@@ -369,6 +375,7 @@ func (c *CloudflareClient) deleteDNSRecordAPI(ctx context.Context, hostname stri
 		if err != nil {
 			return fmt.Errorf("failed to delete DNS record %s: %w", record.ID, err)
 		}
+		c.emit(ChangeEvent{Type: EventDNSDeleted, TunnelID: tunnelIDFromCNAME(record.Content), Hostname: hostname, Record: record.Content})
 	}
 
 	return nil
@@ -386,7 +393,7 @@ func (c *CloudflareClient) getTunnelIDFromName(ctx context.Context, tunnelName s
 		}
 	}
 
-	return "", fmt.Errorf("tunnel with name %s not found", tunnelName)
+	return "", fmt.Errorf("tunnel %s: %w", tunnelName, ErrNotFound)
 }
 
 func (c *CloudflareClient) ValidateConfig(configPath string) error {
@@ -395,7 +402,7 @@ func (c *CloudflareClient) ValidateConfig(configPath string) error {
 		args = append(args, "--config", configPath)
 	}
 
-	_, err := c.execCommand("cloudflared", args...)
+	_, err := c.execCommand(context.Background(), "cloudflared", args...)
 	if err != nil {
 		return fmt.Errorf("config validation failed: %w", err)
 	}
@@ -404,96 +411,172 @@ func (c *CloudflareClient) ValidateConfig(configPath string) error {
 
 // Tunnel Configuration Management via API
 
-func (c *CloudflareClient) GetTunnelConfiguration(ctx context.Context, tunnelID string) (*TunnelConfiguration, error) {
-	if c.accountID == "" {
-		return nil, fmt.Errorf("account ID not available")
+// apiRequest performs an authenticated Cloudflare API v4 request and decodes
+// the "result" field of the response envelope into result (if non-nil).
+// Requests other than POST are retried when rate limited or on server errors.
+func (c *CloudflareClient) apiRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
 	}
 
-	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/cfd_tunnel/%s/configurations", c.accountID, tunnelID)
+	request := func() error { return c.apiRequestOnce(ctx, method, path, data, result) }
+	if method == "POST" {
+		return request()
+	}
+	return withRetry(ctx, request)
+}
+
+func (c *CloudflareClient) apiRequestOnce(ctx context.Context, method, path string, data []byte, result interface{}) error {
+	var reader io.Reader
+	if data != nil {
+		reader = bytes.NewReader(data)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, "https://api.cloudflare.com/client/v4"+path, reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.config.CloudflareAPIKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tunnel configuration: %w", err)
+		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
-	}
-
 	var response struct {
 		Success bool                     `json:"success"`
-		Result  TunnelConfiguration      `json:"result"`
+		Result  json.RawMessage          `json:"result"`
 		Errors  []map[string]interface{} `json:"errors"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp.StatusCode, nil)
+		}
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if !response.Success {
-		return nil, fmt.Errorf("API request failed: %v", response.Errors)
+	if resp.StatusCode != http.StatusOK || !response.Success {
+		return newAPIError(resp.StatusCode, response.Errors)
 	}
 
-	return &response.Result, nil
+	if result != nil && len(response.Result) > 0 {
+		if err := json.Unmarshal(response.Result, result); err != nil {
+			return fmt.Errorf("failed to decode result: %w", err)
+		}
+	}
+
+	return nil
 }
 
-func (c *CloudflareClient) UpdateTunnelConfiguration(ctx context.Context, tunnelID string, config *TunnelConfigData) error {
+func (c *CloudflareClient) GetTunnelConfiguration(ctx context.Context, tunnelID string) (*TunnelConfiguration, error) {
 	if c.accountID == "" {
-		return fmt.Errorf("account ID not available")
+		return nil, fmt.Errorf("account ID not available")
 	}
 
-	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/cfd_tunnel/%s/configurations", c.accountID, tunnelID)
-
-	body, err := json.Marshal(map[string]interface{}{
-		"config": config,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	var result TunnelConfiguration
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/configurations", c.accountID, tunnelID)
+	if err := c.apiRequest(ctx, "GET", path, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to get tunnel configuration: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PUT", url, strings.NewReader(string(body)))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	return &result, nil
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.CloudflareAPIKey)
-	req.Header.Set("Content-Type", "application/json")
+// UpdateTunnelConfiguration replaces the tunnel's configuration regardless of
+// its current version
+func (c *CloudflareClient) UpdateTunnelConfiguration(ctx context.Context, tunnelID string, config *TunnelConfigData) error {
+	return c.updateTunnelConfiguration(ctx, tunnelID, -1, config)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to update tunnel configuration: %w", err)
+// UpdateTunnelConfigurationVersion replaces the tunnel's configuration read
+// at version and returns a *ConfigVersionConflictError instead when another
+// change (e.g. in the dashboard) landed since. The API takes no version to
+// write against, so the remote version is checked right before writing,
+// after any diff prompt. A change landing between that check and the write
+// can't be stopped; it shows in the version the write returns, which is
+// reported as a conflict too.
+func (c *CloudflareClient) UpdateTunnelConfigurationVersion(ctx context.Context, tunnelID string, version int, config *TunnelConfigData) error {
+	return c.updateTunnelConfiguration(ctx, tunnelID, version, config)
+}
+
+// updateTunnelConfiguration asks for diff confirmation, checks the version
+// config was read at (unless version is negative) against the remote one
+// and writes config
+func (c *CloudflareClient) updateTunnelConfiguration(ctx context.Context, tunnelID string, version int, config *TunnelConfigData) error {
+	if c.accountID == "" {
+		return fmt.Errorf("account ID not available")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	// previous is what the diff and the change events are worked out against
+	var previous *TunnelConfiguration
+	prompted := false
+	if c.confirmConfigUpdate != nil {
+		current, err := c.GetTunnelConfiguration(ctx, tunnelID)
+		if err != nil {
+			return err
+		}
+		previous = current
+		// No point asking about changes that can't be saved
+		if err := checkConfigVersion(tunnelID, current.Version, version); err != nil {
+			return err
+		}
+		if prompted, err = c.confirmIngressChanges(tunnelID, current, config); err != nil {
+			return err
+		}
 	}
 
-	var response struct {
-		Success bool                     `json:"success"`
-		Errors  []map[string]interface{} `json:"errors"`
+	// The prompt may have been open for a while, so fetch again unless the
+	// configuration was only just read
+	if version >= 0 && (previous == nil || prompted) {
+		current, err := c.GetTunnelConfiguration(ctx, tunnelID)
+		if err != nil {
+			return err
+		}
+		if err := checkConfigVersion(tunnelID, current.Version, version); err != nil {
+			return err
+		}
+		if previous == nil {
+			previous = current
+		}
+	} else if previous == nil && c.eventsEnabled() {
+		// Only needed for the events, so a failed fetch just skips them
+		previous, _ = c.GetTunnelConfiguration(ctx, tunnelID)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	written, err := c.putTunnelConfiguration(ctx, tunnelID, config)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		c.emitIngressChanges(tunnelID, previous.Config.Ingress, config.Ingress)
 	}
+	// Each write bumps the version by one, so a bigger jump means another
+	// change landed between the check and the write
+	if version >= 0 && written.Version > version+1 {
+		return &ConfigVersionConflictError{TunnelID: tunnelID, Expected: version + 1, Actual: written.Version, Written: true}
+	}
+	return nil
+}
 
-	if !response.Success {
-		return fmt.Errorf("API request failed: %v", response.Errors)
+func (c *CloudflareClient) putTunnelConfiguration(ctx context.Context, tunnelID string, config *TunnelConfigData) (*TunnelConfiguration, error) {
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/configurations", c.accountID, tunnelID)
+	body := map[string]interface{}{
+		"config": config,
+	}
+	var result TunnelConfiguration
+	if err := c.apiRequest(ctx, "PUT", path, body, &result); err != nil {
+		return nil, fmt.Errorf("failed to update tunnel configuration: %w", err)
 	}
 
-	return nil
+	return &result, nil
 }
 
 func (c *CloudflareClient) GetPublicHostnames(ctx context.Context, tunnelID string) ([]PublicHostname, error) {
@@ -521,6 +604,12 @@ func (c *CloudflareClient) GetPublicHostnames(ctx context.Context, tunnelID stri
 }
 
 func (c *CloudflareClient) AddPublicHostname(ctx context.Context, tunnelID, hostname, path, service string) error {
+	return c.AddPublicHostnameWithOptions(ctx, tunnelID, hostname, path, service, nil)
+}
+
+// AddPublicHostnameWithOptions adds a hostname whose rule carries the given
+// originRequest options
+func (c *CloudflareClient) AddPublicHostnameWithOptions(ctx context.Context, tunnelID, hostname, path, service string, originRequest map[string]interface{}) error {
 	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
 	if err != nil {
 		return err
@@ -566,6 +655,9 @@ func (c *CloudflareClient) AddPublicHostname(ctx context.Context, tunnelID, host
 		Service:       service,
 		OriginRequest: map[string]interface{}{},
 	}
+	for key, value := range originRequest {
+		newIngress.OriginRequest[key] = value
+	}
 
 	// Only add path if it's not "*"
 	if path != "*" {
@@ -582,21 +674,21 @@ func (c *CloudflareClient) AddPublicHostname(ctx context.Context, tunnelID, host
 	}
 
 	// Update the tunnel configuration
-	if err := c.UpdateTunnelConfiguration(ctx, tunnelID, &config.Config); err != nil {
+	if err := c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config); err != nil {
 		return err
 	}
 
 	// Get tunnel info to get the tunnel name for DNS creation
 	tunnel, err := c.GetTunnelInfo(ctx, tunnelID)
 	if err != nil {
-		fmt.Printf("Warning: Failed to get tunnel info for DNS creation: %v\n", err)
+		c.warnf("add hostname", "failed to get tunnel info for DNS creation: %v", err)
 		return nil // Don't fail the whole operation
 	}
 
 	// Also create DNS record for the hostname using tunnel name
 	if err := c.CreateTunnelDNSRecord(ctx, tunnel.Name, hostname, false); err != nil {
-		// Log warning but don't fail the operation
-		fmt.Printf("Warning: Failed to create DNS record for %s: %v\n", hostname, err)
+		// Report a warning but don't fail the operation
+		c.warnf("add hostname", "failed to create DNS record for %s: %v", hostname, err)
 	}
 
 	return nil
@@ -618,7 +710,7 @@ func (c *CloudflareClient) UpdatePublicHostname(ctx context.Context, tunnelID, o
 	}
 
 	if ingressToUpdate == nil {
-		return fmt.Errorf("hostname %s not found", originalHostname)
+		return fmt.Errorf("hostname %s: %w", originalHostname, ErrNotFound)
 	}
 
 	// Set defaults
@@ -641,7 +733,61 @@ func (c *CloudflareClient) UpdatePublicHostname(ctx context.Context, tunnelID, o
 	}
 
 	// Update the tunnel configuration
-	return c.UpdateTunnelConfiguration(ctx, tunnelID, &config.Config)
+	if err := c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config); err != nil {
+		return err
+	}
+
+	// A renamed hostname may live in a different zone, so route it in its own zone
+	if !strings.EqualFold(originalHostname, newHostname) {
+		zoneID, _, err := c.ZoneForHostname(ctx, newHostname)
+		if err == nil {
+			err = c.createTunnelCNAME(ctx, nil, zoneID, newHostname, tunnelID, false)
+		}
+		if err != nil {
+			c.warnf("update hostname", "failed to create DNS record for %s: %v", newHostname, err)
+			return nil
+		}
+
+		// Other paths may still route the old hostname to this tunnel
+		for _, ingress := range config.Config.Ingress {
+			if strings.EqualFold(ingress.Hostname, originalHostname) {
+				return nil
+			}
+		}
+		if err := c.deleteTunnelCNAME(ctx, originalHostname, tunnelID); err != nil {
+			c.warnf("update hostname", "failed to delete DNS record for %s: %v", originalHostname, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteTunnelCNAME deletes hostname's CNAME records in its own zone that
+// point at tunnelID, leaving anything else at that name alone
+func (c *CloudflareClient) deleteTunnelCNAME(ctx context.Context, hostname, tunnelID string) error {
+	zoneID, _, err := c.ZoneForHostname(ctx, hostname)
+	if err != nil {
+		return fmt.Errorf("failed to get zone for %s: %w", hostname, err)
+	}
+
+	records, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
+		Type: "CNAME",
+		Name: hostname,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	for _, record := range records {
+		if tunnelIDFromCNAME(record.Content) != tunnelID {
+			continue
+		}
+		if err := c.api.DeleteDNSRecord(ctx, cloudflare.ZoneIdentifier(zoneID), record.ID); err != nil {
+			return fmt.Errorf("failed to delete DNS record %s: %w", record.ID, err)
+		}
+		c.emit(ChangeEvent{Type: EventDNSDeleted, TunnelID: tunnelID, Hostname: hostname, Record: record.Content})
+	}
+	return nil
 }
 
 func (c *CloudflareClient) RemovePublicHostname(ctx context.Context, tunnelID, hostname, path string) error {
@@ -671,14 +817,16 @@ func (c *CloudflareClient) RemovePublicHostname(ctx context.Context, tunnelID, h
 	}
 
 	if !found {
-		return fmt.Errorf("hostname %s with path %s not found", hostname, path)
+		return fmt.Errorf("hostname %s with path %s: %w", hostname, path, ErrNotFound)
 	}
 
-	return c.UpdateTunnelConfiguration(ctx, tunnelID, &config.Config)
+	return c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config)
 }
 
-// ToggleHostnameAuth toggles authentication for a hostname by starting/stopping Traefik
-func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hostname string) (*PublicHostname, error) {
+// ToggleHostnameAuth toggles authentication for a hostname by starting/stopping Traefik.
+// users returns the logins the proxy accepts and is only called when auth is
+// being enabled, so no credentials are made for a hostname being opened up.
+func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hostname string, users func() ([]AuthUser, error)) (*PublicHostname, error) {
 	// Get current hostnames to find the one to toggle
 	hostnames, err := c.GetPublicHostnames(ctx, tunnelID)
 	if err != nil {
@@ -694,7 +842,7 @@ func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hos
 	}
 
 	if targetHostname == nil {
-		return nil, fmt.Errorf("hostname %s not found", hostname)
+		return nil, fmt.Errorf("hostname %s: %w", hostname, ErrNotFound)
 	}
 
 	// Initialize Docker manager
@@ -716,7 +864,9 @@ func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hos
 				if targetHostname.Service != expectedService {
 					// Service URL doesn't match expected Traefik URL, update it
 					targetHostname.Service = expectedService
-					c.UpdatePublicHostname(ctx, tunnelID, hostname, hostname, targetHostname.Path, expectedService)
+					if err := c.UpdatePublicHostname(ctx, tunnelID, hostname, hostname, targetHostname.Path, expectedService); err != nil {
+						c.warnf("toggle auth", "failed to point %s at its Traefik container: %v", hostname, err)
+					}
 				}
 			}
 		} else if targetHostname.OriginalService != "" && strings.HasPrefix(targetHostname.Service, "http://localhost:") {
@@ -725,7 +875,9 @@ func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hos
 			targetHostname.Service = targetHostname.OriginalService
 			targetHostname.AuthEnabled = false
 			// Update tunnel configuration to fix inconsistent state
-			c.UpdatePublicHostname(ctx, tunnelID, hostname, hostname, targetHostname.Path, targetHostname.Service)
+			if err := c.UpdatePublicHostname(ctx, tunnelID, hostname, hostname, targetHostname.Path, targetHostname.Service); err != nil {
+				c.warnf("toggle auth", "failed to restore original service for %s: %v", hostname, err)
+			}
 		}
 	}
 
@@ -765,12 +917,7 @@ func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hos
 		// Keep OriginalService for potential future toggles
 
 	} else {
-		// Enable auth - generate password, start Traefik, update service URL
-		password, err := GenerateRandomPassword(6)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate password: %w", err)
-		}
-
+		// Enable auth - start Traefik with the users' logins, update service URL
 		// Store original service if not already stored
 		if targetHostname.OriginalService == "" {
 			// Only store if current service is not already a Traefik service
@@ -784,8 +931,13 @@ func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hos
 			}
 		}
 
+		logins, err := users()
+		if err != nil {
+			return nil, err
+		}
+
 		// Start Traefik container
-		traefikPort, err := dockerManager.StartTraefikContainer(hostname, targetHostname.OriginalService, password)
+		traefikPort, err := dockerManager.StartTraefikContainer(hostname, targetHostname.OriginalService, logins, c.config.TraefikMiddlewaresFor(hostname))
 		if err != nil {
 			return nil, fmt.Errorf("failed to start Traefik container: %w", err)
 		}
@@ -794,19 +946,35 @@ func (c *CloudflareClient) ToggleHostnameAuth(ctx context.Context, tunnelID, hos
 		traefikService := GetTraefikServiceURL(hostname, traefikPort)
 		if err := c.UpdatePublicHostname(ctx, tunnelID, hostname, hostname, targetHostname.Path, traefikService); err != nil {
 			// If tunnel update fails, stop the Traefik container
-			dockerManager.StopTraefikContainer(hostname)
+			if stopErr := dockerManager.StopTraefikContainer(hostname); stopErr != nil {
+				c.warnf("toggle auth", "failed to stop Traefik container for %s: %v", hostname, stopErr)
+			}
 			return nil, fmt.Errorf("failed to update hostname service: %w", err)
 		}
 
 		// Update hostname struct
 		targetHostname.AuthEnabled = true
-		targetHostname.AuthPassword = password
 		targetHostname.Service = traefikService
 	}
 
 	return targetHostname, nil
 }
 
+// SetHostnameAuthUsers replaces the logins of a hostname whose auth proxy is
+// running
+func (c *CloudflareClient) SetHostnameAuthUsers(hostname, originalService string, users []AuthUser) error {
+	dockerManager, err := NewDockerManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize Docker manager: %w", err)
+	}
+	defer dockerManager.Close()
+
+	if err := dockerManager.UpdateTraefikUsers(hostname, originalService, users, c.config.TraefikMiddlewaresFor(hostname)); err != nil {
+		return fmt.Errorf("failed to update auth users: %w", err)
+	}
+	return nil
+}
+
 // Status and Monitoring
 
 func (c *CloudflareClient) GetTunnelStatus(ctx context.Context, nameOrID string) (TunnelStatus, error) {
@@ -819,15 +987,19 @@ func (c *CloudflareClient) GetTunnelStatus(ctx context.Context, nameOrID string)
 		return StatusInactive, nil
 	}
 
-	hasActiveConnection := false
+	active, reconnecting := 0, 0
 	for _, conn := range tunnel.Connections {
-		if !conn.IsPendingReconnect {
-			hasActiveConnection = true
-			break
+		if conn.IsPendingReconnect {
+			reconnecting++
+		} else {
+			active++
 		}
 	}
 
-	if hasActiveConnection {
+	switch {
+	case active > 0 && reconnecting > 0:
+		return StatusDegraded, nil
+	case active > 0:
 		return StatusActive, nil
 	}
 
@@ -850,7 +1022,7 @@ func (c *CloudflareClient) IsCloudflaredInstalled() bool {
 }
 
 func (c *CloudflareClient) GetCloudflaredVersion() (string, error) {
-	output, err := c.execCommand("cloudflared", "--version")
+	output, err := c.execCommand(context.Background(), "cloudflared", "--version")
 	if err != nil {
 		return "", err
 	}
@@ -892,28 +1064,3 @@ func (c *CloudflareClient) wrapError(operation string, err error, details string
 		Details:   details,
 	}
 }
-
-func IsNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(err.Error(), "not found") ||
-		strings.Contains(err.Error(), "does not exist")
-}
-
-func IsAuthenticationError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(err.Error(), "authentication") ||
-		strings.Contains(err.Error(), "unauthorized") ||
-		strings.Contains(err.Error(), "invalid token")
-}
-
-func IsRateLimitError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(err.Error(), "rate limit") ||
-		strings.Contains(err.Error(), "too many requests")
-}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ColoCount is how many active connections end at one Cloudflare colo
+type ColoCount struct {
+	Colo  string
+	Count int
+}
+
+// coloCode turns a connection's colo name such as "sjc08" into its airport
+// code, "SJC", so the data centers of one city are counted together
+func coloCode(name string) string {
+	code := strings.TrimRight(name, "0123456789")
+	if code == "" {
+		code = name
+	}
+	return strings.ToUpper(code)
+}
+
+// ColoDistribution counts the active connections of all tunnels by colo,
+// most used first. Connections waiting to reconnect are left out.
+func ColoDistribution(tunnels []CLITunnel) []ColoCount {
+	counts := make(map[string]int)
+	for _, tunnel := range tunnels {
+		for _, conn := range tunnel.Connections {
+			if conn.IsPendingReconnect || conn.ColoName == "" {
+				continue
+			}
+			counts[coloCode(conn.ColoName)]++
+		}
+	}
+
+	distribution := make([]ColoCount, 0, len(counts))
+	for colo, count := range counts {
+		distribution = append(distribution, ColoCount{Colo: colo, Count: count})
+	}
+	sort.Slice(distribution, func(i, j int) bool {
+		if distribution[i].Count != distribution[j].Count {
+			return distribution[i].Count > distribution[j].Count
+		}
+		return distribution[i].Colo < distribution[j].Colo
+	})
+	return distribution
+}
+
+// FormatColoDistribution renders a distribution as "SJC: 4, FRA: 2"
+func FormatColoDistribution(distribution []ColoCount) string {
+	parts := make([]string, len(distribution))
+	for i, colo := range distribution {
+		parts[i] = fmt.Sprintf("%s: %d", colo.Colo, colo.Count)
+	}
+	return strings.Join(parts, ", ")
+}
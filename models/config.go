@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
 const (
@@ -13,29 +15,123 @@ const (
 )
 
 type Config struct {
+	SchemaVersion      int    `json:"schema_version"`
 	CloudflareAPIKey   string `json:"cloudflare_api_key"`
 	CloudflareEmail    string `json:"cloudflare_email"`
 	TunnelConfigPath   string `json:"tunnel_config_path"`
 	AutoRefreshSeconds int    `json:"auto_refresh_seconds"`
 	LogLevel           string `json:"log_level"`
+	// MaintenanceServiceURL replaces a hostname's service in maintenance mode
+	// (e.g. a static page server); defaults to http_status:503
+	MaintenanceServiceURL string `json:"maintenance_service,omitempty"`
+	// ProtectedHostnames are glob patterns (e.g. *.prod.example.com) for
+	// hostnames that require typing the full name to modify or delete
+	ProtectedHostnames []string `json:"protected_hostnames,omitempty"`
+	// StaleTunnelDays is the idle period before unused tunnels are offered for cleanup
+	StaleTunnelDays int `json:"stale_tunnel_days,omitempty"`
+	// SkipConfigDiff disables the ingress diff confirmation before config writes
+	SkipConfigDiff bool `json:"skip_config_diff,omitempty"`
+	// SkipDeleteConfirm deletes tunnels and hostnames without asking y/n
+	// first; protected hostnames still have to be typed
+	SkipDeleteConfirm bool `json:"skip_delete_confirm,omitempty"`
+	// ZeroTrustTeam is the team name from <team>.cloudflareaccess.com; it is
+	// looked up from the API when empty
+	ZeroTrustTeam string `json:"zero_trust_team,omitempty"`
+	// NotFoundPagePort is where tunnelman serves its catch-all 404 page
+	NotFoundPagePort int `json:"not_found_port,omitempty"`
+	// ProcessMemoryLimitMB and ProcessCPULimit are resource thresholds for
+	// managed processes; zero disables the check
+	ProcessMemoryLimitMB int     `json:"process_memory_limit_mb,omitempty"`
+	ProcessCPULimit      float64 `json:"process_cpu_limit,omitempty"`
+	// RestartOnLimit restarts a process that stays over a threshold instead
+	// of only raising a warning
+	RestartOnLimit bool `json:"restart_on_limit,omitempty"`
+	// TunnelSupervisors selects how each tunnel's connector is hosted, keyed
+	// by tunnel name (e.g. "systemd:cloudflared-home.service"); tunnels
+	// without an entry run cloudflared directly
+	TunnelSupervisors map[string]string `json:"supervisors,omitempty"`
+	// StateDir and CloudflaredDir relocate tunnelman's state (uptime log,
+	// Traefik configs) and cloudflared's config and credentials directory
+	StateDir       string `json:"state_dir,omitempty"`
+	CloudflaredDir string `json:"cloudflared_dir,omitempty"`
+	// TunnelNamePrefix and TunnelPageSize switch the tunnel list to the
+	// paged API listing, filtered server side, for accounts with many tunnels
+	TunnelNamePrefix string `json:"tunnel_name_prefix,omitempty"`
+	TunnelPageSize   int    `json:"tunnel_page_size,omitempty"`
+	// TunnelLogLevels overrides LogLevel for individual tunnels, keyed by
+	// tunnel name
+	TunnelLogLevels map[string]string `json:"tunnel_log_levels,omitempty"`
+	// APIOnly lists, inspects and deletes tunnels through the API instead of
+	// the cloudflared CLI, so tunnelman works without cert.pem
+	APIOnly bool `json:"api_only,omitempty"`
+	// SmokeTestSeconds is how long a new hostname is polled through the edge
+	// until it answers; zero skips the check
+	SmokeTestSeconds int `json:"smoke_test_seconds,omitempty"`
+	// TunnelRunArgs are extra flags for `cloudflared tunnel`, such as
+	// --edge-ip-version 4 or --region us, keyed by tunnel name
+	TunnelRunArgs map[string][]string `json:"tunnel_run_args,omitempty"`
+	// Events sends a JSON event to a webhook or Unix socket whenever
+	// tunnelman changes a hostname or DNS record
+	Events *EventSinks `json:"events,omitempty"`
+	// ThemeName picks a built-in color preset: dark (the default), light or
+	// high-contrast
+	ThemeName string `json:"theme,omitempty"`
+	// ThemeColors overrides single colors of the preset by role, e.g.
+	// {"accent": "#2563EB"}
+	ThemeColors map[string]string `json:"theme_colors,omitempty"`
+	// Keybindings rebinds actions (quit, refresh, delete, add, edit, open)
+	// to other keys, e.g. {"delete": "x"}
+	Keybindings map[string]string `json:"keybindings,omitempty"`
+	// Polling sets the background refresh intervals of the tunnel list
+	Polling *PollingConfig `json:"polling,omitempty"`
+	// DomainCacheMinutes is how long the zone list of the hostname forms is
+	// reused before it's fetched again; zero uses the default of 10
+	DomainCacheMinutes int `json:"domain_cache_minutes,omitempty"`
+	// TunnelEnvironments maps tunnel name patterns (e.g. dev-*) to the
+	// environment the matching tunnels belong to
+	TunnelEnvironments map[string]string `json:"tunnel_environments,omitempty"`
+	// TunnelSortColumn and TunnelSortDescending order the tunnel list by
+	// name, status, domains or created; empty keeps the listed order
+	TunnelSortColumn     string `json:"tunnel_sort,omitempty"`
+	TunnelSortDescending bool   `json:"tunnel_sort_descending,omitempty"`
+	// TraefikMiddlewares adds Traefik middleware definitions to the auth
+	// proxy of matching hostnames, keyed by hostname or pattern and then by
+	// middleware name
+	TraefikMiddlewares map[string]map[string]interface{} `json:"traefik_middlewares,omitempty"`
+
+	// stored holds the credentials from config.json while a session token
+	// is in use, so Save writes those back instead of the session token
+	stored *storedCredentials
+}
+
+type storedCredentials struct {
+	apiKey string
+	email  string
+}
+
+// UseSessionToken switches the config to an API token held only in memory.
+// Save keeps writing whatever credentials config.json already had.
+func (c *Config) UseSessionToken(token string) {
+	if c.stored == nil {
+		c.stored = &storedCredentials{apiKey: c.CloudflareAPIKey, email: c.CloudflareEmail}
+	}
+	c.CloudflareAPIKey = token
+	c.CloudflareEmail = ""
+}
+
+// SessionToken reports whether the API token is held only in memory
+func (c *Config) SessionToken() bool {
+	return c.stored != nil
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		TunnelConfigPath:   filepath.Join(getConfigDir(), "tunnels.json"),
+		TunnelConfigPath:   filepath.Join(GetStateDir(), "tunnels.json"),
 		AutoRefreshSeconds: 30,
 		LogLevel:           "info",
 	}
 }
 
-func getConfigDir() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return DefaultConfigDir
-	}
-	return filepath.Join(home, DefaultConfigDir)
-}
-
 func GetConfigPath() string {
 	return filepath.Join(getConfigDir(), DefaultConfigFile)
 }
@@ -47,10 +143,19 @@ func (c *Config) Save() error {
 	}
 
 	configPath := GetConfigPath()
-	data, err := json.MarshalIndent(c, "", "  ")
+	c.SchemaVersion = ConfigSchemaVersion
+	onDisk := *c
+	if c.stored != nil {
+		onDisk.CloudflareAPIKey = c.stored.apiKey
+		onDisk.CloudflareEmail = c.stored.email
+	}
+	data, err := json.MarshalIndent(&onDisk, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
+	if data, err = sealConfig(data); err != nil {
+		return fmt.Errorf("failed to encrypt config: %w", err)
+	}
 
 	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -59,11 +164,28 @@ func (c *Config) Save() error {
 	return nil
 }
 
+// IsProtectedHostname reports whether hostname matches one of the configured
+// protected hostname patterns
+func (c *Config) IsProtectedHostname(hostname string) bool {
+	if c == nil {
+		return false
+	}
+
+	hostname = strings.ToLower(hostname)
+	for _, pattern := range c.ProtectedHostnames {
+		if matched, err := path.Match(strings.ToLower(pattern), hostname); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 func LoadConfig() (*Config, error) {
 	configPath := GetConfigPath()
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		config := DefaultConfig()
+		config.applyDirectories()
 		if err := config.Save(); err != nil {
 			return nil, fmt.Errorf("failed to save default config: %w", err)
 		}
@@ -75,10 +197,24 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	var encode func([]byte) ([]byte, error)
+	if IsSealed(data) {
+		if data, err = openConfig(data); err != nil {
+			return nil, err
+		}
+		encode = sealConfig
+	}
+
+	data, err = migrateFile(configPath, data, configMigrations, 0600, encode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate config file: %w", err)
+	}
+
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.applyDirectories()
 
 	return &config, nil
 }
@@ -93,6 +229,7 @@ func (s *AppState) SaveToFile(path string) error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	s.SchemaVersion = StateSchemaVersion
 	data, err := json.MarshalIndent(s, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %w", err)
@@ -126,6 +263,11 @@ func LoadAppState(path string) (*AppState, error) {
 		return nil, fmt.Errorf("failed to read state file: %w", err)
 	}
 
+	data, err = migrateFile(path, data, stateMigrations, 0644, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate state file: %w", err)
+	}
+
 	var state AppState
 	if err := json.Unmarshal(data, &state); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
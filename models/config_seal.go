@@ -0,0 +1,123 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// An encrypted config.json is sealed with Seal. The passphrase is asked for
+// once per process through the source set with SetConfigPassphraseSource and
+// kept in memory so saves can seal the file again. Loads and saves run from
+// TUI commands too, so configSealMu guards all three.
+var (
+	configSealMu           sync.Mutex
+	configPassphraseSource func() ([]byte, error)
+	configPassphrase       []byte
+	configSealed           bool
+)
+
+// ErrConfigLocked is returned when config.json is encrypted and there is no
+// way to get its passphrase
+var ErrConfigLocked = errors.New("config.json is encrypted - set $TUNNELMAN_PASSPHRASE or pass -key-file")
+
+// SetConfigPassphraseSource sets how the passphrase of an encrypted
+// config.json is obtained. It is only called when the file is encrypted.
+func SetConfigPassphraseSource(source func() ([]byte, error)) {
+	configSealMu.Lock()
+	defer configSealMu.Unlock()
+	configPassphraseSource = source
+	configPassphrase = nil
+}
+
+// getConfigPassphrase returns the passphrase, asking for it the first time.
+// configSealMu must be held, which also keeps concurrent loads from asking
+// twice.
+func getConfigPassphrase() ([]byte, error) {
+	if configPassphrase != nil {
+		return configPassphrase, nil
+	}
+	if configPassphraseSource == nil {
+		return nil, ErrConfigLocked
+	}
+	passphrase, err := configPassphraseSource()
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, ErrConfigLocked
+	}
+	configPassphrase = passphrase
+	return passphrase, nil
+}
+
+// ConfigEncrypted reports whether config.json on disk is encrypted
+func ConfigEncrypted() bool {
+	data, err := os.ReadFile(GetConfigPath())
+	return err == nil && IsSealed(data)
+}
+
+// openConfig decrypts a sealed config.json and marks the config as
+// encrypted so Save keeps it that way
+func openConfig(data []byte) ([]byte, error) {
+	configSealMu.Lock()
+	defer configSealMu.Unlock()
+	passphrase, err := getConfigPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	plain, err := Open(data, passphrase)
+	if err != nil {
+		// Let a later load ask again rather than reuse a wrong passphrase
+		configPassphrase = nil
+		return nil, fmt.Errorf("failed to decrypt config file: %w", err)
+	}
+	configSealed = true
+	return plain, nil
+}
+
+// sealConfig seals data if config.json is kept encrypted, and returns it
+// unchanged otherwise
+func sealConfig(data []byte) ([]byte, error) {
+	configSealMu.Lock()
+	defer configSealMu.Unlock()
+	if !configSealed {
+		return data, nil
+	}
+	passphrase, err := getConfigPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	return Seal(data, passphrase)
+}
+
+func setConfigSealed(sealed bool, passphrase []byte) {
+	configSealMu.Lock()
+	defer configSealMu.Unlock()
+	if passphrase != nil {
+		configPassphrase = passphrase
+	}
+	configSealed = sealed
+}
+
+// EncryptConfig rewrites config.json sealed with passphrase. Later saves in
+// this process keep it encrypted.
+func EncryptConfig(passphrase []byte) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	setConfigSealed(true, passphrase)
+	return config.Save()
+}
+
+// DecryptConfig rewrites an encrypted config.json as plain JSON
+func DecryptConfig() error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	setConfigSealed(false, nil)
+	return config.Save()
+}
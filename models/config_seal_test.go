@@ -0,0 +1,140 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	data := []byte(`{"cloudflare_api_key": "secret"}`)
+	sealed, err := Seal(data, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if !IsSealed(sealed) {
+		t.Fatalf("IsSealed(sealed) = false")
+	}
+	if bytes.Contains(sealed, []byte("secret")) {
+		t.Fatalf("sealed data contains the plaintext")
+	}
+
+	opened, err := Open(sealed, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !bytes.Equal(opened, data) {
+		t.Errorf("Open = %q; want %q", opened, data)
+	}
+
+	if _, err := Open(sealed, []byte("wrong horse")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Open with the wrong passphrase = %v; want ErrWrongPassphrase", err)
+	}
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := Open(tampered, []byte("correct horse")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Open of tampered data = %v; want ErrWrongPassphrase", err)
+	}
+	if _, err := Open(sealed[:len(sealMagic)+4], []byte("correct horse")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Open of truncated data = %v; want ErrWrongPassphrase", err)
+	}
+	if _, err := Open(data, []byte("correct horse")); err == nil {
+		t.Errorf("Open of unsealed data succeeded")
+	}
+}
+
+// useSealedConfigDir points config.json at an empty directory and resets the
+// passphrase state when the test ends
+func useSealedConfigDir(t *testing.T) {
+	t.Helper()
+	SetConfigDir(t.TempDir())
+	t.Cleanup(func() {
+		SetConfigDir("")
+		SetConfigPassphraseSource(nil)
+		setConfigSealed(false, nil)
+	})
+}
+
+func passphrase(p string) func() ([]byte, error) {
+	return func() ([]byte, error) { return []byte(p), nil }
+}
+
+func TestEncryptedConfigRoundTrip(t *testing.T) {
+	useSealedConfigDir(t)
+
+	config := DefaultConfig()
+	config.CloudflareAPIKey = "secret-token"
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := EncryptConfig([]byte("correct horse")); err != nil {
+		t.Fatalf("EncryptConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(GetConfigPath())
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if !IsSealed(data) || bytes.Contains(data, []byte("secret-token")) {
+		t.Fatalf("config.json isn't encrypted after EncryptConfig")
+	}
+
+	// A new process asks for the passphrase again
+	SetConfigPassphraseSource(passphrase("correct horse"))
+	loaded, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if loaded.CloudflareAPIKey != "secret-token" {
+		t.Errorf("CloudflareAPIKey = %q; want %q", loaded.CloudflareAPIKey, "secret-token")
+	}
+
+	// Saving keeps it encrypted
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if !ConfigEncrypted() {
+		t.Errorf("config.json was saved unencrypted")
+	}
+
+	if err := DecryptConfig(); err != nil {
+		t.Fatalf("DecryptConfig failed: %v", err)
+	}
+	if ConfigEncrypted() {
+		t.Errorf("config.json is still encrypted after DecryptConfig")
+	}
+}
+
+func TestEncryptedConfigWrongPassphrase(t *testing.T) {
+	useSealedConfigDir(t)
+
+	if err := DefaultConfig().Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := EncryptConfig([]byte("correct horse")); err != nil {
+		t.Fatalf("EncryptConfig failed: %v", err)
+	}
+
+	asked := 0
+	SetConfigPassphraseSource(func() ([]byte, error) {
+		asked++
+		return []byte("wrong horse"), nil
+	})
+	if _, err := LoadConfig(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("LoadConfig with the wrong passphrase = %v; want ErrWrongPassphrase", err)
+	}
+	// The wrong passphrase isn't kept, so the next load asks again
+	if _, err := LoadConfig(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("second LoadConfig = %v; want ErrWrongPassphrase", err)
+	}
+	if asked != 2 {
+		t.Errorf("passphrase asked for %d times; want 2", asked)
+	}
+
+	SetConfigPassphraseSource(nil)
+	if _, err := LoadConfig(); !errors.Is(err, ErrConfigLocked) {
+		t.Errorf("LoadConfig without a passphrase source = %v; want ErrConfigLocked", err)
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigError is a validation failure in a YAML config, with the 1-based
+// line it refers to when known
+type ConfigError struct {
+	Line    int
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return e.Message
+}
+
+var (
+	yamlErrorLinePattern    = regexp.MustCompile(`line (\d+): (.*)`)
+	ingressRuleIndexPattern = regexp.MustCompile(`rule (\d+)`)
+)
+
+// ValidateConfigFile checks a cloudflared YAML config: that it parses, that it
+// passes ValidateTunnelConfig and, when cloudflared is installed, that
+// `cloudflared tunnel ingress validate` accepts it. Errors are *ConfigError.
+func (tm *TunnelManager) ValidateConfigFile(path string) (*TunnelConfigFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config TunnelConfigFile
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		if match := yamlErrorLinePattern.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			return nil, &ConfigError{Line: line, Message: match[2]}
+		}
+		return nil, &ConfigError{Message: err.Error()}
+	}
+
+	if err := tm.ValidateTunnelConfig(&config); err != nil {
+		configErr := &ConfigError{Message: err.Error()}
+		lines := ingressRuleLines(string(data))
+		if match := ingressRuleIndexPattern.FindStringSubmatch(err.Error()); match != nil {
+			if i, _ := strconv.Atoi(match[1]); i < len(lines) {
+				configErr.Line = lines[i]
+			}
+		} else if strings.Contains(err.Error(), "catch-all") && len(lines) > 0 {
+			configErr.Line = lines[len(lines)-1]
+		}
+		return nil, configErr
+	}
+
+	if _, err := exec.LookPath("cloudflared"); err == nil {
+		output, err := exec.Command("cloudflared", "tunnel", "--config", path, "ingress", "validate").CombinedOutput()
+		if err != nil {
+			return nil, &ConfigError{Message: "cloudflared: " + strings.TrimSpace(string(output))}
+		}
+	}
+
+	return &config, nil
+}
+
+// ingressRuleLines returns the 1-based line of each entry in the top-level
+// ingress list, in order
+func ingressRuleLines(data string) []int {
+	var lines []int
+	inIngress := false
+	itemIndent := -1
+	for i, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if indent == 0 && !strings.HasPrefix(trimmed, "-") {
+			inIngress = strings.HasPrefix(trimmed, "ingress:")
+			itemIndent = -1
+			continue
+		}
+		if !inIngress || !strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+		if itemIndent < 0 {
+			itemIndent = indent
+		}
+		if indent == itemIndent {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
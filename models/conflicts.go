@@ -0,0 +1,91 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// HostnameConflict describes why a hostname can't be safely added to a tunnel
+type HostnameConflict struct {
+	Hostname   string
+	TunnelID   string
+	TunnelName string
+	// DNSRecord is set when an existing DNS record points somewhere else
+	DNSRecord string
+}
+
+func (c HostnameConflict) Error() string {
+	if c.TunnelName != "" && c.DNSRecord == "" {
+		return fmt.Sprintf("hostname %s is already served by tunnel %s", c.Hostname, c.TunnelName)
+	}
+	if c.TunnelName != "" {
+		return fmt.Sprintf("hostname %s already has a DNS record pointing at tunnel %s (%s)", c.Hostname, c.TunnelName, c.DNSRecord)
+	}
+	return fmt.Sprintf("hostname %s already has a conflicting DNS record (%s)", c.Hostname, c.DNSRecord)
+}
+
+// tunnelIDFromCNAME extracts the tunnel ID from a <id>.cfargotunnel.com target
+func tunnelIDFromCNAME(content string) string {
+	if !strings.HasSuffix(content, ".cfargotunnel.com") {
+		return ""
+	}
+	return strings.TrimSuffix(content, ".cfargotunnel.com")
+}
+
+// CheckHostnameConflict looks for hostname in the ingress rules of every
+// tunnel other than tunnelID and in the selected zone's DNS records. It
+// returns nil if the hostname can be added to tunnelID.
+func (c *CloudflareClient) CheckHostnameConflict(ctx context.Context, hostname, tunnelID string, tunnels []CLITunnel) (*HostnameConflict, error) {
+	tunnelNames := make(map[string]string)
+	var others []CLITunnel
+	for _, tunnel := range tunnels {
+		tunnelNames[tunnel.ID] = tunnel.Name
+		if tunnel.ID != tunnelID {
+			others = append(others, tunnel)
+		}
+	}
+
+	existing, _ := c.ListAllPublicHostnames(ctx, others)
+	for _, other := range existing {
+		if strings.EqualFold(other.Hostname, hostname) {
+			return &HostnameConflict{Hostname: hostname, TunnelID: other.TunnelID, TunnelName: other.TunnelName}, nil
+		}
+	}
+
+	zones, err := c.api.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	// A hostname outside every zone can't have a conflicting record
+	zoneID, _ := matchZone(zones, hostname)
+	if zoneID == "" {
+		return nil, nil
+	}
+
+	records, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
+		Name: hostname,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing DNS records: %w", err)
+	}
+
+	for _, record := range records {
+		targetID := tunnelIDFromCNAME(record.Content)
+		if record.Type == "CNAME" && targetID == tunnelID {
+			continue
+		}
+
+		return &HostnameConflict{
+			Hostname:   hostname,
+			TunnelID:   targetID,
+			TunnelName: tunnelNames[targetID],
+			DNSRecord:  fmt.Sprintf("%s %s", record.Type, record.Content),
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// connectorEventLimit is how many events are kept per tunnel
+const connectorEventLimit = 50
+
+// TunnelConnector is one cloudflared instance registered for a tunnel, with
+// the edge connections it holds
+type TunnelConnector struct {
+	ID            string                `json:"id"`
+	Version       string                `json:"version"`
+	Arch          string                `json:"arch"`
+	ConfigVersion int                   `json:"config_version"`
+	RunAt         time.Time             `json:"run_at"`
+	Connections   []CLITunnelConnection `json:"conns"`
+}
+
+// GetTunnelConnectors returns the connectors the edge currently has
+// registered for the tunnel, wherever they run
+func (c *CloudflareClient) GetTunnelConnectors(ctx context.Context, tunnelID string) ([]TunnelConnector, error) {
+	if c.accountID == "" {
+		return nil, fmt.Errorf("account ID not available")
+	}
+
+	var connectors []TunnelConnector
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/connections", c.accountID, tunnelID)
+	if err := c.apiRequest(ctx, "GET", path, nil, &connectors); err != nil {
+		return nil, fmt.Errorf("failed to get tunnel connectors: %w", err)
+	}
+	return connectors, nil
+}
+
+// ConnectorEventKind is what happened to a connector or one of its connections
+type ConnectorEventKind string
+
+const (
+	ConnectorStarted       ConnectorEventKind = "started"
+	ConnectorStopped       ConnectorEventKind = "stopped"
+	ConnectionRegistered   ConnectorEventKind = "registered"
+	ConnectionUnregistered ConnectorEventKind = "unregistered"
+	ConnectionReconnecting ConnectorEventKind = "reconnecting"
+	ConnectorsUnavailable  ConnectorEventKind = "error"
+)
+
+// ConnectorEvent is one change seen in a tunnel's connectors
+type ConnectorEvent struct {
+	Time      time.Time
+	Kind      ConnectorEventKind
+	Connector string
+	Colo      string
+	Detail    string
+}
+
+// IsProblem reports whether the event points at flapping or a failure
+func (e ConnectorEvent) IsProblem() bool {
+	return e.Kind == ConnectionUnregistered || e.Kind == ConnectionReconnecting ||
+		e.Kind == ConnectorStopped || e.Kind == ConnectorsUnavailable
+}
+
+// String describes the event on one line
+func (e ConnectorEvent) String() string {
+	text := fmt.Sprintf("%s %-12s", e.Time.Local().Format("Jan 02 15:04:05"), e.Kind)
+	if e.Connector != "" {
+		text += " connector " + shortConnectorID(e.Connector)
+	}
+	if e.Colo != "" {
+		text += " @ " + e.Colo
+	}
+	if e.Detail != "" {
+		text += " - " + e.Detail
+	}
+	return text
+}
+
+func shortConnectorID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// ConnectorEventLog turns successive snapshots of a tunnel's connectors into
+// register, unregister and reconnect events. The API only reports current
+// connections, so events between two snapshots are inferred from how they
+// differ; connections that are already open on the first snapshot are
+// listed at the time they were opened.
+type ConnectorEventLog struct {
+	Events     []ConnectorEvent
+	connectors map[string]TunnelConnector
+	conns      map[string]CLITunnelConnection
+	connOwner  map[string]string
+	failing    bool
+}
+
+// NewConnectorEventLog returns an empty event log
+func NewConnectorEventLog() *ConnectorEventLog {
+	return &ConnectorEventLog{}
+}
+
+// Observe records the differences between connectors and the previous
+// snapshot, taken at now
+func (l *ConnectorEventLog) Observe(connectors []TunnelConnector, now time.Time) {
+	currentConnectors := make(map[string]TunnelConnector, len(connectors))
+	currentConns := make(map[string]CLITunnelConnection)
+	owner := make(map[string]string)
+	for _, connector := range connectors {
+		currentConnectors[connector.ID] = connector
+		for _, conn := range connector.Connections {
+			currentConns[conn.ID] = conn
+			owner[conn.ID] = connector.ID
+		}
+	}
+
+	var events []ConnectorEvent
+	l.failing = false
+
+	for id, connector := range currentConnectors {
+		previous, known := l.connectors[id]
+		if known && previous.RunAt.Equal(connector.RunAt) {
+			continue
+		}
+		at := connector.RunAt
+		if at.IsZero() {
+			at = now
+		}
+		detail := fmt.Sprintf("cloudflared %s", connector.Version)
+		if connector.Arch != "" {
+			detail += " " + connector.Arch
+		}
+		if known {
+			detail = "restarted, " + detail
+		}
+		events = append(events, ConnectorEvent{Time: at, Kind: ConnectorStarted, Connector: id, Detail: detail})
+	}
+	for id := range l.connectors {
+		if _, still := currentConnectors[id]; !still {
+			events = append(events, ConnectorEvent{Time: now, Kind: ConnectorStopped, Connector: id})
+		}
+	}
+
+	for id, conn := range currentConns {
+		previous, known := l.conns[id]
+		if !known {
+			at := conn.OpenedAt
+			if at.IsZero() {
+				at = now
+			}
+			events = append(events, ConnectorEvent{Time: at, Kind: ConnectionRegistered, Connector: owner[id], Colo: conn.ColoName, Detail: conn.OriginIP})
+		}
+		if conn.IsPendingReconnect && !previous.IsPendingReconnect {
+			events = append(events, ConnectorEvent{Time: now, Kind: ConnectionReconnecting, Connector: owner[id], Colo: conn.ColoName})
+		}
+	}
+	for id, conn := range l.conns {
+		if _, still := currentConns[id]; !still {
+			events = append(events, ConnectorEvent{Time: now, Kind: ConnectionUnregistered, Connector: l.connOwner[id], Colo: conn.ColoName})
+		}
+	}
+
+	l.connectors = currentConnectors
+	l.conns = currentConns
+	l.connOwner = owner
+	l.add(events)
+}
+
+// ObserveError records that the connector list couldn't be fetched, once per
+// run of failures
+func (l *ConnectorEventLog) ObserveError(err error, now time.Time) {
+	if l.failing {
+		return
+	}
+	l.failing = true
+	l.add([]ConnectorEvent{{Time: now, Kind: ConnectorsUnavailable, Detail: err.Error()}})
+}
+
+// add appends events, keeping the log in time order and within its limit
+func (l *ConnectorEventLog) add(events []ConnectorEvent) {
+	if len(events) == 0 {
+		return
+	}
+	l.Events = append(l.Events, events...)
+	sort.SliceStable(l.Events, func(i, j int) bool { return l.Events[i].Time.Before(l.Events[j].Time) })
+	if len(l.Events) > connectorEventLimit {
+		l.Events = l.Events[len(l.Events)-connectorEventLimit:]
+	}
+}
+
+// Recent returns up to n events, newest first
+func (l *ConnectorEventLog) Recent(n int) []ConnectorEvent {
+	var recent []ConnectorEvent
+	for i := len(l.Events) - 1; i >= 0 && len(recent) < n; i-- {
+		recent = append(recent, l.Events[i])
+	}
+	return recent
+}
+
+// Problems counts the unregister, reconnect, stop and error events logged
+// since the given time
+func (l *ConnectorEventLog) Problems(since time.Time) int {
+	count := 0
+	for _, event := range l.Events {
+		if event.IsProblem() && !event.Time.Before(since) {
+			count++
+		}
+	}
+	return count
+}
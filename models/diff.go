@@ -0,0 +1,134 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConfigUpdateCancelled is returned when a configuration update is
+// rejected at the diff confirmation prompt
+var ErrConfigUpdateCancelled = errors.New("configuration update cancelled")
+
+type IngressChangeKind string
+
+const (
+	IngressAdded   IngressChangeKind = "added"
+	IngressRemoved IngressChangeKind = "removed"
+	IngressChanged IngressChangeKind = "changed"
+)
+
+// IngressChange is a single rule difference between two ingress lists
+type IngressChange struct {
+	Kind     IngressChangeKind
+	Hostname string
+	Path     string
+	// OldService is empty for added rules, NewService for removed ones
+	OldService string
+	NewService string
+}
+
+func (ch IngressChange) String() string {
+	target := ch.Hostname
+	if target == "" {
+		target = "(catch-all)"
+	}
+	if ch.Path != "" {
+		target += "/" + ch.Path
+	}
+
+	switch ch.Kind {
+	case IngressAdded:
+		return fmt.Sprintf("+ %s → %s", target, ch.NewService)
+	case IngressRemoved:
+		return fmt.Sprintf("- %s → %s", target, ch.OldService)
+	default:
+		return fmt.Sprintf("~ %s: %s → %s", target, ch.OldService, ch.NewService)
+	}
+}
+
+// ConfigUpdateConfirmer decides whether a configuration update with the
+// given ingress changes may proceed
+type ConfigUpdateConfirmer func(tunnelID string, changes []IngressChange) bool
+
+// DiffIngress compares ingress rules by hostname and path. Rules are listed
+// in the order they appear, removals from old first.
+func DiffIngress(old, new []TunnelConfigIngress) []IngressChange {
+	key := func(rule TunnelConfigIngress) string { return rule.Hostname + "\x00" + rule.Path }
+
+	newRules := make(map[string]TunnelConfigIngress)
+	for _, rule := range new {
+		newRules[key(rule)] = rule
+	}
+	oldRules := make(map[string]TunnelConfigIngress)
+	for _, rule := range old {
+		oldRules[key(rule)] = rule
+	}
+
+	var changes []IngressChange
+	for _, rule := range old {
+		updated, exists := newRules[key(rule)]
+		if !exists {
+			changes = append(changes, IngressChange{Kind: IngressRemoved, Hostname: rule.Hostname, Path: rule.Path, OldService: rule.Service})
+		} else if updated.Service != rule.Service {
+			changes = append(changes, IngressChange{Kind: IngressChanged, Hostname: rule.Hostname, Path: rule.Path, OldService: rule.Service, NewService: updated.Service})
+		}
+	}
+	for _, rule := range new {
+		if _, exists := oldRules[key(rule)]; !exists {
+			changes = append(changes, IngressChange{Kind: IngressAdded, Hostname: rule.Hostname, Path: rule.Path, NewService: rule.Service})
+		}
+	}
+
+	return changes
+}
+
+// SetConfigUpdateConfirmer installs a callback that is shown the ingress diff
+// before every tunnel configuration write. Passing nil disables confirmation.
+func (c *CloudflareClient) SetConfigUpdateConfirmer(confirm ConfigUpdateConfirmer) {
+	c.confirmConfigUpdate = confirm
+}
+
+// confirmIngressChanges asks the installed confirmer, if any, to approve the
+// difference between the tunnel's current ingress and config. It reports
+// whether the user was prompted.
+func (c *CloudflareClient) confirmIngressChanges(tunnelID string, current *TunnelConfiguration, config *TunnelConfigData) (bool, error) {
+	if c.confirmConfigUpdate == nil {
+		return false, nil
+	}
+
+	changes := DiffIngress(current.Config.Ingress, config.Ingress)
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	if !c.confirmConfigUpdate(tunnelID, changes) {
+		return true, ErrConfigUpdateCancelled
+	}
+	return true, nil
+}
+
+// ConfigVersionConflictError is returned when a tunnel's configuration was
+// changed remotely between reading and writing it. Written is set when the
+// change only showed after the write, which may have overwritten it.
+type ConfigVersionConflictError struct {
+	TunnelID string
+	Expected int
+	Actual   int
+	Written  bool
+}
+
+func (e *ConfigVersionConflictError) Error() string {
+	if e.Written {
+		return fmt.Sprintf("config changed remotely while saving: tunnel %s is at version %d (expected %d), so a change made elsewhere may have been overwritten - refresh and check", e.TunnelID, e.Actual, e.Expected)
+	}
+	return fmt.Sprintf("config changed remotely: tunnel %s is now at version %d (expected %d) - refresh and try again", e.TunnelID, e.Actual, e.Expected)
+}
+
+// checkConfigVersion compares the remote version with the one the caller
+// read the configuration at, unless that one is negative
+func checkConfigVersion(tunnelID string, current, version int) error {
+	if version >= 0 && current != version {
+		return &ConfigVersionConflictError{TunnelID: tunnelID, Expected: version, Actual: current}
+	}
+	return nil
+}
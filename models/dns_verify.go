@@ -0,0 +1,165 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// DoHResolvers are the public resolvers VerifyHostnameDNS asks, by name
+var DoHResolvers = []struct {
+	Name     string
+	Endpoint string
+}{
+	{"Cloudflare (1.1.1.1)", CloudflareDoH},
+	{"Google (8.8.8.8)", GoogleDoH},
+}
+
+// cloudflareRanges are Cloudflare's published edge ranges. A proxied record
+// resolves to addresses in them instead of exposing its CNAME.
+var cloudflareRanges = func() []*net.IPNet {
+	var ranges []*net.IPNet
+	for _, cidr := range []string{
+		"173.245.48.0/20", "103.21.244.0/22", "103.22.200.0/22", "103.31.4.0/22",
+		"141.101.64.0/18", "108.162.192.0/18", "190.93.240.0/20", "188.114.96.0/20",
+		"197.234.240.0/22", "198.41.128.0/17", "162.158.0.0/15", "104.16.0.0/13",
+		"104.24.0.0/14", "172.64.0.0/13", "131.0.72.0/22",
+		"2400:cb00::/32", "2606:4700::/32", "2803:f800::/32", "2405:b500::/32",
+		"2405:8100::/32", "2a06:98c0::/29", "2c0f:f248::/32",
+	} {
+		_, network, _ := net.ParseCIDR(cidr)
+		ranges = append(ranges, network)
+	}
+	return ranges
+}()
+
+func isCloudflareAddress(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range cloudflareRanges {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolverAnswer is what one public resolver returns for a hostname
+type ResolverAnswer struct {
+	Resolver  string
+	CNAME     string
+	Addresses []string
+	OK        bool
+	Verdict   string
+}
+
+// DNSVerification compares what public resolvers see for a hostname with the
+// CNAME its tunnel needs. Record is the hostname's record in the account, if
+// the hostname is in one of its zones.
+type DNSVerification struct {
+	Hostname string
+	Expected string
+	Record   *DNSRecord
+	Answers  []ResolverAnswer
+}
+
+// OK reports whether every resolver sees the hostname pointing at the tunnel
+func (v *DNSVerification) OK() bool {
+	for _, answer := range v.Answers {
+		if !answer.OK {
+			return false
+		}
+	}
+	return len(v.Answers) > 0
+}
+
+// VerifyHostnameDNS queries hostname over DNS-over-HTTPS at each of
+// DoHResolvers and checks the answers against <tunnelID>.cfargotunnel.com.
+// Proxied records never show their CNAME publicly, so Cloudflare edge
+// addresses count as pointing at the tunnel when the account's record does.
+func (c *CloudflareClient) VerifyHostnameDNS(ctx context.Context, hostname, tunnelID string) (*DNSVerification, error) {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	verification := &DNSVerification{
+		Hostname: hostname,
+		Expected: fmt.Sprintf("%s.cfargotunnel.com", tunnelID),
+	}
+
+	if zoneID, _, err := c.ZoneForHostname(ctx, hostname); err == nil {
+		records, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{Name: hostname})
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up DNS record for %s: %w", hostname, err)
+		}
+		if len(records) > 0 {
+			record := records[0]
+			proxied := record.Proxied != nil && *record.Proxied
+			verification.Record = NewDNSRecord(record.Name, record.Type, record.Content, proxied)
+		}
+	}
+
+	for _, resolver := range DoHResolvers {
+		verification.Answers = append(verification.Answers, verification.check(ctx, resolver.Name, resolver.Endpoint))
+	}
+
+	return verification, nil
+}
+
+func (v *DNSVerification) check(ctx context.Context, resolver, endpoint string) ResolverAnswer {
+	answer := ResolverAnswer{Resolver: resolver}
+
+	resp, err := QueryDoH(ctx, endpoint, v.Hostname, "A")
+	if err != nil {
+		answer.Verdict = err.Error()
+		return answer
+	}
+	if resp.Status != dnsRcodeNoError {
+		answer.Verdict = fmt.Sprintf("lookup failed (rcode %d, 3 is NXDOMAIN)", resp.Status)
+		return answer
+	}
+
+	for _, record := range resp.Answer {
+		data := strings.TrimSuffix(record.Data, ".")
+		switch record.Type {
+		case dnsTypeCNAME:
+			if answer.CNAME == "" {
+				answer.CNAME = strings.ToLower(data)
+			}
+		case dnsTypeA, dnsTypeAAAA:
+			answer.Addresses = append(answer.Addresses, data)
+		}
+	}
+
+	switch {
+	case answer.CNAME == v.Expected:
+		answer.OK = true
+		answer.Verdict = "CNAME points at the tunnel"
+	case answer.CNAME != "":
+		answer.Verdict = "CNAME points at " + answer.CNAME + " instead of the tunnel"
+	case len(answer.Addresses) == 0:
+		answer.Verdict = "no records"
+	case !allCloudflareAddresses(answer.Addresses):
+		answer.Verdict = "resolves to non-Cloudflare addresses " + strings.Join(answer.Addresses, ", ")
+	case v.Record != nil && v.Record.Type == "CNAME" && strings.EqualFold(v.Record.Content, v.Expected):
+		answer.OK = true
+		answer.Verdict = "proxied through Cloudflare to the tunnel"
+	case v.Record != nil:
+		answer.Verdict = fmt.Sprintf("proxied through Cloudflare, but the %s record points at %s", v.Record.Type, v.Record.Content)
+	default:
+		answer.Verdict = "served by Cloudflare, but not from a record in this account"
+	}
+
+	return answer
+}
+
+func allCloudflareAddresses(addresses []string) bool {
+	for _, address := range addresses {
+		if !isCloudflareAddress(address) {
+			return false
+		}
+	}
+	return true
+}
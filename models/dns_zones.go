@@ -0,0 +1,87 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// DNSZone is a zone in the account as listed by the DNS tab. RecordCount is
+// -1 until it has been counted.
+type DNSZone struct {
+	ID          string
+	Name        string
+	RecordCount int
+}
+
+// ListDNSZones returns the account's zones sorted by name, without loading
+// any of their records
+func (c *CloudflareClient) ListDNSZones(ctx context.Context) ([]DNSZone, error) {
+	zones, err := c.api.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	result := make([]DNSZone, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, DNSZone{ID: zone.ID, Name: zone.Name, RecordCount: -1})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// CountDNSRecords returns how many DNS records a zone has by requesting a
+// single record and reading the total from the result info
+func (c *CloudflareClient) CountDNSRecords(ctx context.Context, zoneID string) (int, error) {
+	_, info, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
+		ResultInfo: cloudflare.ResultInfo{Page: 1, PerPage: 1},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count DNS records: %w", err)
+	}
+	if info == nil {
+		return 0, nil
+	}
+	return info.Total, nil
+}
+
+// ListZoneDNSRecords returns every DNS record in a zone sorted by name and type
+func (c *CloudflareClient) ListZoneDNSRecords(ctx context.Context, zoneID string) ([]DNSRecord, error) {
+	records, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	result := make([]DNSRecord, 0, len(records))
+	for _, record := range records {
+		r := DNSRecord{
+			ID:        record.ID,
+			ZoneID:    zoneID,
+			Name:      record.Name,
+			Type:      DNSRecordType(record.Type),
+			Content:   record.Content,
+			TTL:       record.TTL,
+			Comment:   record.Comment,
+			CreatedAt: record.CreatedOn,
+			UpdatedAt: record.ModifiedOn,
+		}
+		if record.Proxied != nil {
+			r.Proxied = *record.Proxied
+		}
+		if record.Priority != nil {
+			r.Priority = int(*record.Priority)
+		}
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Type < result[j].Type
+	})
+
+	return result, nil
+}
@@ -58,8 +58,10 @@ func (dm *DockerManager) findAvailablePort() (int, error) {
 	return port, nil
 }
 
-// StartTraefikContainer starts a Traefik container for the given hostname and returns the assigned port
-func (dm *DockerManager) StartTraefikContainer(hostname, originalService, authPassword string) (int, error) {
+// StartTraefikContainer starts a Traefik container for the given hostname and returns the assigned port.
+// users are the basic auth logins; middlewares are custom Traefik middleware
+// definitions applied after basic auth.
+func (dm *DockerManager) StartTraefikContainer(hostname, originalService string, users []AuthUser, middlewares map[string]interface{}) (int, error) {
 	ctx := context.Background()
 	containerName := GetTraefikContainerName(hostname)
 
@@ -87,7 +89,7 @@ func (dm *DockerManager) StartTraefikContainer(hostname, originalService, authPa
 	}
 
 	// Create Traefik config directory
-	configDir, err := dm.createTraefikConfig(hostname, originalService, authPassword)
+	configDir, err := dm.createTraefikConfig(hostname, originalService, users, middlewares)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create Traefik config: %w", err)
 	}
@@ -216,6 +218,53 @@ func (dm *DockerManager) IsContainerRunning(containerName string) bool {
 	return false
 }
 
+// ListManagedHostnames returns the hostnames of all Traefik containers created by tunnelman
+func (dm *DockerManager) ListManagedHostnames() ([]string, error) {
+	ctx := context.Background()
+	containers, err := dm.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var hostnames []string
+	for _, c := range containers {
+		if c.Labels["tunnelman.managed"] == "true" && c.Labels["tunnelman.hostname"] != "" {
+			hostnames = append(hostnames, c.Labels["tunnelman.hostname"])
+		}
+	}
+
+	return hostnames, nil
+}
+
+// StartContainer starts an existing, stopped container by name
+func (dm *DockerManager) StartContainer(ctx context.Context, containerName string) error {
+	if err := dm.client.ContainerStart(ctx, containerName, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container %s: %w", containerName, err)
+	}
+	return nil
+}
+
+// StopContainer stops a container by name, leaving it in place to be started again
+func (dm *DockerManager) StopContainer(containerName string) error {
+	timeout := 10
+	if err := dm.client.ContainerStop(context.Background(), containerName, container.StopOptions{Timeout: &timeout}); err != nil {
+		return fmt.Errorf("failed to stop container %s: %w", containerName, err)
+	}
+	return nil
+}
+
+// ContainerPID returns the host PID of a running container's main process
+func (dm *DockerManager) ContainerPID(containerName string) (int, error) {
+	containerJSON, err := dm.client.ContainerInspect(context.Background(), containerName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if containerJSON.State == nil {
+		return 0, nil
+	}
+	return containerJSON.State.Pid, nil
+}
+
 // RemoveContainer removes a container by name
 func (dm *DockerManager) RemoveContainer(containerName string) error {
 	ctx := context.Background()
@@ -226,23 +275,12 @@ func (dm *DockerManager) RemoveContainer(containerName string) error {
 }
 
 // createTraefikConfig creates the Traefik configuration files
-func (dm *DockerManager) createTraefikConfig(hostname, originalService, authPassword string) (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	configDir := filepath.Join(homeDir, ".tunnelman", "traefik", hostname)
+func (dm *DockerManager) createTraefikConfig(hostname, originalService string, users []AuthUser, middlewares map[string]interface{}) (string, error) {
+	configDir := filepath.Join(GetStateDir(), "traefik", hostname)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Hash the password for basic auth
-	hashedPassword, err := HashPassword(authPassword)
-	if err != nil {
-		return "", fmt.Errorf("failed to hash password: %w", err)
-	}
-
 	// Convert localhost URLs to host.docker.internal so Traefik can reach the host from inside the container
 	dockerHostService := originalService
 	if strings.Contains(originalService, "localhost") {
@@ -251,44 +289,32 @@ func (dm *DockerManager) createTraefikConfig(hostname, originalService, authPass
 		dockerHostService = strings.Replace(originalService, "127.0.0.1", "host.docker.internal", 1)
 	}
 
-	// Create dynamic configuration
-	dynamicConfig := fmt.Sprintf(`http:
-  routers:
-    %s:
-      rule: "Host(\"%s\")"
-      service: %s-service
-      middlewares:
-        - %s-auth
-
-  services:
-    %s-service:
-      loadBalancer:
-        servers:
-          - url: "%s"
-
-  middlewares:
-    %s-auth:
-      basicAuth:
-        users:
-          - "tunnelman:%s"
-`, hostname, hostname, hostname, hostname, hostname, dockerHostService, hostname, hashedPassword)
+	dynamicConfig, err := buildTraefikConfig(hostname, dockerHostService, users, middlewares)
+	if err != nil {
+		return "", err
+	}
 
 	dynamicConfigPath := filepath.Join(configDir, "dynamic.yml")
-	if err := os.WriteFile(dynamicConfigPath, []byte(dynamicConfig), 0644); err != nil {
+	if err := os.WriteFile(dynamicConfigPath, dynamicConfig, 0644); err != nil {
 		return "", fmt.Errorf("failed to write dynamic config: %w", err)
 	}
 
 	return configDir, nil
 }
 
-// removeTraefikConfig removes the Traefik configuration directory
-func (dm *DockerManager) removeTraefikConfig(hostname string) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+// UpdateTraefikUsers rewrites a running auth proxy's logins; Traefik watches
+// its config file and picks the change up without a restart
+func (dm *DockerManager) UpdateTraefikUsers(hostname, originalService string, users []AuthUser, middlewares map[string]interface{}) error {
+	if !dm.IsContainerRunning(GetTraefikContainerName(hostname)) {
+		return fmt.Errorf("the auth proxy for %s isn't running", hostname)
 	}
+	_, err := dm.createTraefikConfig(hostname, originalService, users, middlewares)
+	return err
+}
 
-	configDir := filepath.Join(homeDir, ".tunnelman", "traefik", hostname)
+// removeTraefikConfig removes the Traefik configuration directory
+func (dm *DockerManager) removeTraefikConfig(hostname string) error {
+	configDir := filepath.Join(GetStateDir(), "traefik", hostname)
 	return os.RemoveAll(configDir)
 }
 
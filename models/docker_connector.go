@@ -0,0 +1,254 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+)
+
+// CloudflaredImage is the official connector image used for Docker connectors
+const CloudflaredImage = "cloudflare/cloudflared:latest"
+
+// GetConnectorContainerName returns the container name for a tunnel's Docker connector
+func GetConnectorContainerName(tunnelName string) string {
+	return fmt.Sprintf("tunnelman-cloudflared-%s", tunnelName)
+}
+
+// GetTunnelToken fetches the token a remotely managed tunnel's connector runs with
+func (c *CloudflareClient) GetTunnelToken(ctx context.Context, tunnelID string) (string, error) {
+	if c.accountID == "" {
+		return "", fmt.Errorf("account ID not available")
+	}
+
+	token, err := c.api.GetTunnelToken(ctx, cloudflare.AccountIdentifier(c.accountID), tunnelID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tunnel token: %w", err)
+	}
+	return token, nil
+}
+
+// StartConnectorContainer runs cloudflared for a tunnel in a container and
+// returns the container name. Host networking lets the connector reach
+// services on localhost just like a host-installed cloudflared. An empty
+// logLevel keeps cloudflared's default.
+func (dm *DockerManager) StartConnectorContainer(ctx context.Context, tunnelID, tunnelName, token, logLevel string) (string, error) {
+	containerName := GetConnectorContainerName(tunnelName)
+	if dm.IsContainerRunning(containerName) {
+		return containerName, nil
+	}
+
+	if err := dm.pullImage(ctx, CloudflaredImage); err != nil {
+		return "", fmt.Errorf("failed to pull cloudflared image: %w", err)
+	}
+
+	// Remove existing container if it exists but is stopped
+	dm.RemoveContainer(containerName)
+
+	config := &container.Config{
+		Image: CloudflaredImage,
+		Cmd:   []string{"tunnel", "--no-autoupdate", "run"},
+		// The token stays out of the container's command line
+		Env: []string{"TUNNEL_TOKEN=" + token},
+		Labels: map[string]string{
+			"tunnelman.managed":   "true",
+			"tunnelman.connector": tunnelID,
+			"tunnelman.tunnel":    tunnelName,
+		},
+	}
+
+	if logLevel != "" {
+		config.Env = append(config.Env, "TUNNEL_LOGLEVEL="+logLevel)
+	}
+
+	hostConfig := &container.HostConfig{
+		NetworkMode: "host",
+		RestartPolicy: container.RestartPolicy{
+			Name: "unless-stopped",
+		},
+	}
+
+	resp, err := dm.client.ContainerCreate(ctx, config, hostConfig, nil, nil, containerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cloudflared container: %w", err)
+	}
+
+	if err := dm.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return "", fmt.Errorf("failed to start cloudflared container: %w", err)
+	}
+
+	return containerName, nil
+}
+
+// ConnectorContainer is a Docker connector created by tunnelman, identified
+// by the labels it was started with
+type ConnectorContainer struct {
+	TunnelID   string
+	TunnelName string
+	Name       string
+}
+
+// ListConnectorContainers returns the Docker connectors created by tunnelman,
+// keyed by tunnel name
+func (dm *DockerManager) ListConnectorContainers() (map[string]ConnectorContainer, error) {
+	containers, err := dm.client.ContainerList(context.Background(), container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	connectors := make(map[string]ConnectorContainer)
+	for _, c := range containers {
+		tunnelID := c.Labels["tunnelman.connector"]
+		tunnelName := c.Labels["tunnelman.tunnel"]
+		if tunnelID == "" || tunnelName == "" || len(c.Names) == 0 {
+			continue
+		}
+		connectors[tunnelName] = ConnectorContainer{TunnelID: tunnelID, TunnelName: tunnelName, Name: strings.TrimPrefix(c.Names[0], "/")}
+	}
+
+	return connectors, nil
+}
+
+// pullImage pulls ref unless it is already available locally
+func (dm *DockerManager) pullImage(ctx context.Context, ref string) error {
+	if _, _, err := dm.client.ImageInspectWithRaw(ctx, ref); err == nil {
+		return nil
+	}
+
+	reader, err := dm.client.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// Consume the pull output
+	_, err = io.Copy(io.Discard, reader)
+	return err
+}
+
+// StartDockerConnector runs the tunnel's connector in a cloudflare/cloudflared
+// container and tracks it like any other managed process. The tunnel's
+// supervisor is switched to Docker so later stops and restarts reach the
+// container.
+func (tm *TunnelManager) StartDockerConnector(ctx context.Context, tunnelID, tunnelName string) (*TunnelProcess, error) {
+	if tm.client == nil {
+		return nil, fmt.Errorf("Cloudflare client not initialized")
+	}
+
+	tm.mutex.RLock()
+	err := tm.checkNotServiceManaged(tunnelName, tunnelID)
+	logLevel, _ := tm.logLevelFor(tunnelName)
+	tm.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tm.client.GetTunnelToken(ctx, tunnelID)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerManager, err := NewDockerManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Docker manager: %w", err)
+	}
+	defer dockerManager.Close()
+
+	if !dockerManager.IsDockerAvailable() {
+		return nil, fmt.Errorf("Docker is not available")
+	}
+
+	containerName, err := dockerManager.StartConnectorContainer(ctx, tunnelID, tunnelName, token, logLevel)
+	if err != nil {
+		return nil, err
+	}
+	pid, _ := dockerManager.ContainerPID(containerName)
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	supervisor := &dockerSupervisor{container: containerName}
+	tm.supervisors[tunnelName] = supervisor
+	process := tm.trackDockerConnector(tunnelID, tunnelName, supervisor, pid)
+	return process, nil
+}
+
+// StopDockerConnector stops and removes the tunnel's Docker connector
+func (tm *TunnelManager) StopDockerConnector(tunnelName string) error {
+	dockerManager, err := NewDockerManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize Docker manager: %w", err)
+	}
+	defer dockerManager.Close()
+
+	if err := dockerManager.RemoveContainer(GetConnectorContainerName(tunnelName)); err != nil {
+		return fmt.Errorf("failed to remove cloudflared container: %w", err)
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	delete(tm.supervisors, tunnelName)
+	delete(tm.processes, tunnelName)
+	return nil
+}
+
+// HasDockerConnector reports whether the tunnel's connector runs in a container
+func (tm *TunnelManager) HasDockerConnector(tunnelName string) bool {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+
+	process, exists := tm.processes[tunnelName]
+	return exists && process.Supervisor == SupervisorDocker
+}
+
+// AdoptDockerConnectors tracks connector containers left running by an
+// earlier session, since their restart policy keeps them up after tunnelman exits
+func (tm *TunnelManager) AdoptDockerConnectors() error {
+	dockerManager, err := NewDockerManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize Docker manager: %w", err)
+	}
+	defer dockerManager.Close()
+
+	connectors, err := dockerManager.ListConnectorContainers()
+	if err != nil {
+		return err
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	for tunnelName, connector := range connectors {
+		if _, exists := tm.processes[tunnelName]; exists {
+			continue
+		}
+		pid, _ := dockerManager.ContainerPID(connector.Name)
+		supervisor := &dockerSupervisor{container: connector.Name}
+		tm.supervisors[tunnelName] = supervisor
+		tm.trackDockerConnector(connector.TunnelID, tunnelName, supervisor, pid)
+	}
+
+	return nil
+}
+
+// trackDockerConnector registers a connector container; callers must hold tm.mutex
+func (tm *TunnelManager) trackDockerConnector(tunnelID, tunnelName string, supervisor *dockerSupervisor, pid int) *TunnelProcess {
+	process := &TunnelProcess{
+		PID:        pid,
+		TunnelID:   tunnelID,
+		Name:       tunnelName,
+		Command:    []string{"docker", "run", CloudflaredImage, "tunnel", "run"},
+		StartTime:  time.Now(),
+		Status:     StatusActive,
+		Supervisor: SupervisorDocker,
+		supervisor: supervisor,
+	}
+	tm.processes[tunnelName] = process
+	return process
+}
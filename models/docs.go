@@ -0,0 +1,128 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// GenerateTunnelDocs renders a Markdown summary of a tunnel's ingress:
+// hostnames, services, DNS records, auth status and connector setup. dm is
+// optional and only used to detect Traefik auth guards.
+func (c *CloudflareClient) GenerateTunnelDocs(ctx context.Context, nameOrID string, dm *DockerManager) (string, error) {
+	tunnel, err := c.GetTunnelInfo(ctx, nameOrID)
+	if err != nil {
+		return "", err
+	}
+
+	hostnames, err := c.GetPublicHostnames(ctx, tunnel.ID)
+	if err != nil {
+		return "", err
+	}
+	c.AnnotateBrowserRendering(ctx, hostnames)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Tunnel: %s\n\n", tunnel.Name)
+	fmt.Fprintf(&b, "_Generated by tunnelman on %s_\n\n", time.Now().Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&b, "- **ID:** `%s`\n", tunnel.ID)
+	if !tunnel.CreatedAt.IsZero() {
+		fmt.Fprintf(&b, "- **Created:** %s\n", tunnel.CreatedAt.Format("2006-01-02"))
+	}
+	colos := make([]string, 0, len(tunnel.Connections))
+	for _, conn := range tunnel.Connections {
+		colos = append(colos, conn.ColoName)
+	}
+	if len(colos) > 0 {
+		fmt.Fprintf(&b, "- **Connections:** %d (%s)\n", len(colos), strings.Join(colos, ", "))
+	} else {
+		fmt.Fprintf(&b, "- **Connections:** none\n")
+	}
+	b.WriteString("\n")
+
+	hostnameTable := ExportTable{
+		Title:   "Public Hostnames",
+		Headers: []string{"Hostname", "Path", "Service", "Auth", "Browser rendering"},
+	}
+	dnsTable := ExportTable{
+		Title:   "DNS Records",
+		Headers: []string{"Name", "Type", "Content", "Proxied"},
+	}
+	for _, hostname := range hostnames {
+		path := hostname.Path
+		if path == "" {
+			path = "*"
+		}
+
+		auth := "none"
+		if dm != nil && dm.IsContainerRunning(GetTraefikContainerName(hostname.Hostname)) {
+			auth = "basic auth (Traefik)"
+		}
+		rendering := "no"
+		if hostname.BrowserRendering {
+			rendering = "yes"
+		}
+		hostnameTable.Rows = append(hostnameTable.Rows, []string{"https://" + hostname.Hostname, path, hostname.Service, auth, rendering})
+
+		records, err := c.dnsRecordsForHostname(ctx, hostname.Hostname)
+		if err != nil {
+			dnsTable.Rows = append(dnsTable.Rows, []string{hostname.Hostname, "?", err.Error(), ""})
+			continue
+		}
+		if len(records) == 0 {
+			dnsTable.Rows = append(dnsTable.Rows, []string{hostname.Hostname, "-", "missing", ""})
+		}
+		for _, record := range records {
+			proxied := "no"
+			if record.Proxied != nil && *record.Proxied {
+				proxied = "yes"
+			}
+			dnsTable.Rows = append(dnsTable.Rows, []string{record.Name, record.Type, record.Content, proxied})
+		}
+	}
+
+	if len(hostnames) == 0 {
+		b.WriteString("## Public Hostnames\n\nNo public hostnames are configured.\n\n")
+	} else {
+		b.WriteString(hostnameTable.Markdown())
+		b.WriteString("\n")
+		b.WriteString(dnsTable.Markdown())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Running a Connector\n\n")
+	b.WriteString("Install `cloudflared` (see https://developers.cloudflare.com/cloudflare-one/connections/connect-networks/downloads/), then either run the tunnel with locally managed credentials:\n\n")
+	fmt.Fprintf(&b, "```bash\ncloudflared tunnel run %s\n```\n\n", tunnel.Name)
+	b.WriteString("or copy the connector token from the Cloudflare dashboard and install it as a service:\n\n")
+	fmt.Fprintf(&b, "```bash\nsudo cloudflared service install <TOKEN>\n```\n\n")
+	if team, err := c.GetZeroTrustTeam(ctx); err == nil {
+		b.WriteString("## Zero Trust\n\n")
+		fmt.Fprintf(&b, "- **Team:** %s\n", team)
+		fmt.Fprintf(&b, "- **App Launcher:** https://%s\n\n", TeamDomain(team))
+		b.WriteString("Enroll a device with WARP to reach private network routes:\n\n")
+		fmt.Fprintf(&b, "```bash\nwarp-cli registration new %s\n```\n\n", team)
+	}
+
+	fmt.Fprintf(&b, "Dashboard: %s\n", c.TunnelDashboardURL(tunnel.ID))
+
+	return b.String(), nil
+}
+
+// dnsRecordsForHostname lists the DNS records for hostname in its zone
+func (c *CloudflareClient) dnsRecordsForHostname(ctx context.Context, hostname string) ([]cloudflare.DNSRecord, error) {
+	zoneID, _, err := c.ZoneForHostname(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	records, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
+		Name: hostname,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DoH endpoints speaking the JSON flavour of DNS-over-HTTPS
+const (
+	CloudflareDoH = "https://cloudflare-dns.com/dns-query"
+	GoogleDoH     = "https://dns.google/resolve"
+)
+
+// DNS response codes and record types used in DoH answers
+const (
+	dnsRcodeNoError = 0
+	dnsTypeA        = 1
+	dnsTypeCNAME    = 5
+	dnsTypeAAAA     = 28
+)
+
+// DoHAnswer is one resource record of a DoH response
+type DoHAnswer struct {
+	Name string `json:"name"`
+	Type int    `json:"type"`
+	TTL  int    `json:"TTL"`
+	Data string `json:"data"`
+}
+
+// DoHResponse is the JSON answer of a DoH resolver. Status is the DNS
+// response code: 0 for NOERROR, 3 for NXDOMAIN.
+type DoHResponse struct {
+	Status int         `json:"Status"`
+	Answer []DoHAnswer `json:"Answer"`
+}
+
+// Resolves reports whether the name has at least one address or CNAME
+func (r *DoHResponse) Resolves() bool {
+	if r.Status != dnsRcodeNoError {
+		return false
+	}
+	for _, answer := range r.Answer {
+		switch answer.Type {
+		case dnsTypeA, dnsTypeAAAA, dnsTypeCNAME:
+			return true
+		}
+	}
+	return false
+}
+
+var dohClient = &http.Client{Timeout: 5 * time.Second}
+
+// QueryDoH asks the resolver at endpoint for the records of name of type
+// rrType (e.g. "A" or "CNAME")
+func QueryDoH(ctx context.Context, endpoint, name, rrType string) (*DoHResponse, error) {
+	query := url.Values{"name": {strings.TrimSuffix(name, ".")}, "type": {rrType}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build DoH request: %w", err)
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	resp, err := dohClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("DoH query to %s failed: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH query to %s failed with status %d", req.URL.Host, resp.StatusCode)
+	}
+
+	var result DoHResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse DoH response from %s: %w", req.URL.Host, err)
+	}
+	return &result, nil
+}
+
+// HostnamePropagated reports whether 1.1.1.1 already resolves hostname, the
+// sign that a newly created record is visible outside Cloudflare's API
+func HostnamePropagated(ctx context.Context, hostname string) (bool, error) {
+	resp, err := QueryDoH(ctx, CloudflareDoH, hostname, "A")
+	if err != nil {
+		return false, err
+	}
+	return resp.Resolves(), nil
+}
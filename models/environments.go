@@ -0,0 +1,58 @@
+package models
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// TunnelEnvironment returns the environment a tunnel belongs to according to
+// the tunnel_environments name patterns, or "" when none matches. When
+// several patterns match, the longest (most specific) one wins.
+func (c *Config) TunnelEnvironment(tunnelName string) string {
+	if c == nil {
+		return ""
+	}
+
+	name := strings.ToLower(tunnelName)
+	best, environment := -1, ""
+	for pattern, env := range c.TunnelEnvironments {
+		matched, err := path.Match(strings.ToLower(pattern), name)
+		if err != nil || !matched {
+			continue
+		}
+		if len(pattern) > best || (len(pattern) == best && env < environment) {
+			best, environment = len(pattern), env
+		}
+	}
+	return environment
+}
+
+// Environments returns the configured environment names, sorted
+func (c *Config) Environments() []string {
+	if c == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var environments []string
+	for _, env := range c.TunnelEnvironments {
+		if !seen[env] {
+			seen[env] = true
+			environments = append(environments, env)
+		}
+	}
+	sort.Strings(environments)
+	return environments
+}
+
+// TunnelsInEnvironment returns the tunnels that belong to env
+func (c *Config) TunnelsInEnvironment(tunnels []CLITunnel, env string) []CLITunnel {
+	var matching []CLITunnel
+	for _, tunnel := range tunnels {
+		if c.TunnelEnvironment(tunnel.Name) == env {
+			matching = append(matching, tunnel)
+		}
+	}
+	return matching
+}
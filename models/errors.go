@@ -0,0 +1,164 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error classes shared by the client, the tunnel manager and the views. Test
+// for them with errors.Is; *APIError and cloudflare-go errors match them by
+// HTTP status and Cloudflare error code.
+var (
+	ErrUnauthorized = errors.New("not authorized")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Cloudflare error codes that mean the token is invalid or lacks permission
+// even when the HTTP status doesn't say so
+var authErrorCodes = map[int]bool{
+	9109:  true, // Invalid access token
+	10000: true, // Authentication error
+}
+
+// Cloudflare error codes for missing resources
+var notFoundErrorCodes = map[int]bool{
+	81044: true, // DNS record does not exist
+}
+
+// APIError is a failed Cloudflare API request made without cloudflare-go
+type APIError struct {
+	StatusCode int
+	Codes      []int
+	Messages   []string
+}
+
+func (e *APIError) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("API request failed with status %d", e.StatusCode)
+	}
+	details := make([]string, len(e.Messages))
+	for i, message := range e.Messages {
+		details[i] = message
+		if i < len(e.Codes) && e.Codes[i] != 0 {
+			details[i] = fmt.Sprintf("%s (%d)", message, e.Codes[i])
+		}
+	}
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, strings.Join(details, ", "))
+}
+
+// Is matches the error classes by status code and error code
+func (e *APIError) Is(target error) bool {
+	return classMatches(target, e.StatusCode, e.Codes)
+}
+
+// newAPIError builds an APIError from a response envelope's errors array
+func newAPIError(statusCode int, errs []map[string]interface{}) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+	for _, e := range errs {
+		code, _ := e["code"].(float64)
+		message, _ := e["message"].(string)
+		apiErr.Codes = append(apiErr.Codes, int(code))
+		apiErr.Messages = append(apiErr.Messages, message)
+	}
+	return apiErr
+}
+
+func classMatches(target error, statusCode int, codes []int) bool {
+	hasCode := func(known map[int]bool) bool {
+		for _, code := range codes {
+			if known[code] {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch target {
+	case ErrUnauthorized:
+		return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden || hasCode(authErrorCodes)
+	case ErrNotFound:
+		return statusCode == http.StatusNotFound || hasCode(notFoundErrorCodes)
+	case ErrRateLimited:
+		return statusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// errorIs is errors.Is that also classifies errors returned by cloudflare-go
+func errorIs(err, target error) bool {
+	if errors.Is(err, target) {
+		return true
+	}
+	var cfErr *cloudflare.Error
+	if errors.As(err, &cfErr) {
+		return classMatches(target, cfErr.StatusCode, cfErr.ErrorCodes)
+	}
+	return false
+}
+
+// IsNotFoundError reports whether err means the resource doesn't exist
+func IsNotFoundError(err error) bool {
+	return errorIs(err, ErrNotFound)
+}
+
+// IsAuthenticationError reports whether err means the API token is invalid
+// or lacks a permission
+func IsAuthenticationError(err error) bool {
+	return errorIs(err, ErrUnauthorized)
+}
+
+// IsRateLimitError reports whether err was caused by Cloudflare rate limiting
+func IsRateLimitError(err error) bool {
+	return errorIs(err, ErrRateLimited)
+}
+
+// IsRetryable reports whether a request that failed with err is worth
+// retrying: it was rate limited or Cloudflare had an internal error.
+// Requests that were rejected can't succeed by being sent again.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsRateLimitError(err) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var cfErr *cloudflare.Error
+	if errors.As(err, &cfErr) {
+		return cfErr.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// maxRequestAttempts bounds retries of a single API request
+const maxRequestAttempts = 4
+
+// withRetry runs request until it succeeds, fails with an error that isn't
+// retryable, or runs out of attempts, backing off exponentially from one
+// second between attempts
+func withRetry(ctx context.Context, request func() error) error {
+	delay := time.Second
+	for attempt := 1; ; attempt++ {
+		err := request()
+		if err == nil || attempt == maxRequestAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
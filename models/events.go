@@ -0,0 +1,193 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Change event types
+const (
+	EventHostnameAdded   = "hostname.added"
+	EventHostnameChanged = "hostname.changed"
+	EventHostnameRemoved = "hostname.removed"
+	EventDNSCreated      = "dns.created"
+	EventDNSDeleted      = "dns.deleted"
+)
+
+const (
+	// eventQueueSize is how many events wait for delivery before new ones
+	// are dropped
+	eventQueueSize = 100
+	// eventDeliveryTimeout bounds each webhook request or socket write
+	eventDeliveryTimeout = 5 * time.Second
+)
+
+// EventSinks says where change events are sent. Either or both may be set.
+type EventSinks struct {
+	// WebhookURL receives each event as a JSON POST
+	WebhookURL string `json:"webhook_url,omitempty"`
+	// Socket is a Unix socket path that receives each event as a JSON line
+	Socket string `json:"socket,omitempty"`
+}
+
+// ChangeEvent is emitted after tunnelman changes a hostname's ingress rule
+// or DNS record, for automation such as cache purges or chat notifications
+type ChangeEvent struct {
+	Type       string    `json:"type"`
+	Time       time.Time `json:"time"`
+	TunnelID   string    `json:"tunnel_id,omitempty"`
+	Hostname   string    `json:"hostname"`
+	Path       string    `json:"path,omitempty"`
+	Service    string    `json:"service,omitempty"`
+	OldService string    `json:"old_service,omitempty"`
+	// Record is the DNS record's content, e.g. <tunnel ID>.cfargotunnel.com
+	Record string `json:"record,omitempty"`
+}
+
+// eventsEnabled reports whether any event sink is configured
+func (c *CloudflareClient) eventsEnabled() bool {
+	return c.config != nil && c.config.Events != nil && (c.config.Events.WebhookURL != "" || c.config.Events.Socket != "")
+}
+
+// emit queues event for delivery without blocking the caller. Events are
+// delivered one at a time, in order, by a single goroutine.
+func (c *CloudflareClient) emit(event ChangeEvent) {
+	if !c.eventsEnabled() {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	c.eventsMu.Lock()
+	if c.eventsClosed {
+		c.eventsMu.Unlock()
+		c.warnf("emit change event", "events closed, dropped %s for %s", event.Type, event.Hostname)
+		return
+	}
+	if c.events == nil {
+		c.events = make(chan ChangeEvent, eventQueueSize)
+		c.eventsDone = make(chan struct{})
+		go c.deliverEvents(*c.config.Events)
+	}
+	queued := true
+	select {
+	case c.events <- event:
+	default:
+		queued = false
+	}
+	c.eventsMu.Unlock()
+
+	if !queued {
+		c.warnf("emit change event", "queue full, dropped %s for %s", event.Type, event.Hostname)
+	}
+}
+
+// CloseEvents stops queueing change events and waits until the queued ones
+// are delivered, or ctx is done. Call it before exiting so the last events
+// aren't lost; events emitted afterwards are dropped.
+func (c *CloudflareClient) CloseEvents(ctx context.Context) error {
+	c.eventsMu.Lock()
+	if c.eventsClosed {
+		c.eventsMu.Unlock()
+		return nil
+	}
+	c.eventsClosed = true
+	done := c.eventsDone
+	if c.events != nil {
+		close(c.events)
+	}
+	c.eventsMu.Unlock()
+
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("change events not delivered: %w", ctx.Err())
+	}
+}
+
+// emitIngressChanges emits an event for each hostname rule that differs
+// between two ingress lists
+func (c *CloudflareClient) emitIngressChanges(tunnelID string, old, new []TunnelConfigIngress) {
+	now := time.Now()
+	for _, change := range DiffIngress(old, new) {
+		if change.Hostname == "" {
+			continue
+		}
+		event := ChangeEvent{Time: now, TunnelID: tunnelID, Hostname: change.Hostname, Path: change.Path}
+		switch change.Kind {
+		case IngressAdded:
+			event.Type = EventHostnameAdded
+			event.Service = change.NewService
+		case IngressRemoved:
+			event.Type = EventHostnameRemoved
+			event.OldService = change.OldService
+		default:
+			event.Type = EventHostnameChanged
+			event.Service = change.NewService
+			event.OldService = change.OldService
+		}
+		c.emit(event)
+	}
+}
+
+func (c *CloudflareClient) deliverEvents(sinks EventSinks) {
+	defer close(c.eventsDone)
+	client := &http.Client{Timeout: eventDeliveryTimeout}
+	for event := range c.events {
+		body, err := json.Marshal(event)
+		if err != nil {
+			c.warnf("emit change event", "%v", err)
+			continue
+		}
+		if sinks.WebhookURL != "" {
+			if err := postEvent(client, sinks.WebhookURL, body); err != nil {
+				c.warnf("emit change event", "webhook: %v", err)
+			}
+		}
+		if sinks.Socket != "" {
+			if err := writeEvent(sinks.Socket, body); err != nil {
+				c.warnf("emit change event", "socket: %v", err)
+			}
+		}
+	}
+}
+
+func postEvent(client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tunnelman")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return nil
+}
+
+func writeEvent(socket string, body []byte) error {
+	conn, err := net.DialTimeout("unix", socket, eventDeliveryTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetWriteDeadline(time.Now().Add(eventDeliveryTimeout))
+	_, err = conn.Write(append(body, '\n'))
+	return err
+}
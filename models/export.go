@@ -0,0 +1,91 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportMarkdown ExportFormat = "md"
+	ExportCSV      ExportFormat = "csv"
+)
+
+// ExportTable is a rendered table with untruncated cell values
+type ExportTable struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// Markdown renders the table as a GitHub-flavored Markdown table
+func (t ExportTable) Markdown() string {
+	var b strings.Builder
+	if t.Title != "" {
+		fmt.Fprintf(&b, "## %s\n\n", t.Title)
+	}
+
+	writeRow := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(escaped, " | "))
+	}
+
+	writeRow(t.Headers)
+	separator := make([]string, len(t.Headers))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeRow(separator)
+	for _, row := range t.Rows {
+		writeRow(row)
+	}
+
+	return b.String()
+}
+
+// CSV renders the table as RFC 4180 CSV with a header row
+func (t ExportTable) CSV() (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(t.Headers); err != nil {
+		return "", err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// WriteExport writes the table to dir as <name>-<timestamp>.<format> and
+// returns the path of the new file
+func WriteExport(dir, name string, format ExportFormat, table ExportTable) (string, error) {
+	var content string
+	switch format {
+	case ExportMarkdown:
+		content = table.Markdown()
+	case ExportCSV:
+		var err error
+		if content, err = table.CSV(); err != nil {
+			return "", fmt.Errorf("failed to render CSV: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	path := filepath.Join(dir, filename)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return path, nil
+}
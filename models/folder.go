@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FolderServicePrefix marks a service entered as folder:<path>, which
+// tunnelman serves itself instead of proxying to an existing origin
+const FolderServicePrefix = "folder:"
+
+// ServedFolder is a hostname whose origin is a directory (or single file)
+// served by tunnelman. The port is kept so the server can be restarted on the
+// same address the tunnel's ingress rule points at.
+type ServedFolder struct {
+	Hostname string `json:"hostname"`
+	Root     string `json:"root"`
+	Port     int    `json:"port"`
+}
+
+// FolderServiceRoot returns the path of a folder:<path> service
+func FolderServiceRoot(service string) (string, bool) {
+	if !strings.HasPrefix(service, FolderServicePrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(service, FolderServicePrefix)), true
+}
+
+// ResolveServedPath expands ~ and makes path absolute, checking that it exists
+func ResolveServedPath(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("no folder given")
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return "", fmt.Errorf("cannot serve %s: %w", abs, err)
+	}
+	return abs, nil
+}
+
+// FolderHandler serves a directory with listings, or a single file at both
+// / and /<name>
+func FolderHandler(root string) (http.Handler, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot serve %s: %w", root, err)
+	}
+	if info.IsDir() {
+		return http.FileServer(http.Dir(root)), nil
+	}
+
+	name := "/" + filepath.Base(root)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != name {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filepath.Base(root)))
+		http.ServeFile(w, r, root)
+	}), nil
+}
+
+// FolderServerKey registers a hostname's folder server with the TunnelManager
+func FolderServerKey(hostname string) string {
+	return FolderServicePrefix + hostname
+}
+
+// ServeFolder starts serving root for hostname on port, or a random port when 0
+func (tm *TunnelManager) ServeFolder(hostname, root string, port int) (*StaticServer, error) {
+	handler, err := FolderHandler(root)
+	if err != nil {
+		return nil, err
+	}
+	return tm.StartStaticServer(FolderServerKey(hostname), root, port, handler)
+}
+
+// AddServedFolder records a folder served for a hostname, replacing any
+// existing record for the same hostname
+func (s *AppState) AddServedFolder(folder ServedFolder) {
+	s.RemoveServedFolder(folder.Hostname)
+	s.ServedFolders = append(s.ServedFolders, folder)
+}
+
+func (s *AppState) RemoveServedFolder(hostname string) bool {
+	for i, folder := range s.ServedFolders {
+		if folder.Hostname == hostname {
+			s.ServedFolders = append(s.ServedFolders[:i], s.ServedFolders[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetServedFolder returns the folder served for a hostname, if any
+func (s *AppState) GetServedFolder(hostname string) (*ServedFolder, bool) {
+	for i, folder := range s.ServedFolders {
+		if folder.Hostname == hostname {
+			return &s.ServedFolders[i], true
+		}
+	}
+	return nil, false
+}
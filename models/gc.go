@@ -0,0 +1,144 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// GCReport lists everything removed (or that would be removed on a dry run)
+// by GarbageCollect
+type GCReport struct {
+	Tunnels     []string
+	DNSRecords  []string
+	ConfigFiles []string
+	Containers  []string
+	Errors      []error
+}
+
+func (r *GCReport) fail(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Errorf(format, args...))
+}
+
+// GarbageCollect removes tunnels, local config files and Traefik auth
+// containers whose name starts with prefix, and the DNS records pointing at
+// those tunnels. Records are matched on their target only, so ones that
+// merely share the prefix but route to another tunnel are left alone.
+// zoneName limits the DNS scan to one zone ("" scans them all). tm and dm are
+// optional; local files and containers are skipped when they are nil.
+// Failures are collected in the report rather than aborting the run.
+func (c *CloudflareClient) GarbageCollect(ctx context.Context, prefix, zoneName string, tm *TunnelManager, dm *DockerManager, dryRun bool) (*GCReport, error) {
+	if strings.TrimSpace(prefix) == "" {
+		return nil, fmt.Errorf("a non-empty prefix is required")
+	}
+
+	report := &GCReport{}
+
+	tunnels, err := c.ListTunnels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[string]CLITunnel)
+	for _, tunnel := range tunnels {
+		if strings.HasPrefix(tunnel.Name, prefix) {
+			matched[tunnel.ID] = tunnel
+		}
+	}
+
+	// DNS records go first so nothing is left pointing at a deleted tunnel
+	var zones []cloudflare.Zone
+	if len(matched) > 0 {
+		var zoneNames []string
+		if zoneName != "" {
+			zoneNames = append(zoneNames, zoneName)
+		}
+		if zones, err = c.api.ListZones(ctx, zoneNames...); err != nil {
+			report.fail("failed to list zones: %w", err)
+		}
+	}
+	for _, zone := range zones {
+		records, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zone.ID), cloudflare.ListDNSRecordsParams{Type: "CNAME"})
+		if err != nil {
+			report.fail("failed to list DNS records in %s: %w", zone.Name, err)
+			continue
+		}
+
+		for _, record := range records {
+			targetID := tunnelIDFromCNAME(record.Content)
+			if _, ok := matched[targetID]; !ok {
+				continue
+			}
+
+			if !dryRun {
+				if err := c.api.DeleteDNSRecord(ctx, cloudflare.ZoneIdentifier(zone.ID), record.ID); err != nil {
+					report.fail("failed to delete DNS record %s: %w", record.Name, err)
+					continue
+				}
+				c.emit(ChangeEvent{Type: EventDNSDeleted, TunnelID: targetID, Hostname: record.Name, Record: record.Content})
+			}
+			report.DNSRecords = append(report.DNSRecords, record.Name)
+		}
+	}
+
+	if dm != nil {
+		hostnames, err := dm.ListManagedHostnames()
+		if err != nil {
+			report.fail("%w", err)
+		}
+		for _, hostname := range hostnames {
+			if !strings.HasPrefix(hostname, prefix) {
+				continue
+			}
+			if !dryRun {
+				dm.RemoveContainer(GetTraefikContainerName(hostname))
+				if err := dm.removeTraefikConfig(hostname); err != nil {
+					report.fail("failed to remove Traefik config for %s: %w", hostname, err)
+					continue
+				}
+			}
+			report.Containers = append(report.Containers, GetTraefikContainerName(hostname))
+		}
+	}
+
+	if tm != nil {
+		names, err := tm.ListConfigFiles()
+		if err != nil {
+			report.fail("%w", err)
+		}
+		for _, name := range names {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			if !dryRun {
+				if err := tm.DeleteTunnelConfig(name); err != nil {
+					report.fail("failed to delete config %s: %w", name, err)
+					continue
+				}
+			}
+			report.ConfigFiles = append(report.ConfigFiles, name+".yml")
+		}
+	}
+
+	for _, tunnel := range tunnels {
+		if _, ok := matched[tunnel.ID]; !ok {
+			continue
+		}
+		if !dryRun {
+			if err := c.DeleteTunnel(ctx, tunnel.ID); err != nil {
+				report.fail("failed to delete tunnel %s: %w", tunnel.Name, err)
+				continue
+			}
+			if tm != nil {
+				if err := tm.DeleteCredentialsFile(tunnel.ID); err != nil {
+					report.fail("failed to delete credentials for %s: %w", tunnel.Name, err)
+				}
+			}
+		}
+		report.Tunnels = append(report.Tunnels, tunnel.Name)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,223 @@
+package models
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const handoffManifest = "manifest.json"
+
+// HandoffManifest describes a handoff bundle: what a teammate needs to run
+// a connector for one tunnel
+type HandoffManifest struct {
+	TunnelID     string    `json:"tunnel_id"`
+	TunnelName   string    `json:"tunnel_name"`
+	CreatedAt    time.Time `json:"created_at"`
+	RemoteConfig bool      `json:"remote_config"`
+	Hostnames    []string  `json:"hostnames"`
+	Recipients   []string  `json:"recipients"`
+	Files        []string  `json:"files"`
+}
+
+// TunnelCredentials is the credentials file cloudflared runs a locally
+// managed tunnel with
+type TunnelCredentials struct {
+	AccountTag   string `json:"AccountTag"`
+	TunnelSecret string `json:"TunnelSecret"`
+	TunnelID     string `json:"TunnelID"`
+}
+
+// CredentialsFromToken decodes a connector token, which is base64 encoded
+// JSON of the form {"a": account, "t": tunnel, "s": secret}
+func CredentialsFromToken(token string) (*TunnelCredentials, error) {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		if data, err = base64.RawStdEncoding.DecodeString(token); err != nil {
+			return nil, fmt.Errorf("malformed tunnel token: %w", err)
+		}
+	}
+
+	var payload struct {
+		AccountTag   string `json:"a"`
+		TunnelID     string `json:"t"`
+		TunnelSecret string `json:"s"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("malformed tunnel token: %w", err)
+	}
+	return &TunnelCredentials{AccountTag: payload.AccountTag, TunnelSecret: payload.TunnelSecret, TunnelID: payload.TunnelID}, nil
+}
+
+// CreateHandoffBundle packs the tunnel's connector token, a credentials
+// file, a suggested cloudflared config mirroring its ingress and its
+// hostname docs into a tar.gz encrypted to recipients with age. dm is
+// optional and only used by the docs to detect Traefik auth guards.
+func (c *CloudflareClient) CreateHandoffBundle(ctx context.Context, nameOrID string, recipients []AgeRecipient, dm *DockerManager) ([]byte, *HandoffManifest, error) {
+	tunnel, err := c.GetTunnelInfo(ctx, nameOrID)
+	if err != nil {
+		return nil, nil, err
+	}
+	token, err := c.GetTunnelToken(ctx, tunnel.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	credentials, err := CredentialsFromToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	remote, err := c.GetTunnelConfiguration(ctx, tunnel.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	docs, err := c.GenerateTunnelDocs(ctx, tunnel.ID, dm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	manifest := &HandoffManifest{
+		TunnelID:     tunnel.ID,
+		TunnelName:   tunnel.Name,
+		CreatedAt:    time.Now(),
+		RemoteConfig: remote.Source == "cloudflare",
+	}
+	for _, recipient := range recipients {
+		manifest.Recipients = append(manifest.Recipients, recipient.String())
+	}
+
+	config := &TunnelConfigFile{TunnelID: tunnel.ID, CredentialsFile: tunnel.ID + ".json"}
+	for _, rule := range remote.Config.Ingress {
+		ingress := IngressRule{Hostname: rule.Hostname, Service: rule.Service, Path: rule.Path}
+		if len(rule.OriginRequest) > 0 {
+			ingress.Extra = map[string]interface{}{"originRequest": rule.OriginRequest}
+		}
+		config.Ingress = append(config.Ingress, ingress)
+		if rule.Hostname != "" {
+			manifest.Hostnames = append(manifest.Hostnames, rule.Hostname)
+		}
+	}
+	if len(config.Ingress) == 0 || config.Ingress[len(config.Ingress)-1].Hostname != "" {
+		config.Ingress = append(config.Ingress, IngressRule{Service: "http_status:404"})
+	}
+
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	credentialsData, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	files := map[string][]byte{
+		handoffConfigFile(tunnel.Name): configData,
+		tunnel.ID + ".json":            credentialsData,
+		handoffTokenFile(tunnel.Name):  []byte(token + "\n"),
+		handoffDocsFile(tunnel.Name):   []byte(docs + handoffInstructions(manifest)),
+	}
+	for name := range files {
+		manifest.Files = append(manifest.Files, name)
+	}
+	sort.Strings(manifest.Files)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	files[handoffManifest] = manifestData
+
+	archive, err := writeArchive(append([]string{handoffManifest}, manifest.Files...), files, manifest.CreatedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	encrypted, err := AgeEncrypt(archive, recipients...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encrypted, manifest, nil
+}
+
+func handoffConfigFile(tunnelName string) string { return tunnelName + ".yml" }
+func handoffTokenFile(tunnelName string) string  { return tunnelName + ".token" }
+func handoffDocsFile(tunnelName string) string   { return tunnelName + ".md" }
+
+// handoffInstructions is the part of the bundle's docs that explains its
+// files
+func handoffInstructions(manifest *HandoffManifest) string {
+	name := manifest.TunnelName
+	var b strings.Builder
+	b.WriteString("\n## Running From This Bundle\n\n")
+	fmt.Fprintf(&b, "`%s` is the connector token and `%s.json` the credentials derived from it. Either lets whoever holds it serve traffic for every hostname of the tunnel, so keep them out of version control and shell history.\n\n", handoffTokenFile(name), manifest.TunnelID)
+	b.WriteString("Run a connector with the token:\n\n")
+	fmt.Fprintf(&b, "```bash\ncloudflared tunnel run --token \"$(cat %s)\"\n```\n\n", handoffTokenFile(name))
+	if manifest.RemoteConfig {
+		b.WriteString("The tunnel's ingress is managed in Cloudflare, so the connector picks up the rules above and any later edits.\n\n")
+	}
+	fmt.Fprintf(&b, "`%s` mirrors the ingress at the time of the bundle for running the tunnel from a config file instead. Run it from this directory, or from `~/.cloudflared` once `tunnelman handoff open` has installed it there with the credentials:\n\n", handoffConfigFile(name))
+	fmt.Fprintf(&b, "```bash\ncloudflared tunnel --config %s run\n```\n", handoffConfigFile(name))
+	if manifest.RemoteConfig {
+		b.WriteString("\nRules managed in Cloudflare take precedence over the file's ingress once the connector is up.\n")
+	}
+	return b.String()
+}
+
+// OpenHandoffBundle decrypts a bundle made by CreateHandoffBundle with the
+// first matching identity and writes its files to dir, pointing the config
+// at the credentials written next to it. Existing files are left alone and
+// reported as skipped unless overwrite is set.
+func OpenHandoffBundle(encrypted []byte, identities []AgeIdentity, dir string, overwrite bool) (manifest *HandoffManifest, written, skipped []string, err error) {
+	archive, err := AgeDecrypt(encrypted, identities...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	files, err := readArchive(archive)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	manifest = &HandoffManifest{}
+	if err := json.Unmarshal(files[handoffManifest], manifest); err != nil {
+		return nil, nil, nil, fmt.Errorf("bundle has no valid manifest: %w", err)
+	}
+
+	if configData, ok := files[handoffConfigFile(manifest.TunnelName)]; ok {
+		var config TunnelConfigFile
+		if err := yaml.Unmarshal(configData, &config); err != nil {
+			return manifest, nil, nil, fmt.Errorf("bundle has no valid config: %w", err)
+		}
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return manifest, nil, nil, fmt.Errorf("failed to resolve %s: %w", dir, err)
+		}
+		config.CredentialsFile = filepath.Join(absDir, manifest.TunnelID+".json")
+		if files[handoffConfigFile(manifest.TunnelName)], err = yaml.Marshal(&config); err != nil {
+			return manifest, nil, nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return manifest, nil, nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	for _, name := range manifest.Files {
+		if name != filepath.Base(name) || name == ".." {
+			return manifest, written, skipped, fmt.Errorf("bundle entry %s escapes %s", name, dir)
+		}
+		target := filepath.Join(dir, name)
+		if _, err := os.Stat(target); err == nil && !overwrite {
+			skipped = append(skipped, target)
+			continue
+		}
+		if err := os.WriteFile(target, files[name], 0600); err != nil {
+			return manifest, written, skipped, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		written = append(written, target)
+	}
+	return manifest, written, skipped, nil
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+)
+
+// DefaultHistorySize is the number of status samples kept per tunnel
+const DefaultHistorySize = 24
+
+// StatusHistory is a fixed-size ring buffer of tunnel status samples, oldest first
+type StatusHistory struct {
+	samples []TunnelStatus
+	next    int
+	full    bool
+}
+
+func NewStatusHistory(size int) *StatusHistory {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	return &StatusHistory{samples: make([]TunnelStatus, size)}
+}
+
+// Record adds a sample, overwriting the oldest one once the buffer is full
+func (h *StatusHistory) Record(status TunnelStatus) {
+	h.samples[h.next] = status
+	h.next = (h.next + 1) % len(h.samples)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Samples returns the recorded samples in chronological order
+func (h *StatusHistory) Samples() []TunnelStatus {
+	if !h.full {
+		return append([]TunnelStatus(nil), h.samples[:h.next]...)
+	}
+	return append(append([]TunnelStatus(nil), h.samples[h.next:]...), h.samples[:h.next]...)
+}
+
+// Sparkline renders the history as block characters: healthy samples are tall,
+// degraded and error samples are progressively shorter and down samples are flat
+func (h *StatusHistory) Sparkline() string {
+	var b strings.Builder
+	for _, status := range h.Samples() {
+		switch status {
+		case StatusActive:
+			b.WriteRune('▇')
+		case StatusDegraded:
+			b.WriteRune('▅')
+		case StatusError:
+			b.WriteRune('▃')
+		case StatusInactive:
+			b.WriteRune('▁')
+		default:
+			b.WriteRune('·')
+		}
+	}
+	return b.String()
+}
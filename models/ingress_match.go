@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MatchIngress returns the index of the ingress rule cloudflared would use for
+// rawURL, or -1 if none matches. Like cloudflared, rules are tried in order:
+// the hostname must match exactly or through a leading "*." wildcard, and the
+// path is a regular expression searched for in the request path.
+func MatchIngress(rules []TunnelConfigIngress, rawURL string) (int, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return -1, fmt.Errorf("invalid URL: %w", err)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return -1, fmt.Errorf("URL has no hostname")
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	for i, rule := range rules {
+		if !ingressHostMatches(strings.ToLower(rule.Hostname), host) {
+			continue
+		}
+		if rule.Path != "" {
+			re, err := regexp.Compile(rule.Path)
+			if err != nil {
+				return -1, fmt.Errorf("rule %d has an invalid path %q: %w", i+1, rule.Path, err)
+			}
+			if !re.MatchString(path) {
+				continue
+			}
+		}
+		return i, nil
+	}
+
+	return -1, nil
+}
+
+func ingressHostMatches(pattern, host string) bool {
+	switch {
+	case pattern == "" || pattern == "*":
+		return true
+	case strings.HasPrefix(pattern, "*."):
+		return strings.HasSuffix(host, pattern[1:])
+	default:
+		return pattern == host
+	}
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+// IngressOrderProblem describes a remote ingress list that won't route as
+// it reads: without a catch-all cloudflared rejects the config, and rules
+// after the first catch-all never match
+type IngressOrderProblem struct {
+	MissingCatchAll bool
+	// Unreachable are the rules after the first catch-all
+	Unreachable []TunnelConfigIngress
+}
+
+// Any reports whether there is anything to fix
+func (p IngressOrderProblem) Any() bool {
+	return p.MissingCatchAll || len(p.Unreachable) > 0
+}
+
+// CheckIngressOrder looks for a missing catch-all and rules shadowed by one
+func CheckIngressOrder(rules []TunnelConfigIngress) IngressOrderProblem {
+	for i, rule := range rules {
+		if rule.Hostname == "" {
+			return IngressOrderProblem{Unreachable: append([]TunnelConfigIngress(nil), rules[i+1:]...)}
+		}
+	}
+	return IngressOrderProblem{MissingCatchAll: true}
+}
+
+// FixIngressOrder moves hostname rules shadowed by the catch-all in front of
+// it, keeping their order, and drops extra catch-alls. A list without a
+// catch-all gets one answering DefaultCatchAllService.
+func FixIngressOrder(rules []TunnelConfigIngress) []TunnelConfigIngress {
+	var fixed []TunnelConfigIngress
+	var catchAll *TunnelConfigIngress
+	for i := range rules {
+		if rules[i].Hostname != "" {
+			fixed = append(fixed, rules[i])
+		} else if catchAll == nil {
+			catchAll = &rules[i]
+		}
+	}
+	if catchAll == nil {
+		catchAll = &TunnelConfigIngress{Service: DefaultCatchAllService}
+	}
+	return append(fixed, *catchAll)
+}
+
+// GetIngressOrderProblem checks the order of the tunnel's remote ingress
+// rules
+func (c *CloudflareClient) GetIngressOrderProblem(ctx context.Context, tunnelID string) (IngressOrderProblem, error) {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return IngressOrderProblem{}, err
+	}
+	return CheckIngressOrder(config.Config.Ingress), nil
+}
+
+// FixTunnelIngressOrder applies FixIngressOrder to the tunnel's remote
+// ingress rules and returns the problem it fixed
+func (c *CloudflareClient) FixTunnelIngressOrder(ctx context.Context, tunnelID string) (IngressOrderProblem, error) {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return IngressOrderProblem{}, err
+	}
+
+	problem := CheckIngressOrder(config.Config.Ingress)
+	if !problem.Any() {
+		return problem, nil
+	}
+	config.Config.Ingress = FixIngressOrder(config.Config.Ingress)
+	if err := c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config); err != nil {
+		return IngressOrderProblem{}, fmt.Errorf("failed to reorder ingress rules: %w", err)
+	}
+	return problem, nil
+}
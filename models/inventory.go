@@ -0,0 +1,132 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// TunnelHostname is a public hostname together with the tunnel serving it
+type TunnelHostname struct {
+	PublicHostname
+	TunnelID   string `json:"tunnel_id"`
+	TunnelName string `json:"tunnel_name"`
+}
+
+// ListAllPublicHostnames collects the public hostnames of every given tunnel.
+// Tunnels whose configuration can't be read are skipped and reported in the
+// returned map of tunnel ID to error.
+func (c *CloudflareClient) ListAllPublicHostnames(ctx context.Context, tunnels []CLITunnel) ([]TunnelHostname, map[string]error) {
+	var all []TunnelHostname
+	failures := make(map[string]error)
+
+	for _, tunnel := range tunnels {
+		hostnames, err := c.GetPublicHostnames(ctx, tunnel.ID)
+		if err != nil {
+			failures[tunnel.ID] = err
+			continue
+		}
+
+		for _, hostname := range hostnames {
+			all = append(all, TunnelHostname{
+				PublicHostname: hostname,
+				TunnelID:       tunnel.ID,
+				TunnelName:     tunnel.Name,
+			})
+		}
+	}
+
+	return all, failures
+}
+
+// FilterHostnames returns the hostnames whose name, service or tunnel name
+// contains query (case-insensitive)
+func FilterHostnames(hostnames []TunnelHostname, query string) []TunnelHostname {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return hostnames
+	}
+
+	var matches []TunnelHostname
+	for _, hostname := range hostnames {
+		if strings.Contains(strings.ToLower(hostname.Hostname), query) ||
+			strings.Contains(strings.ToLower(hostname.Service), query) ||
+			strings.Contains(strings.ToLower(hostname.TunnelName), query) {
+			matches = append(matches, hostname)
+		}
+	}
+	return matches
+}
+
+// DNSStatus describes the DNS record behind a tunnel's public hostname
+type DNSStatus string
+
+const (
+	DNSStatusOK          DNSStatus = "ok"
+	DNSStatusMissing     DNSStatus = "missing"
+	DNSStatusOtherTunnel DNSStatus = "other tunnel"
+	DNSStatusConflict    DNSStatus = "conflict"
+	DNSStatusNoZone      DNSStatus = "no zone"
+)
+
+// HostnameDNSStatuses checks the DNS record of each hostname against the
+// tunnel serving it. Each zone's records are listed once, so this costs one
+// request per zone rather than per hostname. The map is keyed by lowercased
+// hostname.
+func (c *CloudflareClient) HostnameDNSStatuses(ctx context.Context, hostnames []TunnelHostname) (map[string]DNSStatus, error) {
+	zones, err := c.api.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	byZone := make(map[string][]TunnelHostname)
+	statuses := make(map[string]DNSStatus)
+	for _, hostname := range hostnames {
+		zoneID, _ := matchZone(zones, hostname.Hostname)
+		if zoneID == "" {
+			statuses[strings.ToLower(hostname.Hostname)] = DNSStatusNoZone
+			continue
+		}
+		byZone[zoneID] = append(byZone[zoneID], hostname)
+	}
+
+	for zoneID, zoneHostnames := range byZone {
+		records, _, err := c.api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list DNS records: %w", err)
+		}
+
+		byName := make(map[string][]cloudflare.DNSRecord)
+		for _, record := range records {
+			name := strings.ToLower(record.Name)
+			byName[name] = append(byName[name], record)
+		}
+
+		for _, hostname := range zoneHostnames {
+			name := strings.ToLower(hostname.Hostname)
+			statuses[name] = dnsStatusFor(byName[name], hostname.TunnelID)
+		}
+	}
+
+	return statuses, nil
+}
+
+func dnsStatusFor(records []cloudflare.DNSRecord, tunnelID string) DNSStatus {
+	if len(records) == 0 {
+		return DNSStatusMissing
+	}
+	for _, record := range records {
+		if record.Type != "CNAME" {
+			return DNSStatusConflict
+		}
+		if targetID := tunnelIDFromCNAME(record.Content); targetID != tunnelID {
+			if targetID == "" {
+				return DNSStatusConflict
+			}
+			return DNSStatusOtherTunnel
+		}
+	}
+	return DNSStatusOK
+}
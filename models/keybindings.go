@@ -0,0 +1,136 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Actions whose keys can be rebound in the keybindings config section
+const (
+	ActionQuit    = "quit"
+	ActionRefresh = "refresh"
+	ActionDelete  = "delete"
+	ActionAdd     = "add"
+	ActionEdit    = "edit"
+	ActionOpen    = "open"
+)
+
+// DefaultKeyBindings maps each rebindable action to its built-in key
+var DefaultKeyBindings = map[string]string{
+	ActionQuit:    "q",
+	ActionRefresh: "r",
+	ActionDelete:  "d",
+	ActionAdd:     "a",
+	ActionEdit:    "e",
+	ActionOpen:    "o",
+}
+
+// KeyMap resolves the configured keybindings against the defaults
+type KeyMap struct {
+	keys      map[string]string // action → key
+	overrides map[string]string // configured key → default key
+	disabled  map[string]bool   // default keys that were rebound
+}
+
+// KeyMap builds the key map from the keybindings section. Keys use Bubble
+// Tea's names, such as "x", "ctrl+d" or "delete". reserved maps the views'
+// other built-in keys to what they do; rebound keys are translated on every
+// screen, so taking one of them would shadow it. Unknown actions, reserved
+// keys and keys bound twice are errors; the defaults are returned alongside
+// them.
+func (c *Config) KeyMap(reserved map[string]string) (*KeyMap, error) {
+	keyMap := &KeyMap{
+		keys:      make(map[string]string, len(DefaultKeyBindings)),
+		overrides: make(map[string]string),
+		disabled:  make(map[string]bool),
+	}
+	for action, key := range DefaultKeyBindings {
+		keyMap.keys[action] = key
+	}
+	if c == nil || len(c.Keybindings) == 0 {
+		return keyMap, nil
+	}
+
+	actions := make([]string, 0, len(c.Keybindings))
+	for action := range c.Keybindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		key := strings.TrimSpace(c.Keybindings[action])
+		if _, known := DefaultKeyBindings[action]; !known {
+			return defaultKeyMap(), fmt.Errorf("unknown keybinding action %q (expected one of: quit, refresh, delete, add, edit, open)", action)
+		}
+		if key == "" {
+			return defaultKeyMap(), fmt.Errorf("keybinding for %s is empty", action)
+		}
+		if does, taken := reserved[key]; taken {
+			return defaultKeyMap(), fmt.Errorf("key %q for %s is already built in: %s", key, action, does)
+		}
+		keyMap.keys[action] = key
+	}
+
+	// A rebound key may also collide with an action left at its default
+	all := make([]string, 0, len(keyMap.keys))
+	for action := range keyMap.keys {
+		all = append(all, action)
+	}
+	sort.Strings(all)
+	boundTo := make(map[string]string)
+	for _, action := range all {
+		key := keyMap.keys[action]
+		if other, taken := boundTo[key]; taken {
+			return defaultKeyMap(), fmt.Errorf("key %q is bound to both %s and %s", key, other, action)
+		}
+		boundTo[key] = action
+	}
+
+	for action, key := range keyMap.keys {
+		if defaultKey := DefaultKeyBindings[action]; key != defaultKey {
+			keyMap.overrides[key] = defaultKey
+			keyMap.disabled[defaultKey] = true
+		}
+	}
+	return keyMap, nil
+}
+
+func defaultKeyMap() *KeyMap {
+	keyMap, _ := (*Config)(nil).KeyMap(nil)
+	return keyMap
+}
+
+// Key returns the key bound to action
+func (k *KeyMap) Key(action string) string {
+	if k == nil {
+		return DefaultKeyBindings[action]
+	}
+	return k.keys[action]
+}
+
+// Translate maps a pressed key onto the built-in key the views handle. A
+// rebound action's own default key translates to "" so it no longer fires.
+func (k *KeyMap) Translate(key string) string {
+	if k == nil {
+		return key
+	}
+	if defaultKey, ok := k.overrides[key]; ok {
+		return defaultKey
+	}
+	if k.disabled[key] {
+		return ""
+	}
+	return key
+}
+
+// Bound returns the key that triggers the built-in key defaultKey, which is
+// defaultKey itself unless its action was rebound
+func (k *KeyMap) Bound(defaultKey string) string {
+	for action, key := range DefaultKeyBindings {
+		if key == defaultKey {
+			return k.Key(action)
+		}
+	}
+	return defaultKey
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LocalConfig is a cloudflared YAML config found in the cloudflared directory
+type LocalConfig struct {
+	Path   string
+	Config *TunnelConfigFile
+}
+
+// Name is the config's file name without its extension
+func (l LocalConfig) Name() string {
+	return strings.TrimSuffix(filepath.Base(l.Path), filepath.Ext(l.Path))
+}
+
+// ScanLocalConfigs reads every *.yml and *.yaml file in the cloudflared
+// directory that names a tunnel. Files that don't parse are skipped.
+func (tm *TunnelManager) ScanLocalConfigs() ([]LocalConfig, error) {
+	var paths []string
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, err := filepath.Glob(filepath.Join(tm.configDir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", tm.configDir, err)
+		}
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+
+	var configs []LocalConfig
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var config TunnelConfigFile
+		if err := yaml.Unmarshal(data, &config); err != nil || config.TunnelID == "" {
+			continue
+		}
+		configs = append(configs, LocalConfig{Path: path, Config: &config})
+	}
+
+	return configs, nil
+}
+
+// MatchLocalConfigs maps tunnel IDs to the local config that runs them. A
+// config's tunnel field may hold either the tunnel's ID or its name; when
+// several files name the same tunnel, <tunnel name>.yml wins.
+func MatchLocalConfigs(configs []LocalConfig, tunnels []CLITunnel) map[string]LocalConfig {
+	matched := make(map[string]LocalConfig)
+	for _, tunnel := range tunnels {
+		for _, local := range configs {
+			if local.Config.TunnelID != tunnel.ID && local.Config.TunnelID != tunnel.Name {
+				continue
+			}
+			if _, exists := matched[tunnel.ID]; !exists || local.Name() == tunnel.Name {
+				matched[tunnel.ID] = local
+			}
+		}
+	}
+	return matched
+}
+
+// StartLocalConfig runs the tunnel with an existing config file as is, unlike
+// StartTunnel which writes the config it is given first. The file's loglevel
+// is only overridden by a log level set for this tunnel specifically.
+func (tm *TunnelManager) StartLocalConfig(ctx context.Context, tunnelName string, local LocalConfig) (*TunnelProcess, error) {
+	if err := tm.ValidateTunnelConfig(local.Config); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", local.Path, err)
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	if process, exists := tm.processes[tunnelName]; exists && process.IsRunning() {
+		return nil, fmt.Errorf("tunnel %s is already running with PID %d", tunnelName, process.PID)
+	}
+	if err := tm.checkNotServiceManaged(tunnelName, local.Config.TunnelID); err != nil {
+		return nil, err
+	}
+
+	args := []string{"tunnel", "--config", local.Path}
+	if logLevel, override := tm.logLevelFor(tunnelName); override {
+		args = append(args, logLevelArgs(logLevel)...)
+	}
+	args = append(args, "run", tunnelName)
+	process, err := tm.supervisorFor(tunnelName).Start(ctx, tunnelName, args)
+	if err != nil {
+		return nil, err
+	}
+	process.Config = local.Config
+
+	tm.processes[tunnelName] = process
+
+	return process, nil
+}
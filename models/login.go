@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// ErrNotLoggedIn is returned when a cloudflared command fails because there
+// is no origin certificate (cert.pem), i.e. `cloudflared tunnel login` was
+// never run on this machine
+var ErrNotLoggedIn = errors.New(`cloudflared is not logged in (no cert.pem) - run 'cloudflared tunnel login', or set "api_only": true in config.json to manage tunnels through the API`)
+
+// notLoggedInMarkers are fragments of cloudflared's output when cert.pem is missing
+var notLoggedInMarkers = []string{
+	"cannot determine default origin certificate path",
+	"origin certificate path",
+	"cert.pem",
+	"cloudflared tunnel login",
+}
+
+func isNotLoggedInOutput(output string) bool {
+	output = strings.ToLower(output)
+	for _, marker := range notLoggedInMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotLoggedInError reports whether err comes from cloudflared missing cert.pem
+func IsNotLoggedInError(err error) bool {
+	return errors.Is(err, ErrNotLoggedIn)
+}
+
+// CloudflaredLoginCommand returns the command that authorizes cloudflared for
+// a zone in the browser and writes cert.pem
+func CloudflaredLoginCommand() *exec.Cmd {
+	return exec.Command("cloudflared", "tunnel", "login")
+}
+
+// listTunnelsAPI lists every live tunnel through the API, for api_only mode
+func (c *CloudflareClient) listTunnelsAPI(ctx context.Context) ([]CLITunnel, error) {
+	var all []CLITunnel
+	for page := 1; ; page++ {
+		tunnels, more, err := c.ListTunnelsPage(ctx, TunnelListOptions{Page: page, PerPage: DefaultTunnelPageSize})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tunnels...)
+		if !more {
+			return all, nil
+		}
+	}
+}
+
+// findTunnelAPI looks a tunnel up by ID or name through the API
+func (c *CloudflareClient) findTunnelAPI(ctx context.Context, nameOrID string) (*CLITunnel, error) {
+	tunnels, err := c.listTunnelsAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tunnel := range tunnels {
+		if tunnel.ID == nameOrID || tunnel.Name == nameOrID {
+			return &tunnel, nil
+		}
+	}
+	return nil, fmt.Errorf("tunnel %s: %w", nameOrID, ErrNotFound)
+}
+
+// deleteTunnelAPI deletes a tunnel through the API, for api_only mode
+func (c *CloudflareClient) deleteTunnelAPI(ctx context.Context, nameOrID string) error {
+	tunnel, err := c.findTunnelAPI(ctx, nameOrID)
+	if err != nil {
+		return err
+	}
+	if err := c.api.DeleteTunnel(ctx, cloudflare.AccountIdentifier(c.accountID), tunnel.ID); err != nil {
+		return fmt.Errorf("failed to delete tunnel: %w", err)
+	}
+	return nil
+}
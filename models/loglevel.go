@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cloudflaredLogLevels are the values cloudflared accepts for --loglevel
+var cloudflaredLogLevels = []string{"debug", "info", "warn", "error", "fatal"}
+
+func validLogLevel(level string) bool {
+	for _, valid := range cloudflaredLogLevels {
+		if level == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigureLogLevels sets the log level passed to cloudflared for every
+// tunnel, with per-tunnel overrides keyed by tunnel name. An empty default
+// leaves cloudflared's own default in place.
+func (tm *TunnelManager) ConfigureLogLevels(defaultLevel string, overrides map[string]string) error {
+	defaultLevel = strings.ToLower(defaultLevel)
+	if defaultLevel != "" && !validLogLevel(defaultLevel) {
+		return fmt.Errorf("invalid log level %q (want one of %s)", defaultLevel, strings.Join(cloudflaredLogLevels, ", "))
+	}
+
+	levels := make(map[string]string, len(overrides))
+	for tunnelName, level := range overrides {
+		level = strings.ToLower(level)
+		if !validLogLevel(level) {
+			return fmt.Errorf("invalid log level %q for tunnel %s (want one of %s)", level, tunnelName, strings.Join(cloudflaredLogLevels, ", "))
+		}
+		levels[tunnelName] = level
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm.logLevel = defaultLevel
+	tm.logLevels = levels
+	return nil
+}
+
+// logLevelFor returns the tunnel's log level and whether it was set for that
+// tunnel specifically; callers must hold tm.mutex
+func (tm *TunnelManager) logLevelFor(tunnelName string) (string, bool) {
+	if level, ok := tm.logLevels[tunnelName]; ok {
+		return level, true
+	}
+	return tm.logLevel, false
+}
+
+// logLevelArgs returns the --loglevel flag for a tunnel's cloudflared
+// command line, or nothing when no level is configured
+func logLevelArgs(level string) []string {
+	if level == "" {
+		return nil
+	}
+	return []string{"--loglevel", level}
+}
@@ -0,0 +1,35 @@
+package models
+
+// DefaultMaintenanceService is served in place of a hostname's origin while
+// it is in maintenance mode
+const DefaultMaintenanceService = "http_status:503"
+
+// MaintenanceService returns the configured maintenance service, falling back
+// to a bare 503 response
+func (c *Config) MaintenanceService() string {
+	if c == nil || c.MaintenanceServiceURL == "" {
+		return DefaultMaintenanceService
+	}
+	return c.MaintenanceServiceURL
+}
+
+// SetMaintenance records a hostname as being in maintenance, remembering the
+// service it should be restored to
+func (s *AppState) SetMaintenance(hostname, originalService string) {
+	if s.Maintenance == nil {
+		s.Maintenance = make(map[string]string)
+	}
+	s.Maintenance[hostname] = originalService
+}
+
+// ClearMaintenance removes a hostname from maintenance mode
+func (s *AppState) ClearMaintenance(hostname string) {
+	delete(s.Maintenance, hostname)
+}
+
+// MaintenanceOriginal returns the service to restore for a hostname in
+// maintenance mode, and whether the hostname is in maintenance at all
+func (s *AppState) MaintenanceOriginal(hostname string) (string, bool) {
+	original, exists := s.Maintenance[hostname]
+	return original, exists
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Remote tunnel states reported by the Cloudflare API
+const (
+	RemoteStatusHealthy  = "healthy"
+	RemoteStatusDegraded = "degraded"
+	RemoteStatusDown     = "down"
+	RemoteStatusInactive = "inactive"
+)
+
+// TunnelMetadata is the tunnel record kept by Cloudflare, which knows about
+// soft deletion and degraded connectors that `cloudflared tunnel list` hides
+type TunnelMetadata struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Status          string                 `json:"status"`
+	TunType         string                 `json:"tun_type"`
+	RemoteConfig    bool                   `json:"remote_config"`
+	ConfigSrc       string                 `json:"config_src"`
+	CreatedAt       time.Time              `json:"created_at"`
+	DeletedAt       *time.Time             `json:"deleted_at"`
+	ConnsActiveAt   *time.Time             `json:"conns_active_at"`
+	ConnsInactiveAt *time.Time             `json:"conns_inactive_at"`
+	Metadata        map[string]interface{} `json:"metadata"`
+}
+
+// IsDeleted reports whether the tunnel has been soft-deleted
+func (md TunnelMetadata) IsDeleted() bool {
+	return md.DeletedAt != nil && !md.DeletedAt.IsZero()
+}
+
+// CreatedBy returns the creator recorded in the tunnel's metadata, if any
+func (md TunnelMetadata) CreatedBy() string {
+	for _, key := range []string{"created_by", "createdBy", "owner"} {
+		if value, ok := md.Metadata[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Describe summarizes the remote record on one line
+func (md TunnelMetadata) Describe() string {
+	parts := []string{"remote status: " + md.Status}
+	if md.IsDeleted() {
+		parts = append(parts, "deleted "+md.DeletedAt.Format("2006-01-02 15:04"))
+	}
+	if !md.CreatedAt.IsZero() {
+		created := "created " + md.CreatedAt.Format("2006-01-02")
+		if by := md.CreatedBy(); by != "" {
+			created += " by " + by
+		}
+		parts = append(parts, created)
+	}
+	if md.ConfigSrc != "" {
+		parts = append(parts, "config: "+md.ConfigSrc)
+	}
+
+	var extra []string
+	for key, value := range md.Metadata {
+		extra = append(extra, fmt.Sprintf("%s=%v", key, value))
+	}
+	sort.Strings(extra)
+	parts = append(parts, extra...)
+
+	return strings.Join(parts, " • ")
+}
+
+// ApplyRemoteStatus downgrades a locally derived status when Cloudflare
+// reports the tunnel as deleted, degraded or without connectors
+func ApplyRemoteStatus(status TunnelStatus, md TunnelMetadata) TunnelStatus {
+	if md.IsDeleted() || md.Status == RemoteStatusDown || md.Status == RemoteStatusInactive {
+		return StatusInactive
+	}
+	if md.Status == RemoteStatusDegraded && status == StatusActive {
+		return StatusDegraded
+	}
+	return status
+}
+
+// ListTunnelMetadata returns the remote record of every tunnel in the
+// account, including soft-deleted ones, keyed by tunnel ID
+func (c *CloudflareClient) ListTunnelMetadata(ctx context.Context) (map[string]TunnelMetadata, error) {
+	if c.accountID == "" {
+		return nil, fmt.Errorf("account ID not available")
+	}
+
+	const perPage = 100
+	result := make(map[string]TunnelMetadata)
+	for page := 1; ; page++ {
+		var tunnels []TunnelMetadata
+		path := fmt.Sprintf("/accounts/%s/cfd_tunnel?per_page=%d&page=%d", c.accountID, perPage, page)
+		if err := c.apiRequest(ctx, "GET", path, nil, &tunnels); err != nil {
+			return nil, fmt.Errorf("failed to list tunnel metadata: %w", err)
+		}
+
+		for _, tunnel := range tunnels {
+			result[tunnel.ID] = tunnel
+		}
+		if len(tunnels) < perPage {
+			return result, nil
+		}
+	}
+}
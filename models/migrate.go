@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// migration upgrades a decoded JSON document by one schema version
+type migration func(doc map[string]interface{}) error
+
+// Each slice holds the migrations from version i to i+1, so the current
+// schema version of a file type is the length of its slice. Append new
+// migrations here whenever a stored field is renamed or reshaped.
+var (
+	configMigrations = []migration{
+		// v0 -> v1: schema_version introduced, no structural changes
+		func(doc map[string]interface{}) error { return nil },
+	}
+	stateMigrations = []migration{
+		// v0 -> v1: schema_version introduced, no structural changes
+		func(doc map[string]interface{}) error { return nil },
+	}
+)
+
+var (
+	ConfigSchemaVersion = len(configMigrations)
+	StateSchemaVersion  = len(stateMigrations)
+)
+
+// migrateFile brings the JSON document read from path up to the latest
+// schema version. If any migration runs, the original file is copied to
+// <path>.v<old>.backup and the migrated document is written back to path.
+// When encode is set, both are passed through it before being written.
+func migrateFile(path string, data []byte, migrations []migration, perm os.FileMode, encode func([]byte) ([]byte, error)) ([]byte, error) {
+	write := func(target string, data []byte) error {
+		if encode != nil {
+			encoded, err := encode(data)
+			if err != nil {
+				return err
+			}
+			data = encoded
+		}
+		return os.WriteFile(target, data, perm)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	version := 0
+	if v, ok := doc["schema_version"].(float64); ok {
+		version = int(v)
+	}
+
+	if version > len(migrations) {
+		return nil, fmt.Errorf("%s has schema version %d, newer than supported version %d - please upgrade tunnelman", path, version, len(migrations))
+	}
+	if version == len(migrations) {
+		return data, nil
+	}
+
+	backupPath := fmt.Sprintf("%s.v%d.backup", path, version)
+	if err := write(backupPath, data); err != nil {
+		return nil, fmt.Errorf("failed to back up %s before migration: %w", path, err)
+	}
+
+	for v := version; v < len(migrations); v++ {
+		if err := migrations[v](doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate %s from version %d to %d: %w", path, v, v+1, err)
+		}
+	}
+	doc["schema_version"] = len(migrations)
+
+	migrated, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal migrated %s: %w", path, err)
+	}
+
+	if err := write(path, migrated); err != nil {
+		return nil, fmt.Errorf("failed to write migrated %s: %w", path, err)
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConfigureNewTunnel gives a freshly created tunnel its first public
+// hostname. New tunnels have no remote configuration yet, so the ingress is
+// written from scratch with a catch-all rule after the hostname, then the
+// hostname's DNS record is pointed at the tunnel.
+func (c *CloudflareClient) ConfigureNewTunnel(ctx context.Context, tunnel *CLITunnel, hostname, service string) error {
+	config := &TunnelConfigData{
+		Ingress: []TunnelConfigIngress{
+			{ID: "1", Hostname: hostname, Service: service, OriginRequest: map[string]interface{}{}},
+			{Service: DefaultCatchAllService},
+		},
+	}
+	if err := c.UpdateTunnelConfiguration(ctx, tunnel.ID, config); err != nil {
+		return fmt.Errorf("failed to configure tunnel %s: %w", tunnel.Name, err)
+	}
+
+	if err := c.CreateTunnelDNSRecord(ctx, tunnel.Name, hostname, false); err != nil {
+		return fmt.Errorf("failed to create DNS record for %s: %w", hostname, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// DefaultCatchAllService is cloudflared's bare response for unmatched requests
+	DefaultCatchAllService = "http_status:404"
+	// DefaultNotFoundPort is where the catch-all page is served, fixed so the
+	// catch-all rule keeps working across tunnelman restarts
+	DefaultNotFoundPort = 8404
+	// NotFoundServerKey registers the catch-all page server with the TunnelManager
+	NotFoundServerKey = "notfound"
+)
+
+const notFoundPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Nothing here</title>
+<style>
+  body { margin: 0; min-height: 100vh; display: flex; align-items: center; justify-content: center;
+         font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; background: #111827; color: #E5E7EB; }
+  main { text-align: center; padding: 2rem; }
+  h1 { font-size: 4rem; margin: 0; color: #7C3AED; }
+  p { color: #9CA3AF; }
+  code { color: #F3F4F6; }
+</style>
+</head>
+<body>
+<main>
+  <h1>404</h1>
+  <p>Nothing is being served at <code>%s</code> right now.</p>
+  <p>Routed by tunnelman</p>
+</main>
+</body>
+</html>
+`
+
+// NotFoundPort returns the configured port for the catch-all page
+func (c *Config) NotFoundPort() int {
+	if c == nil || c.NotFoundPagePort <= 0 {
+		return DefaultNotFoundPort
+	}
+	return c.NotFoundPagePort
+}
+
+// NotFoundService returns the service URL of the catch-all page
+func (c *Config) NotFoundService() string {
+	return "http://localhost:" + strconv.Itoa(c.NotFoundPort())
+}
+
+// NotFoundHandler answers every request with the branded 404 page
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, notFoundPage, html.EscapeString(r.Host+r.URL.Path))
+	})
+}
+
+// StartNotFoundServer serves the catch-all page on port
+func (tm *TunnelManager) StartNotFoundServer(port int) (*StaticServer, error) {
+	return tm.StartStaticServer(NotFoundServerKey, "", port, NotFoundHandler())
+}
+
+// GetCatchAllService returns the service of the tunnel's catch-all rule, or
+// an empty string if the tunnel has none
+func (c *CloudflareClient) GetCatchAllService(ctx context.Context, tunnelID string) (string, error) {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, ingress := range config.Config.Ingress {
+		if ingress.Hostname == "" {
+			return ingress.Service, nil
+		}
+	}
+	return "", nil
+}
+
+// SetCatchAllService points the tunnel's catch-all rule at service, adding
+// the rule if the tunnel doesn't have one yet
+func (c *CloudflareClient) SetCatchAllService(ctx context.Context, tunnelID, service string) error {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i := range config.Config.Ingress {
+		if config.Config.Ingress[i].Hostname == "" {
+			config.Config.Ingress[i].Service = service
+			found = true
+			break
+		}
+	}
+	if !found {
+		config.Config.Ingress = append(config.Config.Ingress, TunnelConfigIngress{Service: service})
+	}
+
+	return c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config)
+}
+
+// SetCustomNotFound records whether a tunnel's catch-all uses the tunnelman page
+func (s *AppState) SetCustomNotFound(tunnelID string, enabled bool) {
+	for i, id := range s.CustomNotFound {
+		if id == tunnelID {
+			if !enabled {
+				s.CustomNotFound = append(s.CustomNotFound[:i], s.CustomNotFound[i+1:]...)
+			}
+			return
+		}
+	}
+	if enabled {
+		s.CustomNotFound = append(s.CustomNotFound, tunnelID)
+	}
+}
+
+// HasCustomNotFound reports whether a tunnel's catch-all uses the tunnelman page
+func (s *AppState) HasCustomNotFound(tunnelID string) bool {
+	for _, id := range s.CustomNotFound {
+		if id == tunnelID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PathRule routes requests for one path of a hostname to a service
+type PathRule struct {
+	Path    string
+	Service string
+}
+
+// IsCatchAll reports whether the rule matches every path of the hostname
+func (r PathRule) IsCatchAll() bool {
+	switch r.Path {
+	case "", "*", "/", "/*", ".*", "/.*", "^/":
+		return true
+	}
+	return false
+}
+
+func (r PathRule) String() string {
+	path := r.Path
+	if r.IsCatchAll() {
+		path = "*"
+	}
+	return fmt.Sprintf("%s → %s", path, r.Service)
+}
+
+// ParsePathRules parses a comma separated list of path=service pairs such as
+// "/api=http://localhost:8080, /app=http://localhost:3000, *=http://localhost:80"
+func ParsePathRules(spec string) ([]PathRule, error) {
+	var rules []PathRule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		path, service, found := strings.Cut(part, "=")
+		path, service = strings.TrimSpace(path), strings.TrimSpace(service)
+		if !found || path == "" || service == "" {
+			return nil, fmt.Errorf("invalid rule %q - use path=service", part)
+		}
+		rules = append(rules, PathRule{Path: path, Service: service})
+	}
+
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no path rules given")
+	}
+	return rules, nil
+}
+
+// FormatPathRules is the inverse of ParsePathRules
+func FormatPathRules(rules []PathRule) string {
+	parts := make([]string, len(rules))
+	for i, rule := range rules {
+		path := rule.Path
+		if rule.IsCatchAll() {
+			path = "*"
+		}
+		parts[i] = path + "=" + rule.Service
+	}
+	return strings.Join(parts, ", ")
+}
+
+// OrderPathRules validates rules and orders them so cloudflared, which uses
+// the first matching rule, reaches every one of them: more specific paths come
+// first and the catch-all, if any, comes last.
+func OrderPathRules(rules []PathRule) ([]PathRule, error) {
+	ordered := make([]PathRule, 0, len(rules))
+	seen := make(map[string]bool)
+	var catchAll *PathRule
+
+	for i := range rules {
+		rule := rules[i]
+		if rule.IsCatchAll() {
+			if catchAll != nil {
+				return nil, fmt.Errorf("only one catch-all path is allowed")
+			}
+			catchAll = &rule
+			continue
+		}
+
+		if seen[rule.Path] {
+			return nil, fmt.Errorf("path %s is listed more than once", rule.Path)
+		}
+		seen[rule.Path] = true
+
+		if _, err := regexp.Compile(rule.Path); err != nil {
+			return nil, fmt.Errorf("path %s is not a valid regular expression: %w", rule.Path, err)
+		}
+		ordered = append(ordered, rule)
+	}
+
+	// Longer literal prefixes are more specific; ties keep the given order
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return len(literalPrefix(ordered[i].Path)) > len(literalPrefix(ordered[j].Path))
+	})
+
+	// A rule whose own path is matched by an earlier rule can never be reached
+	for i, rule := range ordered {
+		for _, earlier := range ordered[:i] {
+			re := regexp.MustCompile(earlier.Path)
+			if prefix := literalPrefix(rule.Path); prefix != "" && re.MatchString(prefix) {
+				return nil, fmt.Errorf("path %s is shadowed by %s, which matches first", rule.Path, earlier.Path)
+			}
+		}
+	}
+
+	if catchAll != nil {
+		ordered = append(ordered, *catchAll)
+	}
+	return ordered, nil
+}
+
+var literalPrefixPattern = regexp.MustCompile(`^[^\\.+*?()|\[\]{}^$]*`)
+
+// literalPrefix returns the part of a path pattern before any regex syntax
+func literalPrefix(path string) string {
+	return literalPrefixPattern.FindString(strings.TrimPrefix(path, "^"))
+}
+
+// GetPathRules returns the rules currently routing hostname, in match order
+func GetPathRules(hostnames []PublicHostname, hostname string) []PathRule {
+	var rules []PathRule
+	for _, h := range hostnames {
+		if h.Hostname == hostname {
+			rules = append(rules, PathRule{Path: h.Path, Service: h.Service})
+		}
+	}
+	return rules
+}
+
+// SetPathRules replaces every ingress rule for hostname with rules, which must
+// already be ordered by OrderPathRules. The new rules take the place of the
+// first existing rule for the hostname, or go before the catch-all for a new one.
+func (c *CloudflareClient) SetPathRules(ctx context.Context, tunnelID, hostname string, rules []PathRule) error {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	maxID := 0
+	insertAt := -1
+	var kept []TunnelConfigIngress
+	for _, ingress := range config.Config.Ingress {
+		if id, err := strconv.Atoi(ingress.ID); err == nil && id > maxID {
+			maxID = id
+		}
+		if ingress.Hostname == hostname {
+			if insertAt < 0 {
+				insertAt = len(kept)
+			}
+			continue
+		}
+		kept = append(kept, ingress)
+	}
+
+	isNew := insertAt < 0
+	if isNew {
+		insertAt = len(kept)
+		for i, ingress := range kept {
+			if ingress.Hostname == "" {
+				insertAt = i
+				break
+			}
+		}
+	}
+
+	newRules := make([]TunnelConfigIngress, len(rules))
+	for i, rule := range rules {
+		maxID++
+		newRules[i] = TunnelConfigIngress{
+			ID:            strconv.Itoa(maxID),
+			Hostname:      hostname,
+			Service:       rule.Service,
+			OriginRequest: map[string]interface{}{},
+		}
+		if !rule.IsCatchAll() {
+			newRules[i].Path = rule.Path
+		}
+	}
+
+	ingress := append([]TunnelConfigIngress{}, kept[:insertAt]...)
+	ingress = append(ingress, newRules...)
+	config.Config.Ingress = append(ingress, kept[insertAt:]...)
+
+	if err := c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config); err != nil {
+		return err
+	}
+
+	if isNew {
+		if err := c.CreateTunnelDNSRecords(ctx, tunnelID, []string{hostname}, false)[0].Err; err != nil {
+			c.warnf("set path rules", "failed to create DNS record for %s: %v", hostname, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Directory overrides set from command line flags, which take precedence
+// over config.json and the environment
+var (
+	configDirOverride      string
+	stateDirOverride       string
+	cloudflaredDirOverride string
+)
+
+// SetConfigDir overrides where config.json is read from and written to
+func SetConfigDir(dir string) {
+	configDirOverride = dir
+}
+
+// SetStateDir overrides where tunnelman keeps state such as the uptime log
+func SetStateDir(dir string) {
+	stateDirOverride = dir
+}
+
+// SetCloudflaredDir overrides the cloudflared directory holding tunnel
+// configs and credentials
+func SetCloudflaredDir(dir string) {
+	cloudflaredDirOverride = dir
+}
+
+// applyDirectories fills in directories set in config.json that weren't
+// already overridden by a flag
+func (c *Config) applyDirectories() {
+	if stateDirOverride == "" && c.StateDir != "" {
+		stateDirOverride = expandHome(c.StateDir)
+	}
+	if cloudflaredDirOverride == "" && c.CloudflaredDir != "" {
+		cloudflaredDirOverride = expandHome(c.CloudflaredDir)
+	}
+}
+
+// getConfigDir returns the tunnelman config directory: the override if set,
+// a ~/.tunnelman holding a config.json for backwards compatibility, then
+// $XDG_CONFIG_HOME/tunnelman, then ~/.tunnelman
+func getConfigDir() string {
+	if configDirOverride != "" {
+		return configDirOverride
+	}
+	if legacy, ok := legacyDir(); ok {
+		return legacy
+	}
+	if dir, ok := xdgDir("XDG_CONFIG_HOME"); ok {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultConfigDir
+	}
+	return filepath.Join(home, DefaultConfigDir)
+}
+
+// GetStateDir returns where runtime state is kept: the override if set,
+// a ~/.tunnelman holding a config.json, then $XDG_STATE_HOME/tunnelman,
+// then the config directory
+func GetStateDir() string {
+	if stateDirOverride != "" {
+		return stateDirOverride
+	}
+	if configDirOverride != "" {
+		return configDirOverride
+	}
+	if legacy, ok := legacyDir(); ok {
+		return legacy
+	}
+	if dir, ok := xdgDir("XDG_STATE_HOME"); ok {
+		return dir
+	}
+	return getConfigDir()
+}
+
+// legacyDir returns ~/.tunnelman when it is still in use, which is judged by
+// its config.json: state written there alone must not take over from an XDG
+// config directory
+func legacyDir() (string, bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false
+	}
+	legacy := filepath.Join(home, DefaultConfigDir)
+	if _, err := os.Stat(filepath.Join(legacy, DefaultConfigFile)); err != nil {
+		return "", false
+	}
+	return legacy, true
+}
+
+// xdgDir returns the tunnelman directory under the XDG base directory named
+// by env, if that is set to an absolute path
+func xdgDir(env string) (string, bool) {
+	if base := os.Getenv(env); base != "" && filepath.IsAbs(base) {
+		return filepath.Join(base, "tunnelman"), true
+	}
+	return "", false
+}
+
+// getDefaultConfigDir returns the cloudflared directory, ~/.cloudflared unless overridden
+func getDefaultConfigDir() string {
+	if cloudflaredDirOverride != "" {
+		return cloudflaredDirOverride
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".cloudflared"
+	}
+	return filepath.Join(home, ".cloudflared")
+}
+
+// GetCloudflaredDir returns the directory holding cloudflared tunnel configs and credentials
+func GetCloudflaredDir() string {
+	return getDefaultConfigDir()
+}
+
+func expandHome(path string) string {
+	if len(path) > 1 && path[:2] == "~/" {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Default polling intervals: the list is cheap to fetch, while statuses and
+// domain counts take a request per tunnel
+const (
+	DefaultListPollSeconds        = 5
+	DefaultStatusPollSeconds      = 15
+	DefaultDomainCountPollSeconds = 60
+)
+
+// PollingConfig sets how often the tunnel list, tunnel statuses and domain
+// counts are refreshed in the background. Zero uses the default interval.
+type PollingConfig struct {
+	ListSeconds        int `json:"list_seconds,omitempty"`
+	StatusSeconds      int `json:"status_seconds,omitempty"`
+	DomainCountSeconds int `json:"domain_count_seconds,omitempty"`
+	// OnDemand turns background polling off; data only refreshes with r
+	OnDemand bool `json:"on_demand,omitempty"`
+}
+
+func (c *Config) pollInterval(seconds func(*PollingConfig) int, fallback int) time.Duration {
+	if c != nil && c.Polling != nil {
+		if c.Polling.OnDemand {
+			return 0
+		}
+		if s := seconds(c.Polling); s > 0 {
+			return time.Duration(s) * time.Second
+		}
+	}
+	return time.Duration(fallback) * time.Second
+}
+
+// ListPollInterval returns how often the tunnel list is reloaded, or zero
+// when polling is on demand only
+func (c *Config) ListPollInterval() time.Duration {
+	return c.pollInterval(func(p *PollingConfig) int { return p.ListSeconds }, DefaultListPollSeconds)
+}
+
+// StatusPollInterval returns how often tunnel statuses are reloaded, or
+// zero when polling is on demand only
+func (c *Config) StatusPollInterval() time.Duration {
+	return c.pollInterval(func(p *PollingConfig) int { return p.StatusSeconds }, DefaultStatusPollSeconds)
+}
+
+// DomainCountPollInterval returns how often each tunnel's hostname count is
+// reloaded, or zero when polling is on demand only
+func (c *Config) DomainCountPollInterval() time.Duration {
+	return c.pollInterval(func(p *PollingConfig) int { return p.DomainCountSeconds }, DefaultDomainCountPollSeconds)
+}
+
+// OnDemandPolling reports whether background polling is off
+func (c *Config) OnDemandPolling() bool {
+	return c != nil && c.Polling != nil && c.Polling.OnDemand
+}
@@ -0,0 +1,93 @@
+package models
+
+// ServicePreset pre-fills the hostname form for a commonly self-hosted app
+type ServicePreset struct {
+	Name string
+	// Subdomain is suggested when the hostname field is empty
+	Subdomain string
+	// Service is the app's usual local address
+	Service string
+	// OriginRequest holds the originRequest options the app needs through a
+	// tunnel, such as skipping verification of its self-signed certificate
+	OriginRequest map[string]interface{}
+	// Auth recommends whether to put the hostname behind an auth guard
+	Auth string
+}
+
+// ServicePresets is the catalog the hostname form cycles through
+var ServicePresets = []ServicePreset{
+	{
+		Name:      "Home Assistant",
+		Subdomain: "home",
+		Service:   "http://localhost:8123",
+		Auth:      "Has its own login with MFA; add the tunnel to http.trusted_proxies. An auth guard breaks the mobile app",
+	},
+	{
+		Name:      "Jellyfin",
+		Subdomain: "jellyfin",
+		Service:   "http://localhost:8096",
+		Auth:      "Has its own login; an auth guard breaks the TV and mobile apps. Heavy video streaming may breach Cloudflare's terms",
+	},
+	{
+		Name:      "Plex",
+		Subdomain: "plex",
+		Service:   "http://localhost:32400",
+		Auth:      "Has its own login; an auth guard breaks the Plex apps. Heavy video streaming may breach Cloudflare's terms",
+	},
+	{
+		Name:      "Grafana",
+		Subdomain: "grafana",
+		Service:   "http://localhost:3000",
+		Auth:      "Has its own login; set server.root_url to the public https:// URL",
+	},
+	{
+		Name:          "Proxmox VE",
+		Subdomain:     "proxmox",
+		Service:       "https://localhost:8006",
+		OriginRequest: map[string]interface{}{"noTLSVerify": true},
+		Auth:          "Admin console: enable auth (Shift+A) or Cloudflare Access on top of its login",
+	},
+	{
+		Name:          "Portainer",
+		Subdomain:     "portainer",
+		Service:       "https://localhost:9443",
+		OriginRequest: map[string]interface{}{"noTLSVerify": true},
+		Auth:          "Controls Docker on the host: enable auth (Shift+A) or Cloudflare Access",
+	},
+	{
+		Name:      "Nextcloud",
+		Subdomain: "cloud",
+		Service:   "http://localhost:8080",
+		Auth:      "Has its own login; add the hostname to trusted_domains. Uploads over 100 MB need chunking",
+	},
+	{
+		Name:      "Vaultwarden",
+		Subdomain: "vault",
+		Service:   "http://localhost:8080",
+		Auth:      "Has its own login; an auth guard breaks the Bitwarden apps",
+	},
+	{
+		Name:      "Uptime Kuma",
+		Subdomain: "status",
+		Service:   "http://localhost:3001",
+		Auth:      "Status pages are meant to be public; the dashboard has its own login",
+	},
+	{
+		Name:      "Pi-hole",
+		Subdomain: "pihole",
+		Service:   "http://localhost:80",
+		Auth:      "Admin console: enable auth (Shift+A) or Cloudflare Access",
+	},
+	{
+		Name:      "Sonarr",
+		Subdomain: "sonarr",
+		Service:   "http://localhost:8989",
+		Auth:      "Often runs without a login: enable auth (Shift+A)",
+	},
+	{
+		Name:      "Radarr",
+		Subdomain: "radarr",
+		Service:   "http://localhost:7878",
+		Auth:      "Often runs without a login: enable auth (Shift+A)",
+	},
+}
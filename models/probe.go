@@ -0,0 +1,142 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultProbeWorkers = 8
+	DefaultProbeTimeout = 3 * time.Second
+)
+
+// ProbeResult is the outcome of checking whether an origin service answers
+type ProbeResult struct {
+	Service   string
+	Reachable bool
+	// Skipped is set for services that can't be probed, such as http_status:404
+	Skipped bool
+	Latency time.Duration
+	Error   string
+}
+
+// ProbeOrigins checks every service concurrently using at most workers
+// goroutines, each probe bounded by timeout. Duplicate services are probed
+// once. The returned map is keyed by service URL.
+func ProbeOrigins(ctx context.Context, services []string, workers int, timeout time.Duration) map[string]ProbeResult {
+	if workers <= 0 {
+		workers = DefaultProbeWorkers
+	}
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+
+	jobs := make(chan string)
+	results := make(map[string]ProbeResult)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for service := range jobs {
+				result := probeOrigin(ctx, service, timeout)
+				mutex.Lock()
+				results[service] = result
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+	for _, service := range services {
+		if seen[service] {
+			continue
+		}
+		seen[service] = true
+		jobs <- service
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func probeOrigin(ctx context.Context, service string, timeout time.Duration) ProbeResult {
+	result := ProbeResult{Service: service}
+
+	u, err := url.Parse(service)
+	if err != nil || u.Scheme == "" || strings.HasPrefix(service, "http_status:") || u.Scheme == "unix" || u.Scheme == "hello_world" {
+		result.Skipped = true
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+
+	switch u.Scheme {
+	case "http", "https":
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, service, nil)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		client := &http.Client{
+			// Any response means the origin is up, so don't follow redirects
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		resp.Body.Close()
+	default:
+		host := u.Host
+		if u.Port() == "" {
+			port, ok := defaultOriginPorts[u.Scheme]
+			if !ok {
+				result.Skipped = true
+				return result
+			}
+			host = net.JoinHostPort(u.Hostname(), port)
+		}
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", host)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		conn.Close()
+	}
+
+	result.Reachable = true
+	result.Latency = time.Since(start)
+	return result
+}
+
+var defaultOriginPorts = map[string]string{
+	"ssh": "22",
+	"rdp": "3389",
+	"smb": "445",
+	"vnc": "5900",
+}
+
+// Summary returns a short description of the result for status lines
+func (r ProbeResult) Summary() string {
+	switch {
+	case r.Skipped:
+		return "not probed"
+	case r.Reachable:
+		return fmt.Sprintf("up (%s)", r.Latency.Round(time.Millisecond))
+	default:
+		return "down: " + r.Error
+	}
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProcessStats is a snapshot of a managed process's resource usage
+type ProcessStats struct {
+	RSSBytes   uint64
+	CPUPercent float64
+}
+
+// GetProcessStats reads memory and CPU usage for pid through ps, which is
+// available on both Linux and macOS
+func GetProcessStats(pid int) (ProcessStats, error) {
+	output, err := exec.Command("ps", "-o", "rss=,%cpu=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ProcessStats{}, fmt.Errorf("failed to read stats for PID %d: %w", pid, err)
+	}
+
+	fields := strings.Fields(string(output))
+	if len(fields) < 2 {
+		return ProcessStats{}, fmt.Errorf("unexpected ps output for PID %d: %q", pid, output)
+	}
+
+	rssKB, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return ProcessStats{}, fmt.Errorf("failed to parse memory usage: %w", err)
+	}
+	cpu, err := strconv.ParseFloat(strings.Replace(fields[1], ",", ".", 1), 64)
+	if err != nil {
+		return ProcessStats{}, fmt.Errorf("failed to parse CPU usage: %w", err)
+	}
+
+	return ProcessStats{RSSBytes: rssKB * 1024, CPUPercent: cpu}, nil
+}
+
+// FormatBytes renders a byte count like 12.3 MB
+func FormatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// ProcessLimitChecks is how many consecutive samples must exceed a limit
+// before it is reported, so short spikes don't trigger restarts
+const ProcessLimitChecks = 3
+
+// HasProcessLimits reports whether any resource threshold is configured
+func (c *Config) HasProcessLimits() bool {
+	return c != nil && (c.ProcessMemoryLimitMB > 0 || c.ProcessCPULimit > 0)
+}
+
+// ProcessLimitExceeded describes the threshold stats are over, or returns ""
+// when the process is within its limits
+func (c *Config) ProcessLimitExceeded(stats ProcessStats) string {
+	if c == nil {
+		return ""
+	}
+	if limit := uint64(c.ProcessMemoryLimitMB) * 1024 * 1024; limit > 0 && stats.RSSBytes > limit {
+		return fmt.Sprintf("memory %s over the %d MB limit", FormatBytes(stats.RSSBytes), c.ProcessMemoryLimitMB)
+	}
+	if c.ProcessCPULimit > 0 && stats.CPUPercent > c.ProcessCPULimit {
+		return fmt.Sprintf("CPU %.1f%% over the %.0f%% limit", stats.CPUPercent, c.ProcessCPULimit)
+	}
+	return ""
+}
+
+// ProcessKeys returns the keys of all tracked processes in a stable order
+func (tm *TunnelManager) ProcessKeys() []string {
+	processes := tm.GetAllProcesses()
+	keys := make([]string, 0, len(processes))
+	for key := range processes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetProcessStats returns resource usage for every running tracked process
+func (tm *TunnelManager) GetProcessStats() map[string]ProcessStats {
+	stats := make(map[string]ProcessStats)
+	for key, process := range tm.GetRunningTunnels() {
+		if s, err := GetProcessStats(process.PID); err == nil {
+			stats[key] = s
+		}
+	}
+	return stats
+}
+
+// StopProcess stops the tracked process under key, keeping its entry so it
+// can be restarted
+func (tm *TunnelManager) StopProcess(key string) error {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	process, exists := tm.processes[key]
+	if !exists {
+		return fmt.Errorf("process %s: %w", key, ErrNotFound)
+	}
+	if !process.IsRunning() {
+		return nil
+	}
+	// The monitor goroutine may reap the process first, making Wait fail even
+	// though the process did stop
+	if err := tm.stopProcess(process); err != nil && process.IsRunning() {
+		return err
+	}
+	return nil
+}
+
+// RestartProcess stops the tracked process under key, if running, and starts
+// it again with the same command line
+func (tm *TunnelManager) RestartProcess(key string) (*TunnelProcess, error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	process, exists := tm.processes[key]
+	if !exists {
+		return nil, fmt.Errorf("process %s: %w", key, ErrNotFound)
+	}
+	if len(process.Command) == 0 {
+		return nil, fmt.Errorf("no command recorded for %s", key)
+	}
+
+	supervisor := tm.supervisorOf(process)
+	if process.IsRunning() {
+		if err := supervisor.Stop(process); err != nil && process.IsRunning() {
+			return nil, fmt.Errorf("failed to stop %s: %w", key, err)
+		}
+	}
+
+	// Restarted processes outlive the command that restarted them
+	restarted, err := supervisor.Start(context.Background(), key, process.Command[1:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to restart %s: %w", key, err)
+	}
+	restarted.TunnelID = process.TunnelID
+	restarted.Name = process.Name
+	restarted.Config = process.Config
+	tm.processes[key] = restarted
+
+	return restarted, nil
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Reverse proxies a snippet can be generated for
+const (
+	ProxyCaddy = "caddy"
+	ProxyNginx = "nginx"
+)
+
+// ProxyKinds is the order the snippet view cycles through
+var ProxyKinds = []string{ProxyCaddy, ProxyNginx}
+
+// ProxyUpstream is the app address snippets forward to; the origin's own
+// upstream isn't known to the tunnel, so it's left for the user to adjust
+const ProxyUpstream = "127.0.0.1:3000"
+
+// ProxyOrigin is what a reverse proxy at a tunnel origin has to accept:
+// cloudflared connects to Port over Scheme and sends Host
+type ProxyOrigin struct {
+	Scheme string
+	Port   string
+	Host   string
+	Path   string
+	// TLSVerified is set when cloudflared checks the origin's certificate,
+	// so the proxy needs one cloudflared trusts
+	TLSVerified bool
+}
+
+// NewProxyOrigin works out the Host header and port cloudflared uses for an
+// ingress rule. Only http:// and https:// services can sit behind a proxy.
+func NewProxyOrigin(rule TunnelConfigIngress) (ProxyOrigin, error) {
+	service, err := url.Parse(rule.Service)
+	if err != nil || service.Host == "" {
+		return ProxyOrigin{}, fmt.Errorf("service %q is not a URL", rule.Service)
+	}
+	if service.Scheme != "http" && service.Scheme != "https" {
+		return ProxyOrigin{}, fmt.Errorf("%s:// services don't go through a reverse proxy", service.Scheme)
+	}
+
+	origin := ProxyOrigin{Scheme: service.Scheme, Port: service.Port(), Host: rule.Hostname, Path: rule.Path}
+	if origin.Port == "" {
+		origin.Port = "80"
+		if origin.Scheme == "https" {
+			origin.Port = "443"
+		}
+	}
+	// cloudflared sends the public hostname unless the rule overrides it
+	if host, ok := rule.OriginRequest["httpHostHeader"].(string); ok && host != "" {
+		origin.Host = host
+	}
+	if origin.Scheme == "https" {
+		noVerify, _ := rule.OriginRequest["noTLSVerify"].(bool)
+		origin.TLSVerified = !noVerify
+	}
+	return origin, nil
+}
+
+// ReverseProxySnippet renders a Caddyfile site block or nginx server block
+// that accepts rule's requests from cloudflared and forwards them to
+// upstream, defaulting to ProxyUpstream
+func ReverseProxySnippet(kind string, rule TunnelConfigIngress, upstream string) (string, error) {
+	origin, err := NewProxyOrigin(rule)
+	if err != nil {
+		return "", err
+	}
+	if upstream == "" {
+		upstream = ProxyUpstream
+	}
+
+	switch kind {
+	case ProxyCaddy:
+		return caddySnippet(rule, origin, upstream), nil
+	case ProxyNginx:
+		return nginxSnippet(rule, origin, upstream), nil
+	}
+	return "", fmt.Errorf("unknown reverse proxy %q (expected %s)", kind, strings.Join(ProxyKinds, " or "))
+}
+
+func caddySnippet(rule TunnelConfigIngress, origin ProxyOrigin, upstream string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s → %s through the tunnel\n", rule.Hostname, rule.Service)
+	if origin.TLSVerified {
+		b.WriteString("# cloudflared verifies this certificate: enable No TLS Verify on the\n")
+		b.WriteString("# hostname or point its caPool at Caddy's local CA\n")
+	}
+	fmt.Fprintf(&b, "%s://%s:%s {\n", origin.Scheme, origin.Host, origin.Port)
+	if origin.Scheme == "https" {
+		b.WriteString("\ttls internal\n")
+	}
+	if origin.Path != "" {
+		fmt.Fprintf(&b, "\t@tunnel path_regexp %s\n", origin.Path)
+		fmt.Fprintf(&b, "\treverse_proxy @tunnel %s\n", upstream)
+	} else {
+		fmt.Fprintf(&b, "\treverse_proxy %s\n", upstream)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func nginxSnippet(rule TunnelConfigIngress, origin ProxyOrigin, upstream string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s → %s through the tunnel\n", rule.Hostname, rule.Service)
+	b.WriteString("server {\n")
+	if origin.Scheme == "https" {
+		fmt.Fprintf(&b, "    listen %s ssl;\n", origin.Port)
+	} else {
+		fmt.Fprintf(&b, "    listen %s;\n", origin.Port)
+	}
+	fmt.Fprintf(&b, "    server_name %s;\n", origin.Host)
+	if origin.Scheme == "https" {
+		if origin.TLSVerified {
+			b.WriteString("    # cloudflared verifies this certificate: enable No TLS Verify on the\n")
+			b.WriteString("    # hostname or point its caPool at the CA that signed it\n")
+		}
+		fmt.Fprintf(&b, "    ssl_certificate     /etc/nginx/certs/%s.crt;\n", origin.Host)
+		fmt.Fprintf(&b, "    ssl_certificate_key /etc/nginx/certs/%s.key;\n", origin.Host)
+	}
+	b.WriteString("\n    # Visitors' addresses arrive in CF-Connecting-IP; set_real_ip_from is\n")
+	b.WriteString("    # where cloudflared connects from\n")
+	b.WriteString("    set_real_ip_from 127.0.0.1;\n")
+	b.WriteString("    real_ip_header CF-Connecting-IP;\n\n")
+
+	location := "/"
+	if origin.Path != "" {
+		location = "~ " + origin.Path
+	}
+	fmt.Fprintf(&b, "    location %s {\n", location)
+	fmt.Fprintf(&b, "        proxy_pass http://%s;\n", upstream)
+	b.WriteString("        proxy_set_header Host $host;\n")
+	b.WriteString("        proxy_set_header X-Forwarded-Proto https;\n")
+	b.WriteString("        proxy_http_version 1.1;\n")
+	b.WriteString("        proxy_set_header Upgrade $http_upgrade;\n")
+	b.WriteString("        proxy_set_header Connection \"upgrade\";\n")
+	b.WriteString("    }\n}\n")
+	return b.String()
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RemoteLogLevels are the levels `cloudflared tail` can filter on, most
+// verbose first
+var RemoteLogLevels = []string{"debug", "info", "warn", "error"}
+
+// DefaultRemoteLogLevel is the level remote logs start at
+const DefaultRemoteLogLevel = "info"
+
+// remoteLogEvents names the event types of the management log stream
+var remoteLogEvents = map[int]string{0: "cloudflared", 1: "http", 2: "tcp", 3: "udp"}
+
+// RemoteLogLine is one log entry a tunnel's connectors streamed to the edge.
+// Output cloudflared tail didn't emit as JSON is kept as the message.
+type RemoteLogLine struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Event   int                    `json:"event"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+// String formats the line like cloudflared's own log output
+func (l RemoteLogLine) String() string {
+	var b strings.Builder
+	if !l.Time.IsZero() {
+		b.WriteString(l.Time.Local().Format("15:04:05") + " ")
+	}
+	if l.Level != "" {
+		fmt.Fprintf(&b, "%-5s ", strings.ToUpper(l.Level))
+	}
+	if event, ok := remoteLogEvents[l.Event]; ok && l.Event != 0 {
+		b.WriteString("[" + event + "] ")
+	}
+	b.WriteString(l.Message)
+
+	keys := make([]string, 0, len(l.Fields))
+	for key := range l.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%v", key, l.Fields[key])
+	}
+	return b.String()
+}
+
+func parseRemoteLogLine(line string) RemoteLogLine {
+	var parsed RemoteLogLine
+	if err := json.Unmarshal([]byte(line), &parsed); err != nil || parsed.Message == "" {
+		return RemoteLogLine{Message: line}
+	}
+	return parsed
+}
+
+// ManagementToken returns a short-lived token for the tunnel's management
+// endpoints, which lets cloudflared tail connect without cert.pem
+func (c *CloudflareClient) ManagementToken(ctx context.Context, tunnelID string) (string, error) {
+	if c.accountID == "" {
+		return "", fmt.Errorf("account ID not available")
+	}
+
+	var token string
+	path := fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/management", c.accountID, tunnelID)
+	body := map[string]interface{}{"resources": []string{"logs"}}
+	if err := c.apiRequest(ctx, "POST", path, body, &token); err != nil {
+		return "", fmt.Errorf("failed to get management token: %w", err)
+	}
+	return token, nil
+}
+
+// TailTunnelLogs streams the logs of every connector of the tunnel, wherever
+// it runs, through `cloudflared tail` and sends each line on lines until ctx
+// is cancelled or the stream ends. lines is closed when it returns.
+func (c *CloudflareClient) TailTunnelLogs(ctx context.Context, tunnelID, level string, lines chan<- RemoteLogLine) error {
+	defer close(lines)
+
+	token, err := c.ManagementToken(ctx, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "cloudflared", "tail", "--output", "json", "--level", level, tunnelID)
+	// Passed through the environment so the token doesn't show up in ps
+	cmd.Env = append(os.Environ(), "TUNNEL_MANAGEMENT_TOKEN="+token)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start cloudflared tail: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start cloudflared tail: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			select {
+			case lines <- parseRemoteLogLine(line):
+			case <-ctx.Done():
+			}
+		}
+	}
+
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			return fmt.Errorf("cloudflared tail failed: %s", output)
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("cloudflared tail failed: %w", err)
+		}
+		return fmt.Errorf("cloudflared tail exited with status %d", exitErr.ExitCode())
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Healthy reports whether the connector holds at least one edge connection
+// that isn't waiting to reconnect
+func (c TunnelConnector) Healthy() bool {
+	for _, conn := range c.Connections {
+		if !conn.IsPendingReconnect {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary describes the connector in one line: its short ID, version, start
+// time and the colos its healthy connections go through
+func (c TunnelConnector) Summary() string {
+	var colos []string
+	for _, conn := range c.Connections {
+		if !conn.IsPendingReconnect {
+			colos = append(colos, conn.ColoName)
+		}
+	}
+	return fmt.Sprintf("%s  cloudflared %s  since %s  via %s", shortConnectorID(c.ID), c.Version, c.RunAt.Local().Format("Jan 2 15:04"), strings.Join(colos, ", "))
+}
+
+// HealthyConnectors returns the connectors currently able to serve traffic
+func HealthyConnectors(connectors []TunnelConnector) []TunnelConnector {
+	var healthy []TunnelConnector
+	for _, connector := range connectors {
+		if connector.Healthy() {
+			healthy = append(healthy, connector)
+		}
+	}
+	return healthy
+}
+
+// Replicas counts the connectors behind the tunnel's healthy connections.
+// Listings that don't name each connection's connector (cloudflared's own
+// list) count as one connector as long as any connection is up.
+func (t CLITunnel) Replicas() int {
+	clients := make(map[string]bool)
+	up := false
+	for _, conn := range t.Connections {
+		if conn.IsPendingReconnect {
+			continue
+		}
+		up = true
+		if conn.ClientID != "" {
+			clients[conn.ClientID] = true
+		}
+	}
+	if len(clients) == 0 && up {
+		return 1
+	}
+	return len(clients)
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRequestSampleWindow is how far back request samples are fetched
+const DefaultRequestSampleWindow = time.Hour
+
+// RequestSample is a group of requests to a hostname that share a minute,
+// method, path and response status
+type RequestSample struct {
+	Time   time.Time
+	Method string
+	Path   string
+	Status int
+	Count  int
+}
+
+const requestSamplesQuery = `query($zoneTag: string, $filter: ZoneHttpRequestsAdaptiveGroupsFilter_InputObject) {
+  viewer {
+    zones(filter: {zoneTag: $zoneTag}) {
+      httpRequestsAdaptiveGroups(limit: 50, filter: $filter, orderBy: [datetimeMinute_DESC]) {
+        count
+        dimensions {
+          datetimeMinute
+          clientRequestHTTPMethodName
+          clientRequestPath
+          edgeResponseStatus
+        }
+      }
+    }
+  }
+}`
+
+// GetRequestSamples returns recent requests to hostname, newest first, from
+// the zone's GraphQL analytics. Which zones have this dataset, and for how
+// long, depends on the plan; an unavailable dataset is reported as an error.
+func (c *CloudflareClient) GetRequestSamples(ctx context.Context, hostname string, window time.Duration) ([]RequestSample, error) {
+	zoneID, _, err := c.ZoneForHostname(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query": requestSamplesQuery,
+		"variables": map[string]interface{}{
+			"zoneTag": zoneID,
+			"filter": map[string]interface{}{
+				"clientRequestHTTPHost": hostname,
+				"datetime_geq":          time.Now().Add(-window).UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.cloudflare.com/client/v4/graphql", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.CloudflareAPIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query analytics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp.StatusCode, nil)
+	}
+
+	var response struct {
+		Data struct {
+			Viewer struct {
+				Zones []struct {
+					Groups []struct {
+						Count      int `json:"count"`
+						Dimensions struct {
+							Minute string `json:"datetimeMinute"`
+							Method string `json:"clientRequestHTTPMethodName"`
+							Path   string `json:"clientRequestPath"`
+							Status int    `json:"edgeResponseStatus"`
+						} `json:"dimensions"`
+					} `json:"httpRequestsAdaptiveGroups"`
+				} `json:"zones"`
+			} `json:"viewer"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		var messages []string
+		for _, e := range response.Errors {
+			messages = append(messages, e.Message)
+		}
+		return nil, fmt.Errorf("request logs not available for this zone: %s", strings.Join(messages, "; "))
+	}
+
+	var samples []RequestSample
+	for _, zone := range response.Data.Viewer.Zones {
+		for _, group := range zone.Groups {
+			t, _ := time.Parse(time.RFC3339, group.Dimensions.Minute)
+			samples = append(samples, RequestSample{
+				Time:   t,
+				Method: group.Dimensions.Method,
+				Path:   group.Dimensions.Path,
+				Status: group.Dimensions.Status,
+				Count:  group.Count,
+			})
+		}
+	}
+
+	return samples, nil
+}
+
+// SummarizeStatuses counts requests by status class (2xx, 3xx, ...)
+func SummarizeStatuses(samples []RequestSample) map[string]int {
+	summary := make(map[string]int)
+	for _, sample := range samples {
+		summary[fmt.Sprintf("%dxx", sample.Status/100)] += sample.Count
+	}
+	return summary
+}
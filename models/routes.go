@@ -0,0 +1,61 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// TunnelRoute is a private network (CIDR) route served by a tunnel
+type TunnelRoute struct {
+	ID                 string    `json:"id"`
+	Network            string    `json:"network"`
+	TunnelID           string    `json:"tunnel_id"`
+	TunnelName         string    `json:"tunnel_name,omitempty"`
+	Comment            string    `json:"comment,omitempty"`
+	VirtualNetworkID   string    `json:"virtual_network_id,omitempty"`
+	VirtualNetworkName string    `json:"virtual_network_name,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// GetTunnelRoutes returns the private network routes that point at a tunnel
+func (c *CloudflareClient) GetTunnelRoutes(ctx context.Context, tunnelID string) ([]TunnelRoute, error) {
+	if c.accountID == "" {
+		return nil, fmt.Errorf("account ID not available")
+	}
+
+	query := url.Values{}
+	query.Set("tunnel_id", tunnelID)
+	query.Set("is_deleted", "false")
+
+	var routes []TunnelRoute
+	path := fmt.Sprintf("/accounts/%s/teamnet/routes?%s", c.accountID, query.Encode())
+	if err := c.apiRequest(ctx, "GET", path, nil, &routes); err != nil {
+		return nil, fmt.Errorf("failed to list tunnel routes: %w", err)
+	}
+
+	return routes, nil
+}
+
+// GetWarpRouting reports whether WARP (private network) routing is enabled
+// in the tunnel's remote configuration
+func (c *CloudflareClient) GetWarpRouting(ctx context.Context, tunnelID string) (bool, error) {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return false, err
+	}
+	return config.Config.WarpRouting.Enabled, nil
+}
+
+// SetWarpRouting enables or disables WARP routing, which lets WARP clients
+// reach the tunnel's private network routes (including ICMP)
+func (c *CloudflareClient) SetWarpRouting(ctx context.Context, tunnelID string, enabled bool) error {
+	config, err := c.GetTunnelConfiguration(ctx, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	config.Config.WarpRouting.Enabled = enabled
+	return c.UpdateTunnelConfigurationVersion(ctx, tunnelID, config.Version, &config.Config)
+}
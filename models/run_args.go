@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// managedRunFlags are set by tunnelman itself and can't be overridden with
+// extra run arguments
+var managedRunFlags = []string{"--config", "--url", "--loglevel"}
+
+// ParseRunArgs splits extra cloudflared arguments the way a shell would for
+// simple cases: on whitespace, keeping single or double quoted parts together
+func ParseRunArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, ValidateRunArgs(args)
+}
+
+// FormatRunArgs joins arguments back into a line ParseRunArgs accepts
+func FormatRunArgs(args []string) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t'\"") {
+			arg = `"` + arg + `"`
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
+// ValidateRunArgs checks extra arguments start with a flag and don't touch
+// the flags tunnelman manages
+func ValidateRunArgs(args []string) error {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("extra arguments must start with a flag, got %q", args[0])
+	}
+	for _, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		for _, managed := range managedRunFlags {
+			if name == managed || name == strings.TrimPrefix(managed, "-") {
+				return fmt.Errorf("%s is set by tunnelman and can't be passed as an extra argument", managed)
+			}
+		}
+	}
+	return nil
+}
+
+// ConfigureRunArgs sets the extra arguments passed to `cloudflared tunnel`
+// before `run`, keyed by tunnel name
+func (tm *TunnelManager) ConfigureRunArgs(runArgs map[string][]string) error {
+	args := make(map[string][]string, len(runArgs))
+	for tunnelName, tunnelArgs := range runArgs {
+		if err := ValidateRunArgs(tunnelArgs); err != nil {
+			return fmt.Errorf("invalid run arguments for tunnel %s: %w", tunnelName, err)
+		}
+		args[tunnelName] = tunnelArgs
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm.runArgs = args
+	return nil
+}
+
+// SetRunArgs replaces one tunnel's extra arguments; they apply from its next start
+func (tm *TunnelManager) SetRunArgs(tunnelName string, args []string) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	if tm.runArgs == nil {
+		tm.runArgs = make(map[string][]string)
+	}
+	if len(args) == 0 {
+		delete(tm.runArgs, tunnelName)
+		return
+	}
+	tm.runArgs[tunnelName] = args
+}
+
+// RunArgs returns the tunnel's extra arguments
+func (tm *TunnelManager) RunArgs(tunnelName string) []string {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+	return tm.runArgs[tunnelName]
+}
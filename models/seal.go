@@ -0,0 +1,89 @@
+package models
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// sealMagic prefixes data sealed with a passphrase so it can be told apart
+// from plain JSON
+var sealMagic = []byte("tunnelman-sealed-v1\n")
+
+const (
+	sealSaltSize  = 16
+	sealNonceSize = 24
+)
+
+// ErrWrongPassphrase is returned when sealed data doesn't open with the
+// passphrase given, or was tampered with
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted data")
+
+// IsSealed reports whether data was produced by Seal
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, sealMagic)
+}
+
+// sealKey derives the secretbox key from a passphrase with scrypt
+func sealKey(passphrase, salt []byte) (*[32]byte, error) {
+	derived, err := scrypt.Key(passphrase, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	var key [32]byte
+	copy(key[:], derived)
+	return &key, nil
+}
+
+// Seal encrypts data with a key derived from passphrase using NaCl
+// secretbox. The salt and nonce are stored in the output.
+func Seal(data, passphrase []byte) ([]byte, error) {
+	salt := make([]byte, sealSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	var nonce [sealNonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	key, err := sealKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{}, sealMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce[:]...)
+	return secretbox.Seal(out, data, &nonce, key), nil
+}
+
+// Open decrypts data produced by Seal
+func Open(sealed, passphrase []byte) ([]byte, error) {
+	if !IsSealed(sealed) {
+		return nil, fmt.Errorf("not a tunnelman sealed file")
+	}
+	sealed = sealed[len(sealMagic):]
+	if len(sealed) < sealSaltSize+sealNonceSize+secretbox.Overhead {
+		return nil, ErrWrongPassphrase
+	}
+
+	salt := sealed[:sealSaltSize]
+	var nonce [sealNonceSize]byte
+	copy(nonce[:], sealed[sealSaltSize:sealSaltSize+sealNonceSize])
+
+	key, err := sealKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := secretbox.Open(nil, sealed[sealSaltSize+sealNonceSize:], &nonce, key)
+	if !ok {
+		return nil, ErrWrongPassphrase
+	}
+	return data, nil
+}
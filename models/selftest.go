@@ -0,0 +1,156 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultSelfTestTimeout is how long the self test waits for its hostname to
+// answer through the edge
+const DefaultSelfTestTimeout = 2 * time.Minute
+
+// SelfTestStep is one stage of a self test. Skipped steps weren't reached
+// because an earlier one failed.
+type SelfTestStep struct {
+	Name    string
+	Err     error
+	Skipped bool
+	Elapsed time.Duration
+}
+
+// SelfTestResult lists what SelfTest did, in order
+type SelfTestResult struct {
+	Hostname string
+	Steps    []SelfTestStep
+}
+
+// OK reports whether every step succeeded
+func (r *SelfTestResult) OK() bool {
+	for _, step := range r.Steps {
+		if step.Err != nil || step.Skipped {
+			return false
+		}
+	}
+	return len(r.Steps) > 0
+}
+
+// run records a step, skipping it when an earlier one failed, and reports
+// whether the test has failed so far
+func (r *SelfTestResult) run(name string, failed bool, fn func() error) bool {
+	if failed {
+		r.Steps = append(r.Steps, SelfTestStep{Name: name, Skipped: true})
+		return true
+	}
+	start := time.Now()
+	err := fn()
+	r.Steps = append(r.Steps, SelfTestStep{Name: name, Err: err, Elapsed: time.Since(start)})
+	return err != nil
+}
+
+// SelfTestHostname returns a random selftest-<id>.<zone> hostname
+func SelfTestHostname(zone string) (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate hostname: %w", err)
+	}
+	return fmt.Sprintf("selftest-%s.%s", hex.EncodeToString(buf), zone), nil
+}
+
+// SelfTest proves the token, tunnel and DNS work end to end: it serves a
+// random token from a local echo server, routes a throwaway hostname in zone
+// to it, requests the hostname through the edge until the token comes back,
+// then removes the hostname, its DNS record and the server. The tunnel's
+// connector must run on this machine for the edge to reach the server.
+func (c *CloudflareClient) SelfTest(ctx context.Context, tm *TunnelManager, tunnelID, zone string, timeout time.Duration) (*SelfTestResult, error) {
+	hostname, err := SelfTestHostname(zone)
+	if err != nil {
+		return nil, err
+	}
+	token, err := GenerateRandomPassword(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	result := &SelfTestResult{Hostname: hostname}
+	var server *StaticServer
+
+	failed := result.run("Start local echo server", false, func() error {
+		server, err = tm.StartStaticServer("selftest:"+hostname, "", 0, selfTestHandler(token))
+		return err
+	})
+	failed = result.run("Route "+hostname+" to it", failed, func() error {
+		return c.AddPublicHostname(ctx, tunnelID, hostname, "*", server.URL())
+	})
+	routed := !failed
+	result.run("Request it through the edge", failed, func() error {
+		return requestSelfTest(ctx, hostname, token, timeout)
+	})
+
+	// Clean up even when the test was cancelled
+	if server != nil {
+		tm.StopStaticServer(server.Key)
+	}
+	if routed {
+		result.run("Remove hostname and DNS record", false, func() error {
+			return c.RemoveHostnameWithDNS(context.Background(), tunnelID, hostname, "*")
+		})
+	}
+
+	return result, nil
+}
+
+// selfTestHandler echoes the token along with the request it answered
+func selfTestHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintf(w, "tunnelman self test %s\n%s %s%s\n", token, r.Method, r.Host, r.URL.Path)
+	})
+}
+
+// requestSelfTest polls https://hostname/ until the echo server's token comes
+// back, describing the last failure if it never does
+func requestSelfTest(ctx context.Context, hostname, token string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	var last string
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+hostname+"/", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			last = err.Error()
+		} else {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+			switch {
+			case strings.Contains(string(body), token):
+				return nil
+			case resp.StatusCode == StatusTunnelError || strings.Contains(string(body), "error code: 1033"):
+				last = "edge answered 530 - is the tunnel's connector running on this machine?"
+			default:
+				last = fmt.Sprintf("HTTP %d without the expected token", resp.StatusCode)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return ctx.Err()
+			}
+			return fmt.Errorf("no answer after %s: %s", timeout, last)
+		case <-time.After(smokeTestInterval):
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ServiceConnector is a cloudflared instance run by the operating system's
+// service manager rather than by tunnelman
+type ServiceConnector struct {
+	// Manager describes the service, e.g. "systemd: cloudflared.service"
+	Manager string
+	// TunnelRef is the tunnel name or ID the connector runs
+	TunnelRef string
+}
+
+// Label is how a service-managed tunnel is shown in the UI
+func (s ServiceConnector) Label() string {
+	return fmt.Sprintf("service-managed (%s)", s.Manager)
+}
+
+// DetectServiceConnectors finds cloudflared instances run by systemd,
+// launchd or the Windows service manager and the tunnels they serve.
+// Detection is best effort: service managers that aren't available are skipped.
+func DetectServiceConnectors() []ServiceConnector {
+	var commands map[string][]string
+	switch runtime.GOOS {
+	case "linux":
+		commands = systemdCloudflaredCommands()
+	case "darwin":
+		commands = launchdCloudflaredCommands()
+	case "windows":
+		commands = windowsCloudflaredCommands()
+	}
+
+	var connectors []ServiceConnector
+	for manager, args := range commands {
+		if ref := tunnelRefFromArgs(args); ref != "" {
+			connectors = append(connectors, ServiceConnector{Manager: manager, TunnelRef: ref})
+		}
+	}
+	return connectors
+}
+
+// systemdCloudflaredCommands returns the command lines of running cloudflared units
+func systemdCloudflaredCommands() map[string][]string {
+	output, err := exec.Command("systemctl", "list-units", "--type=service", "--state=running", "--no-legend", "--plain", "cloudflared*").Output()
+	if err != nil {
+		return nil
+	}
+
+	commands := make(map[string][]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		unit := fields[0]
+
+		execStart, err := exec.Command("systemctl", "show", "--property=ExecStart", "--value", unit).Output()
+		if err != nil {
+			continue
+		}
+		// ExecStart looks like "{ path=/usr/bin/cloudflared ; argv[]=/usr/bin/cloudflared tunnel run ; ... }"
+		argv := string(execStart)
+		if _, after, found := strings.Cut(argv, "argv[]="); found {
+			argv, _, _ = strings.Cut(after, ";")
+		}
+		commands["systemd: "+unit] = strings.Fields(argv)
+	}
+	return commands
+}
+
+// launchdCloudflaredCommands returns the command lines of cloudflared
+// processes whose parent is launchd
+func launchdCloudflaredCommands() map[string][]string {
+	output, err := exec.Command("ps", "-eo", "pid=,ppid=,args=").Output()
+	if err != nil {
+		return nil
+	}
+
+	commands := make(map[string][]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[1] != "1" || filepath.Base(fields[2]) != "cloudflared" {
+			continue
+		}
+		commands["launchd: PID "+fields[0]] = fields[2:]
+	}
+	return commands
+}
+
+var windowsBinaryPathPattern = regexp.MustCompile(`BINARY_PATH_NAME\s*:\s*(.+)`)
+
+// windowsCloudflaredCommands returns the command line of the Cloudflared
+// Windows service when it is running
+func windowsCloudflaredCommands() map[string][]string {
+	status, err := exec.Command("sc", "query", "Cloudflared").Output()
+	if err != nil || !strings.Contains(string(status), "RUNNING") {
+		return nil
+	}
+
+	config, err := exec.Command("sc", "qc", "Cloudflared").Output()
+	if err != nil {
+		return nil
+	}
+	match := windowsBinaryPathPattern.FindStringSubmatch(string(config))
+	if match == nil {
+		return nil
+	}
+	return map[string][]string{"Windows service: Cloudflared": strings.Fields(strings.ReplaceAll(match[1], `"`, ""))}
+}
+
+// tunnelRefFromArgs works out which tunnel a cloudflared command line runs:
+// an explicit `run <tunnel>`, a --token, or the tunnel in its config file
+func tunnelRefFromArgs(args []string) string {
+	configPath := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		value := ""
+		if name, v, found := strings.Cut(arg, "="); found {
+			arg, value = name, v
+		} else if i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		switch arg {
+		case "--token":
+			if id := tunnelIDFromToken(value); id != "" {
+				return id
+			}
+		case "--config":
+			configPath = value
+		case "run":
+			// The positional argument after run is the tunnel, unless it's a flag
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				return args[i+1]
+			}
+		}
+	}
+
+	return tunnelRefFromConfig(configPath)
+}
+
+// tunnelIDFromToken decodes the tunnel ID from a connector token
+func tunnelIDFromToken(token string) string {
+	credentials, err := CredentialsFromToken(token)
+	if err != nil {
+		return ""
+	}
+	return credentials.TunnelID
+}
+
+// tunnelRefFromConfig reads the tunnel from a cloudflared config file,
+// falling back to the locations cloudflared searches by default
+func tunnelRefFromConfig(configPath string) string {
+	paths := []string{configPath}
+	if configPath == "" {
+		paths = []string{
+			filepath.Join(getDefaultConfigDir(), "config.yml"),
+			filepath.Join(getDefaultConfigDir(), "config.yaml"),
+			"/etc/cloudflared/config.yml",
+			"/etc/cloudflared/config.yaml",
+			"/usr/local/etc/cloudflared/config.yml",
+		}
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var config struct {
+			Tunnel string `yaml:"tunnel"`
+		}
+		if err := yaml.Unmarshal(data, &config); err == nil && config.Tunnel != "" {
+			return config.Tunnel
+		}
+	}
+	return ""
+}
+
+// DetectServiceTunnels refreshes which tunnels are run by a system service.
+// StartTunnel refuses to spawn a second connector for those tunnels.
+func (tm *TunnelManager) DetectServiceTunnels() []ServiceConnector {
+	connectors := DetectServiceConnectors()
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	tm.serviceTunnels = make(map[string]ServiceConnector, len(connectors))
+	for _, connector := range connectors {
+		tm.serviceTunnels[connector.TunnelRef] = connector
+	}
+	return connectors
+}
+
+// ServiceConnectorFor returns the system service running the tunnel, matched
+// by either its name or ID
+func (tm *TunnelManager) ServiceConnectorFor(tunnelName, tunnelID string) (ServiceConnector, bool) {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+	return tm.serviceConnectorFor(tunnelName, tunnelID)
+}
+
+// serviceConnectorFor is ServiceConnectorFor for callers holding tm.mutex
+func (tm *TunnelManager) serviceConnectorFor(tunnelName, tunnelID string) (ServiceConnector, bool) {
+	for _, ref := range []string{tunnelName, tunnelID} {
+		if connector, ok := tm.serviceTunnels[ref]; ok && ref != "" {
+			return connector, true
+		}
+	}
+	return ServiceConnector{}, false
+}
+
+// checkNotServiceManaged refuses to start a duplicate connector; callers must hold tm.mutex
+func (tm *TunnelManager) checkNotServiceManaged(tunnelName, tunnelID string) error {
+	if connector, ok := tm.serviceConnectorFor(tunnelName, tunnelID); ok {
+		return fmt.Errorf("tunnel %s is already run by %s", tunnelName, connector.Manager)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ServiceLimitation explains why a hostname won't work the way a visitor
+// would expect behind Cloudflare's proxy
+type ServiceLimitation struct {
+	// Blocking limitations can never work and shouldn't be created
+	Blocking bool
+	Message  string
+}
+
+// nonHTTPPorts are well-known ports of protocols that aren't HTTP, with the
+// service scheme cloudflared offers for them
+var nonHTTPPorts = map[string]struct{ protocol, scheme string }{
+	"21":    {"FTP", "tcp"},
+	"22":    {"SSH", "ssh"},
+	"25":    {"SMTP", "tcp"},
+	"53":    {"DNS", "tcp"},
+	"445":   {"SMB", "smb"},
+	"1883":  {"MQTT", "tcp"},
+	"3306":  {"MySQL", "tcp"},
+	"3389":  {"RDP", "rdp"},
+	"5432":  {"PostgreSQL", "tcp"},
+	"5900":  {"VNC", "vnc"},
+	"6379":  {"Redis", "tcp"},
+	"27017": {"MongoDB", "tcp"},
+}
+
+// clientSchemes are services browsers can't open: visitors need
+// `cloudflared access` or WARP on their side
+var clientSchemes = map[string]bool{"tcp": true, "ssh": true, "rdp": true, "smb": true, "vnc": true}
+
+// CheckServiceLimitations lists the problems a hostname routed to service
+// would run into. hostname is what the visitor types, so a port on it is
+// flagged too.
+func CheckServiceLimitations(hostname, service string) []ServiceLimitation {
+	var limits []ServiceLimitation
+	if _, port, err := net.SplitHostPort(hostname); err == nil {
+		limits = append(limits, ServiceLimitation{Blocking: true, Message: fmt.Sprintf(
+			"Hostnames can't carry a port: visitors always connect on 443 (or 80), and cloudflared forwards to the service's port - move :%s into the service URL", port)})
+	}
+
+	// Services cloudflared answers itself or serves locally
+	if strings.HasPrefix(service, "http_status:") || service == "hello_world" || service == "bastion" || strings.HasPrefix(service, "folder:") {
+		return limits
+	}
+
+	u, err := url.Parse(service)
+	if err != nil || u.Scheme == "" || (u.Host == "" && u.Scheme != "unix" && u.Scheme != "unix+tls") {
+		return append(limits, ServiceLimitation{Blocking: true, Message: fmt.Sprintf(
+			"%q isn't a service cloudflared understands - start it with http://, https://, tcp:// or ssh:// (e.g. http://localhost:8080)", service)})
+	}
+
+	switch {
+	case u.Scheme == "udp":
+		limits = append(limits, ServiceLimitation{Blocking: true, Message: "Public hostnames can't carry UDP - add the network as a private route and reach it over WARP instead"})
+
+	case clientSchemes[u.Scheme]:
+		client := u.Scheme
+		if client == "vnc" {
+			client = "tcp"
+		}
+		message := fmt.Sprintf("Browsers can't open %s:// hostnames: visitors need `cloudflared access %s --hostname ...` or WARP", u.Scheme, client)
+		if BrowserRenderingType(service) != "" {
+			message += ", or turn on browser rendering with Shift+B"
+		}
+		limits = append(limits, ServiceLimitation{Message: message + ". Open TCP for anyone needs Cloudflare Spectrum"})
+
+	case u.Scheme == "http" || u.Scheme == "https":
+		if known, ok := nonHTTPPorts[u.Port()]; ok {
+			limits = append(limits, ServiceLimitation{Message: fmt.Sprintf(
+				"Port %s usually speaks %s, not HTTP - Cloudflare's proxy only carries HTTP(S) to browsers; use %s://%s and cloudflared access on the client, or Spectrum",
+				u.Port(), known.protocol, known.scheme, u.Host)})
+		}
+	}
+	return limits
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultShareTTL is how long a `tunnelman share` link stays up
+	DefaultShareTTL = time.Hour
+	// DefaultShareUsername is the basic auth user for shared files
+	DefaultShareUsername = "tunnelman"
+)
+
+// Share is a file or folder served on a temporary, password-protected hostname
+type Share struct {
+	Hostname  string
+	TunnelID  string
+	Root      string
+	Username  string
+	Password  string
+	ExpiresAt time.Time
+	Server    *StaticServer
+}
+
+// URL returns the public link to the share
+func (s *Share) URL() string {
+	return "https://" + s.Hostname
+}
+
+// ShareHostname returns a random share-<id>.<zone> hostname
+func ShareHostname(zone string) (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate hostname: %w", err)
+	}
+	return fmt.Sprintf("share-%s.%s", hex.EncodeToString(buf), zone), nil
+}
+
+// BasicAuthHandler requires username and password before calling next
+func BasicAuthHandler(username, password string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="tunnelman share"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// StartShare serves path behind basic auth and publishes it on hostname through
+// the tunnel. The share must be torn down with StopShare once ttl has elapsed.
+func (c *CloudflareClient) StartShare(ctx context.Context, tm *TunnelManager, tunnelID, hostname, path, username string, ttl time.Duration) (*Share, error) {
+	root, err := ResolveServedPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := GenerateRandomPassword(6)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	if username == "" {
+		username = DefaultShareUsername
+	}
+
+	handler, err := FolderHandler(root)
+	if err != nil {
+		return nil, err
+	}
+	server, err := tm.StartStaticServer(FolderServerKey(hostname), root, 0, BasicAuthHandler(username, password, handler))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.AddPublicHostname(ctx, tunnelID, hostname, "*", server.URL()); err != nil {
+		tm.StopStaticServer(server.Key)
+		return nil, fmt.Errorf("failed to publish %s: %w", hostname, err)
+	}
+
+	return &Share{
+		Hostname:  hostname,
+		TunnelID:  tunnelID,
+		Root:      root,
+		Username:  username,
+		Password:  password,
+		ExpiresAt: time.Now().Add(ttl),
+		Server:    server,
+	}, nil
+}
+
+// StopShare removes the share's hostname and DNS record and stops its server
+func (c *CloudflareClient) StopShare(ctx context.Context, tm *TunnelManager, share *Share) error {
+	tm.StopStaticServer(share.Server.Key)
+	if err := c.RemoveHostnameWithDNS(ctx, share.TunnelID, share.Hostname, "*"); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", share.Hostname, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// smokeTestInterval is the pause between requests to a new hostname
+	smokeTestInterval = 3 * time.Second
+	// StatusTunnelError is what the edge answers while it can't reach a
+	// connector for the hostname, e.g. with error 1033 for an unknown tunnel
+	StatusTunnelError = 530
+)
+
+// SmokeTestTimeout returns how long to poll a newly created hostname through
+// the edge before giving up. Zero disables the check.
+func (c *Config) SmokeTestTimeout() time.Duration {
+	if c == nil || c.SmokeTestSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.SmokeTestSeconds) * time.Second
+}
+
+// SmokeTestResult is the outcome of SmokeTestHostname. StatusCode and Error
+// describe the last attempt.
+type SmokeTestResult struct {
+	Hostname   string
+	Live       bool
+	StatusCode int
+	Error      string
+	Elapsed    time.Duration
+}
+
+// Describe summarizes the last attempt for the status bar
+func (r SmokeTestResult) Describe() string {
+	switch {
+	case r.Error != "":
+		return r.Error
+	case r.StatusCode == StatusTunnelError:
+		return "edge answered 530 (tunnel not reachable yet)"
+	}
+	return fmt.Sprintf("HTTP %d", r.StatusCode)
+}
+
+// SmokeTestHostname requests https://hostname/ through the Cloudflare edge
+// until the answer is neither a DNS failure nor a 530/1033 tunnel error, or
+// timeout elapses. Any other answer, including 404s and redirects, means DNS
+// and the ingress configuration have propagated.
+func SmokeTestHostname(ctx context.Context, hostname string, timeout time.Duration) SmokeTestResult {
+	result := SmokeTestResult{Hostname: hostname}
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	for {
+		result.StatusCode, result.Error = smokeTestOnce(ctx, client, "https://"+hostname+"/")
+		result.Elapsed = time.Since(start)
+		if result.Error == "" && result.StatusCode != StatusTunnelError {
+			result.Live = true
+			return result
+		}
+
+		select {
+		case <-ctx.Done():
+			return result
+		case <-time.After(smokeTestInterval):
+		}
+	}
+}
+
+func smokeTestOnce(ctx context.Context, client *http.Client, url string) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err.Error()
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer resp.Body.Close()
+
+	// Some edge errors come back with a different status but name 1033 in the body
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if strings.Contains(string(body), "error code: 1033") {
+		return StatusTunnelError, ""
+	}
+	return resp.StatusCode, ""
+}
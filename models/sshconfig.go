@@ -0,0 +1,82 @@
+package models
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GetSSHConfigPath returns the path of the user's OpenSSH client config
+func GetSSHConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".ssh", "config")
+	}
+	return filepath.Join(home, ".ssh", "config")
+}
+
+// SSHConfigStanza returns the ~/.ssh/config entry that connects to a tunneled
+// SSH hostname through `cloudflared access ssh`
+func SSHConfigStanza(hostname string) string {
+	return fmt.Sprintf("Host %s\n  ProxyCommand cloudflared access ssh --hostname %%h\n", hostname)
+}
+
+// HasSSHConfigHost reports whether the SSH config at path already has a Host
+// entry for hostname
+func HasSSHConfigHost(path, hostname string) (bool, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open SSH config: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "Host") {
+			continue
+		}
+		for _, pattern := range fields[1:] {
+			if strings.EqualFold(pattern, hostname) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, scanner.Err()
+}
+
+// AppendSSHConfig adds the cloudflared stanza for hostname to the SSH config
+// at path (the default config if empty) unless a Host entry already exists.
+// It reports whether the stanza was added.
+func AppendSSHConfig(path, hostname string) (bool, error) {
+	if path == "" {
+		path = GetSSHConfigPath()
+	}
+
+	exists, err := HasSSHConfigHost(path, hostname)
+	if err != nil || exists {
+		return false, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, fmt.Errorf("failed to create SSH config directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return false, fmt.Errorf("failed to open SSH config: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := fmt.Fprintf(file, "\n# Added by tunnelman\n%s", SSHConfigStanza(hostname)); err != nil {
+		return false, fmt.Errorf("failed to write SSH config: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// StartupProbe is what the launch banner reports about the local tools
+type StartupProbe struct {
+	// CloudflaredVersion is empty when cloudflared isn't installed or
+	// doesn't run
+	CloudflaredVersion string
+	DockerAvailable    bool
+}
+
+// ProbeStartup checks which cloudflared is installed and whether Docker is
+// reachable
+func ProbeStartup() StartupProbe {
+	var probe StartupProbe
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if output, err := exec.CommandContext(ctx, "cloudflared", "--version").Output(); err == nil {
+		probe.CloudflaredVersion = ParseCloudflaredVersion(string(output))
+	}
+
+	if dockerManager, err := NewDockerManager(); err == nil {
+		probe.DockerAvailable = dockerManager.IsDockerAvailable()
+		dockerManager.Close()
+	}
+	return probe
+}
+
+// ParseCloudflaredVersion picks the version number out of `cloudflared
+// --version` output such as "cloudflared version 2024.6.1 (built ...)"
+func ParseCloudflaredVersion(output string) string {
+	fields := strings.Fields(output)
+	for i, field := range fields {
+		if field == "version" && i+1 < len(fields) {
+			return fields[i+1]
+		}
+	}
+	if len(fields) > 0 {
+		return fields[len(fields)-1]
+	}
+	return ""
+}
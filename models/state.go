@@ -22,15 +22,41 @@ type UIState struct {
 	IsFiltering    bool     `json:"is_filtering"`
 	WindowWidth    int      `json:"window_width"`
 	WindowHeight   int      `json:"window_height"`
+	// SelectedTunnelID and OpenTunnelID restore the cursor and the open
+	// hostname list by ID, since list positions shift between sessions
+	SelectedTunnelID string   `json:"selected_tunnel_id,omitempty"`
+	OpenTunnelID     string   `json:"open_tunnel_id,omitempty"`
+	PinnedTunnels    []string `json:"pinned_tunnels,omitempty"`
 }
 
 type AppState struct {
+	SchemaVersion  int         `json:"schema_version"`
 	Tunnels        []Tunnel    `json:"tunnels"`
 	DNSRecords     []DNSRecord `json:"dns_records"`
 	UI             UIState     `json:"ui"`
 	LastSync       time.Time   `json:"last_sync"`
 	ConfigPath     string      `json:"config_path"`
 	SelectedDomain string      `json:"selected_domain"`
+	// Maintenance maps hostnames in maintenance mode to their original service
+	Maintenance map[string]string `json:"maintenance,omitempty"`
+	// TemporaryHostnames are removed automatically once they expire
+	TemporaryHostnames []TemporaryHostname `json:"temporary_hostnames,omitempty"`
+	// RecentServices lists the most recently used service URLs, newest first
+	RecentServices []string `json:"recent_services,omitempty"`
+	// CustomNotFound lists tunnels whose catch-all rule points at the
+	// tunnelman 404 page, so the page server is restarted on launch
+	CustomNotFound []string `json:"custom_not_found,omitempty"`
+	// ServedFolders are hostnames whose origin is a folder served by tunnelman
+	ServedFolders []ServedFolder `json:"served_folders,omitempty"`
+	// AuthUsers are the basic auth logins of hostnames behind the auth
+	// proxy, kept across disabling and re-enabling auth
+	AuthUsers map[string][]AuthUser `json:"auth_users,omitempty"`
+	// TunnelDomains is the zone of the hostname last added to each tunnel,
+	// keyed by tunnel ID, preselected the next time the form opens for it
+	TunnelDomains map[string]string `json:"tunnel_domains,omitempty"`
+	// LocalConfigsScanned is set once existing cloudflared configs have been
+	// looked for, so they are only announced on first run
+	LocalConfigsScanned bool `json:"local_configs_scanned,omitempty"`
 }
 
 func NewAppState() *AppState {
@@ -150,6 +176,28 @@ func (s *AppState) UpdateWindowSize(width, height int) {
 	s.UI.WindowHeight = height
 }
 
+// IsPinnedTunnel reports whether the tunnel is pinned to the top of the list
+func (s *AppState) IsPinnedTunnel(id string) bool {
+	for _, pinned := range s.UI.PinnedTunnels {
+		if pinned == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TogglePinnedTunnel pins or unpins a tunnel and returns whether it is now pinned
+func (s *AppState) TogglePinnedTunnel(id string) bool {
+	for i, pinned := range s.UI.PinnedTunnels {
+		if pinned == id {
+			s.UI.PinnedTunnels = append(s.UI.PinnedTunnels[:i], s.UI.PinnedTunnels[i+1:]...)
+			return false
+		}
+	}
+	s.UI.PinnedTunnels = append(s.UI.PinnedTunnels, id)
+	return true
+}
+
 func (s *AppState) SetSelectedDomain(domain string) {
 	s.SelectedDomain = domain
 }
@@ -157,3 +205,37 @@ func (s *AppState) SetSelectedDomain(domain string) {
 func (s *AppState) GetSelectedDomain() string {
 	return s.SelectedDomain
 }
+
+// SetTunnelDomain remembers the zone last used for a hostname of the tunnel
+func (s *AppState) SetTunnelDomain(tunnelID, domain string) {
+	if tunnelID == "" || domain == "" {
+		return
+	}
+	if s.TunnelDomains == nil {
+		s.TunnelDomains = make(map[string]string)
+	}
+	s.TunnelDomains[tunnelID] = domain
+}
+
+// TunnelDomain returns the zone last used for a hostname of the tunnel
+func (s *AppState) TunnelDomain(tunnelID string) string {
+	return s.TunnelDomains[tunnelID]
+}
+
+// MaxRecentServices is the number of service URLs remembered for the hostname form
+const MaxRecentServices = 10
+
+// AddRecentService moves service to the front of the recent services list
+func (s *AppState) AddRecentService(service string) {
+	if service == "" {
+		return
+	}
+
+	recent := []string{service}
+	for _, existing := range s.RecentServices {
+		if existing != service && len(recent) < MaxRecentServices {
+			recent = append(recent, existing)
+		}
+	}
+	s.RecentServices = recent
+}
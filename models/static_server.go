@@ -0,0 +1,103 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// StaticServer is an HTTP server run inside tunnelman, such as the catch-all
+// page or a shared folder. It only lives as long as the tunnelman process.
+type StaticServer struct {
+	Key       string
+	Root      string
+	Port      int
+	StartTime time.Time
+	server    *http.Server
+}
+
+// URL returns the service URL to point an ingress rule at
+func (s *StaticServer) URL() string {
+	return fmt.Sprintf("http://localhost:%d", s.Port)
+}
+
+// StartStaticServer serves handler on localhost:port under key, or on a random
+// free port when port is 0. An already running server for key is returned as is.
+func (tm *TunnelManager) StartStaticServer(key, root string, port int, handler http.Handler) (*StaticServer, error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	if server, exists := tm.servers[key]; exists {
+		return server, nil
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+
+	server := &StaticServer{
+		Key:       key,
+		Root:      root,
+		Port:      listener.Addr().(*net.TCPAddr).Port,
+		StartTime: time.Now(),
+		server:    &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second},
+	}
+	tm.servers[key] = server
+
+	go func() {
+		if err := server.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			tm.mutex.Lock()
+			delete(tm.servers, key)
+			tm.mutex.Unlock()
+		}
+	}()
+
+	return server, nil
+}
+
+// StopStaticServer shuts down the server registered under key, if any
+func (tm *TunnelManager) StopStaticServer(key string) error {
+	tm.mutex.Lock()
+	server, exists := tm.servers[key]
+	delete(tm.servers, key)
+	tm.mutex.Unlock()
+
+	if !exists {
+		return nil
+	}
+	if err := server.server.Close(); err != nil {
+		return fmt.Errorf("failed to stop server %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetStaticServer returns the running server registered under key
+func (tm *TunnelManager) GetStaticServer(key string) (*StaticServer, bool) {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+
+	server, exists := tm.servers[key]
+	return server, exists
+}
+
+// GetStaticServers returns a copy of all running servers
+func (tm *TunnelManager) GetStaticServers() map[string]*StaticServer {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+
+	servers := make(map[string]*StaticServer, len(tm.servers))
+	for key, server := range tm.servers {
+		servers[key] = server
+	}
+	return servers
+}
+
+// StopStaticServers stops every server, e.g. when the TUI exits
+func (tm *TunnelManager) StopStaticServers() {
+	for key := range tm.GetStaticServers() {
+		tm.StopStaticServer(key)
+	}
+}
@@ -0,0 +1,315 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+type SupervisorKind string
+
+const (
+	SupervisorExec    SupervisorKind = "exec"
+	SupervisorSystemd SupervisorKind = "systemd"
+	SupervisorLaunchd SupervisorKind = "launchd"
+	SupervisorDocker  SupervisorKind = "docker"
+)
+
+// Supervisor hosts a tunnel's connector. TunnelManager routes every start,
+// stop and liveness check through the supervisor selected for the tunnel, so
+// the same actions work whether cloudflared is a child process, a service
+// unit or a container.
+type Supervisor interface {
+	Kind() SupervisorKind
+	// Start launches the connector tracked under key; args are the
+	// cloudflared arguments, which service-backed supervisors ignore in
+	// favour of their own unit definition
+	Start(ctx context.Context, key string, args []string) (*TunnelProcess, error)
+	Stop(process *TunnelProcess) error
+	IsRunning(process *TunnelProcess) bool
+}
+
+// ParseSupervisor builds a supervisor from a spec such as "exec",
+// "systemd:cloudflared-home.service", "launchd:com.cloudflare.home" or
+// "docker:home-connector". The name after the colon is the unit, job label
+// or container to control.
+func (tm *TunnelManager) ParseSupervisor(spec string) (Supervisor, error) {
+	kind, name, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	switch SupervisorKind(kind) {
+	case "", SupervisorExec:
+		return &execSupervisor{tm: tm}, nil
+	case SupervisorSystemd:
+		if name == "" {
+			return nil, fmt.Errorf("systemd supervisor needs a unit name, e.g. systemd:cloudflared.service")
+		}
+		return &systemdSupervisor{unit: name}, nil
+	case SupervisorLaunchd:
+		if name == "" {
+			return nil, fmt.Errorf("launchd supervisor needs a job label, e.g. launchd:com.cloudflare.cloudflared")
+		}
+		return &launchdSupervisor{label: name}, nil
+	case SupervisorDocker:
+		if name == "" {
+			return nil, fmt.Errorf("docker supervisor needs a container name, e.g. docker:cloudflared")
+		}
+		return &dockerSupervisor{container: name}, nil
+	}
+	return nil, fmt.Errorf("unknown supervisor %q - use exec, systemd, launchd or docker", kind)
+}
+
+// ConfigureSupervisors selects supervisors per tunnel name from specs in
+// ParseSupervisor format. Tunnels without an entry are run directly.
+func (tm *TunnelManager) ConfigureSupervisors(specs map[string]string) error {
+	supervisors := make(map[string]Supervisor, len(specs))
+	for tunnelName, spec := range specs {
+		supervisor, err := tm.ParseSupervisor(spec)
+		if err != nil {
+			return fmt.Errorf("invalid supervisor for tunnel %s: %w", tunnelName, err)
+		}
+		supervisors[tunnelName] = supervisor
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm.supervisors = supervisors
+	return nil
+}
+
+// SetSupervisor selects the supervisor for a single tunnel
+func (tm *TunnelManager) SetSupervisor(tunnelName string, supervisor Supervisor) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm.supervisors[tunnelName] = supervisor
+}
+
+// supervisorFor returns the tunnel's supervisor; callers must hold tm.mutex
+func (tm *TunnelManager) supervisorFor(tunnelName string) Supervisor {
+	if supervisor, ok := tm.supervisors[tunnelName]; ok {
+		return supervisor
+	}
+	return &execSupervisor{tm: tm}
+}
+
+// execSupervisor runs cloudflared as a child of tunnelman
+type execSupervisor struct {
+	tm *TunnelManager
+}
+
+func (s *execSupervisor) Kind() SupervisorKind { return SupervisorExec }
+
+func (s *execSupervisor) Start(ctx context.Context, key string, args []string) (*TunnelProcess, error) {
+	cmd := exec.CommandContext(ctx, "cloudflared", args...)
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start tunnel: %w", err)
+	}
+
+	go s.tm.monitorProcess(key, cmd)
+
+	return &TunnelProcess{
+		PID:        cmd.Process.Pid,
+		TunnelID:   key,
+		Name:       key,
+		Command:    append([]string{"cloudflared"}, args...),
+		StartTime:  time.Now(),
+		Status:     StatusActive,
+		Supervisor: SupervisorExec,
+		Process:    cmd.Process,
+		supervisor: s,
+	}, nil
+}
+
+func (s *execSupervisor) Stop(process *TunnelProcess) error {
+	if process.Process == nil {
+		return fmt.Errorf("process handle not available")
+	}
+
+	err := process.Process.Signal(syscall.SIGTERM)
+	if err != nil {
+		return fmt.Errorf("failed to send SIGTERM: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := process.Process.Wait()
+		done <- err
+	}()
+
+	select {
+	case <-time.After(10 * time.Second):
+		if err := process.Process.Kill(); err != nil {
+			return fmt.Errorf("failed to kill process: %w", err)
+		}
+		process.Status = StatusInactive
+		return nil
+	case err := <-done:
+		process.Status = StatusInactive
+		return err
+	}
+}
+
+func (s *execSupervisor) IsRunning(process *TunnelProcess) bool {
+	return processAlive(process.Process)
+}
+
+func processAlive(process *os.Process) bool {
+	if process == nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// systemdSupervisor controls a systemd unit that runs the connector
+type systemdSupervisor struct {
+	unit string
+}
+
+func (s *systemdSupervisor) Kind() SupervisorKind { return SupervisorSystemd }
+
+func (s *systemdSupervisor) Start(ctx context.Context, key string, args []string) (*TunnelProcess, error) {
+	if output, err := exec.CommandContext(ctx, "systemctl", "start", s.unit).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %s", s.unit, strings.TrimSpace(string(output)))
+	}
+
+	pid := 0
+	if output, err := exec.Command("systemctl", "show", "--property=MainPID", "--value", s.unit).Output(); err == nil {
+		pid, _ = strconv.Atoi(strings.TrimSpace(string(output)))
+	}
+
+	return &TunnelProcess{
+		PID:        pid,
+		TunnelID:   key,
+		Name:       key,
+		Command:    []string{"systemctl", "start", s.unit},
+		StartTime:  time.Now(),
+		Status:     StatusActive,
+		Supervisor: SupervisorSystemd,
+		supervisor: s,
+	}, nil
+}
+
+func (s *systemdSupervisor) Stop(process *TunnelProcess) error {
+	if output, err := exec.Command("systemctl", "stop", s.unit).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stop %s: %s", s.unit, strings.TrimSpace(string(output)))
+	}
+	process.Status = StatusInactive
+	return nil
+}
+
+func (s *systemdSupervisor) IsRunning(process *TunnelProcess) bool {
+	return exec.Command("systemctl", "is-active", "--quiet", s.unit).Run() == nil
+}
+
+// launchdSupervisor controls a launchd job that runs the connector
+type launchdSupervisor struct {
+	label string
+}
+
+func (s *launchdSupervisor) Kind() SupervisorKind { return SupervisorLaunchd }
+
+func (s *launchdSupervisor) Start(ctx context.Context, key string, args []string) (*TunnelProcess, error) {
+	if output, err := exec.CommandContext(ctx, "launchctl", "start", s.label).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %s", s.label, strings.TrimSpace(string(output)))
+	}
+
+	return &TunnelProcess{
+		PID:        s.pid(),
+		TunnelID:   key,
+		Name:       key,
+		Command:    []string{"launchctl", "start", s.label},
+		StartTime:  time.Now(),
+		Status:     StatusActive,
+		Supervisor: SupervisorLaunchd,
+		supervisor: s,
+	}, nil
+}
+
+func (s *launchdSupervisor) Stop(process *TunnelProcess) error {
+	if output, err := exec.Command("launchctl", "stop", s.label).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stop %s: %s", s.label, strings.TrimSpace(string(output)))
+	}
+	process.Status = StatusInactive
+	return nil
+}
+
+func (s *launchdSupervisor) IsRunning(process *TunnelProcess) bool {
+	return s.pid() > 0
+}
+
+// pid reads the job's PID from `launchctl list <label>`, or 0 when it isn't running
+func (s *launchdSupervisor) pid() int {
+	output, err := exec.Command("launchctl", "list", s.label).Output()
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, `"PID" = `) {
+			pid, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, `"PID" = `), ";"))
+			return pid
+		}
+	}
+	return 0
+}
+
+// dockerSupervisor controls an existing container that runs the connector
+type dockerSupervisor struct {
+	container string
+}
+
+func (s *dockerSupervisor) Kind() SupervisorKind { return SupervisorDocker }
+
+func (s *dockerSupervisor) Start(ctx context.Context, key string, args []string) (*TunnelProcess, error) {
+	dockerManager, err := NewDockerManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Docker manager: %w", err)
+	}
+	defer dockerManager.Close()
+
+	if err := dockerManager.StartContainer(ctx, s.container); err != nil {
+		return nil, err
+	}
+	pid, _ := dockerManager.ContainerPID(s.container)
+
+	return &TunnelProcess{
+		PID:        pid,
+		TunnelID:   key,
+		Name:       key,
+		Command:    []string{"docker", "start", s.container},
+		StartTime:  time.Now(),
+		Status:     StatusActive,
+		Supervisor: SupervisorDocker,
+		supervisor: s,
+	}, nil
+}
+
+func (s *dockerSupervisor) Stop(process *TunnelProcess) error {
+	dockerManager, err := NewDockerManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize Docker manager: %w", err)
+	}
+	defer dockerManager.Close()
+
+	if err := dockerManager.StopContainer(s.container); err != nil {
+		return err
+	}
+	process.Status = StatusInactive
+	return nil
+}
+
+func (s *dockerSupervisor) IsRunning(process *TunnelProcess) bool {
+	dockerManager, err := NewDockerManager()
+	if err != nil {
+		return false
+	}
+	defer dockerManager.Close()
+
+	return dockerManager.IsContainerRunning(s.container)
+}
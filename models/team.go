@@ -0,0 +1,57 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const accessTeamDomainSuffix = ".cloudflareaccess.com"
+
+// TeamDomain returns the Zero Trust team domain for a team name
+func TeamDomain(team string) string {
+	return team + accessTeamDomainSuffix
+}
+
+// GetZeroTrustTeam returns the configured Zero Trust team name, looking it up
+// from the account's Access organization when it isn't configured. The
+// looked-up name is cached for the rest of the session.
+func (c *CloudflareClient) GetZeroTrustTeam(ctx context.Context) (string, error) {
+	if c.config.ZeroTrustTeam != "" {
+		return c.config.ZeroTrustTeam, nil
+	}
+	if c.teamName != "" {
+		return c.teamName, nil
+	}
+	if c.accountID == "" {
+		return "", fmt.Errorf("account ID not available")
+	}
+
+	var organization struct {
+		AuthDomain string `json:"auth_domain"`
+	}
+	if err := c.apiRequest(ctx, "GET", fmt.Sprintf("/accounts/%s/access/organizations", c.accountID), nil, &organization); err != nil {
+		return "", fmt.Errorf("failed to look up Zero Trust organization: %w", err)
+	}
+	if organization.AuthDomain == "" {
+		return "", fmt.Errorf("no Zero Trust organization found for this account")
+	}
+
+	c.teamName = strings.TrimSuffix(organization.AuthDomain, accessTeamDomainSuffix)
+	return c.teamName, nil
+}
+
+// AccessAppLauncherURL returns the team's App Launcher, where users sign in
+// to browser-rendered and other Access applications
+func (c *CloudflareClient) AccessAppLauncherURL(ctx context.Context) string {
+	team, err := c.GetZeroTrustTeam(ctx)
+	if err != nil {
+		return ""
+	}
+	return "https://" + TeamDomain(team)
+}
+
+// TunnelDashboardURL returns the Zero Trust dashboard page for a tunnel
+func (c *CloudflareClient) TunnelDashboardURL(tunnelID string) string {
+	return fmt.Sprintf("https://one.dash.cloudflare.com/%s/networks/tunnels/cfd_tunnel/%s/edit", c.accountID, tunnelID)
+}
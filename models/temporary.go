@@ -0,0 +1,73 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TemporaryHostname is a public hostname that should be removed (together
+// with its DNS record) once it expires
+type TemporaryHostname struct {
+	Hostname  string    `json:"hostname"`
+	TunnelID  string    `json:"tunnel_id"`
+	Path      string    `json:"path,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (t TemporaryHostname) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// AddTemporaryHostname records an expiry for a hostname, replacing any
+// existing expiry for the same hostname
+func (s *AppState) AddTemporaryHostname(temp TemporaryHostname) {
+	s.RemoveTemporaryHostname(temp.Hostname)
+	s.TemporaryHostnames = append(s.TemporaryHostnames, temp)
+}
+
+func (s *AppState) RemoveTemporaryHostname(hostname string) bool {
+	for i, temp := range s.TemporaryHostnames {
+		if temp.Hostname == hostname {
+			s.TemporaryHostnames = append(s.TemporaryHostnames[:i], s.TemporaryHostnames[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetTemporaryHostname returns the expiry record for a hostname, if it has one
+func (s *AppState) GetTemporaryHostname(hostname string) (*TemporaryHostname, bool) {
+	for i, temp := range s.TemporaryHostnames {
+		if temp.Hostname == hostname {
+			return &s.TemporaryHostnames[i], true
+		}
+	}
+	return nil, false
+}
+
+// ExpiredHostnames returns all temporary hostnames that have expired by now
+func (s *AppState) ExpiredHostnames(now time.Time) []TemporaryHostname {
+	var expired []TemporaryHostname
+	for _, temp := range s.TemporaryHostnames {
+		if temp.IsExpired(now) {
+			expired = append(expired, temp)
+		}
+	}
+	return expired
+}
+
+// RemoveHostnameWithDNS removes a public hostname from the tunnel
+// configuration and then deletes its DNS record. A missing ingress rule is
+// not an error so that cleanup can be retried.
+func (c *CloudflareClient) RemoveHostnameWithDNS(ctx context.Context, tunnelID, hostname, path string) error {
+	if err := c.RemovePublicHostname(ctx, tunnelID, hostname, path); err != nil && !IsNotFoundError(err) {
+		return err
+	}
+
+	if err := c.DeleteTunnelDNSRecord(ctx, hostname); err != nil && !IsNotFoundError(err) {
+		return fmt.Errorf("removed hostname but failed to delete DNS record: %w", err)
+	}
+
+	return nil
+}
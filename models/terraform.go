@@ -0,0 +1,248 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// terraformOriginRequest maps the originRequest keys of the tunnel API to the
+// origin_request attributes of the Terraform provider. Keys without an
+// equivalent are left out with a comment.
+var terraformOriginRequest = map[string]string{
+	"noTLSVerify":            "no_tls_verify",
+	"httpHostHeader":         "http_host_header",
+	"originServerName":       "origin_server_name",
+	"caPool":                 "ca_pool",
+	"disableChunkedEncoding": "disable_chunked_encoding",
+	"http2Origin":            "http2_origin",
+	"noHappyEyeballs":        "no_happy_eyeballs",
+	"keepAliveConnections":   "keep_alive_connections",
+	"proxyAddress":           "proxy_address",
+	"proxyPort":              "proxy_port",
+	"proxyType":              "proxy_type",
+	"bastionMode":            "bastion_mode",
+}
+
+// TerraformTunnel is one tunnel's remote config and the DNS records pointing
+// at it, as rendered by RenderTerraform
+type TerraformTunnel struct {
+	Tunnel  CLITunnel
+	Config  TunnelConfigData
+	Records []TerraformRecord
+}
+
+// TerraformRecord is a tunnel CNAME and the zone it lives in
+type TerraformRecord struct {
+	ZoneID   string
+	RecordID string
+	Name     string
+}
+
+// GenerateTerraform renders the named tunnels, or every tunnel when names is
+// empty, as Terraform HCL for the Cloudflare provider
+func (c *CloudflareClient) GenerateTerraform(ctx context.Context, names []string) (string, error) {
+	if c.accountID == "" {
+		return "", fmt.Errorf("account ID not available")
+	}
+
+	var tunnels []CLITunnel
+	if len(names) == 0 {
+		all, err := c.ListTunnels(ctx)
+		if err != nil {
+			return "", err
+		}
+		tunnels = all
+	}
+	for _, name := range names {
+		tunnel, err := c.GetTunnelInfo(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to find tunnel %s: %w", name, err)
+		}
+		tunnels = append(tunnels, *tunnel)
+	}
+
+	exports := make([]TerraformTunnel, 0, len(tunnels))
+	for _, tunnel := range tunnels {
+		config, err := c.GetTunnelConfiguration(ctx, tunnel.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get config for %s: %w", tunnel.Name, err)
+		}
+		export := TerraformTunnel{Tunnel: tunnel, Config: config.Config}
+
+		seen := make(map[string]bool)
+		target := tunnel.ID + ".cfargotunnel.com"
+		for _, rule := range config.Config.Ingress {
+			if rule.Hostname == "" || seen[rule.Hostname] {
+				continue
+			}
+			seen[rule.Hostname] = true
+
+			zoneID, _, err := c.ZoneForHostname(ctx, rule.Hostname)
+			if err != nil {
+				return "", err
+			}
+			records, err := c.dnsRecordsForHostname(ctx, rule.Hostname)
+			if err != nil {
+				return "", fmt.Errorf("failed to get DNS records for %s: %w", rule.Hostname, err)
+			}
+			for _, record := range records {
+				if record.Type == "CNAME" && record.Content == target {
+					export.Records = append(export.Records, TerraformRecord{ZoneID: zoneID, RecordID: record.ID, Name: record.Name})
+				}
+			}
+		}
+		exports = append(exports, export)
+	}
+
+	return RenderTerraform(c.accountID, exports), nil
+}
+
+// RenderTerraform writes cloudflare_tunnel, cloudflare_tunnel_config and
+// cloudflare_record blocks for the tunnels, with import blocks so
+// `terraform plan` adopts the existing resources instead of recreating them.
+// Tunnel secrets can't be read back, so each tunnel gets a sensitive
+// variable and ignores changes to it.
+func RenderTerraform(accountID string, tunnels []TerraformTunnel) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by tunnelman on %s\n", time.Now().Format("2006-01-02 15:04 MST"))
+	b.WriteString("# Review the plan before applying: it should only show imports.\n\n")
+	b.WriteString("terraform {\n  required_providers {\n    cloudflare = {\n      source  = \"cloudflare/cloudflare\"\n      version = \"~> 4.40\"\n    }\n  }\n}\n\n")
+	fmt.Fprintf(&b, "locals {\n  account_id = %s\n}\n", strconv.Quote(accountID))
+
+	names := make(map[string]bool)
+	for _, tunnel := range tunnels {
+		name := terraformName(tunnel.Tunnel.Name, names)
+		importID := accountID + "/" + tunnel.Tunnel.ID
+
+		fmt.Fprintf(&b, "\n# Tunnel %s (%s)\n", tunnel.Tunnel.Name, tunnel.Tunnel.ID)
+		fmt.Fprintf(&b, "variable \"%s_secret\" {\n", name)
+		fmt.Fprintf(&b, "  description = \"TunnelSecret from the cloudflared credentials file %s.json\"\n", tunnel.Tunnel.ID)
+		b.WriteString("  type        = string\n  sensitive   = true\n}\n\n")
+
+		fmt.Fprintf(&b, "resource \"cloudflare_tunnel\" \"%s\" {\n", name)
+		b.WriteString("  account_id = local.account_id\n")
+		fmt.Fprintf(&b, "  name       = %s\n", strconv.Quote(tunnel.Tunnel.Name))
+		fmt.Fprintf(&b, "  secret     = var.%s_secret\n", name)
+		b.WriteString("  config_src = \"cloudflare\"\n\n")
+		b.WriteString("  lifecycle {\n    ignore_changes = [secret]\n  }\n}\n\n")
+		fmt.Fprintf(&b, "import {\n  to = cloudflare_tunnel.%s\n  id = %s\n}\n\n", name, strconv.Quote(importID))
+
+		fmt.Fprintf(&b, "resource \"cloudflare_tunnel_config\" \"%s\" {\n", name)
+		b.WriteString("  account_id = local.account_id\n")
+		fmt.Fprintf(&b, "  tunnel_id  = cloudflare_tunnel.%s.id\n\n", name)
+		b.WriteString("  config {\n")
+		if tunnel.Config.WarpRouting.Enabled {
+			b.WriteString("    warp_routing {\n      enabled = true\n    }\n")
+		}
+		for _, rule := range tunnel.Config.Ingress {
+			writeTerraformIngressRule(&b, rule)
+		}
+		b.WriteString("  }\n}\n\n")
+		fmt.Fprintf(&b, "import {\n  to = cloudflare_tunnel_config.%s\n  id = %s\n}\n", name, strconv.Quote(importID))
+
+		for _, record := range tunnel.Records {
+			recordName := terraformName(record.Name, names)
+			fmt.Fprintf(&b, "\nresource \"cloudflare_record\" \"%s\" {\n", recordName)
+			fmt.Fprintf(&b, "  zone_id = %s\n", strconv.Quote(record.ZoneID))
+			fmt.Fprintf(&b, "  name    = %s\n", strconv.Quote(record.Name))
+			b.WriteString("  type    = \"CNAME\"\n")
+			fmt.Fprintf(&b, "  content = cloudflare_tunnel.%s.cname\n", name)
+			b.WriteString("  proxied = true\n}\n\n")
+			fmt.Fprintf(&b, "import {\n  to = cloudflare_record.%s\n  id = %s\n}\n", recordName, strconv.Quote(record.ZoneID+"/"+record.RecordID))
+		}
+	}
+
+	return b.String()
+}
+
+func writeTerraformIngressRule(b *strings.Builder, rule TunnelConfigIngress) {
+	b.WriteString("    ingress_rule {\n")
+	var attributes [][2]string
+	if rule.Hostname != "" {
+		attributes = append(attributes, [2]string{"hostname", strconv.Quote(rule.Hostname)})
+	}
+	if rule.Path != "" {
+		attributes = append(attributes, [2]string{"path", strconv.Quote(rule.Path)})
+	}
+	attributes = append(attributes, [2]string{"service", strconv.Quote(rule.Service)})
+	writeTerraformAttributes(b, "      ", attributes)
+
+	if len(rule.OriginRequest) > 0 {
+		keys := make([]string, 0, len(rule.OriginRequest))
+		for key := range rule.OriginRequest {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("\n      origin_request {\n")
+		attributes = nil
+		for _, key := range keys {
+			attribute, ok := terraformOriginRequest[key]
+			value, literal := terraformLiteral(rule.OriginRequest[key])
+			if !ok || !literal {
+				fmt.Fprintf(b, "        # not exported: %s\n", key)
+				continue
+			}
+			attributes = append(attributes, [2]string{attribute, value})
+		}
+		writeTerraformAttributes(b, "        ", attributes)
+		b.WriteString("      }\n")
+	}
+	b.WriteString("    }\n")
+}
+
+// writeTerraformAttributes writes name = value lines with the equals signs
+// aligned the way terraform fmt does
+func writeTerraformAttributes(b *strings.Builder, indent string, attributes [][2]string) {
+	width := 0
+	for _, attribute := range attributes {
+		if len(attribute[0]) > width {
+			width = len(attribute[0])
+		}
+	}
+	for _, attribute := range attributes {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, attribute[0], attribute[1])
+	}
+}
+
+// terraformLiteral renders a scalar JSON value as HCL, reporting false for
+// lists and objects
+func terraformLiteral(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// terraformName turns name into a unique Terraform identifier, recording it
+// in taken
+func terraformName(name string, taken map[string]bool) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	base := b.String()
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "t_" + base
+	}
+
+	candidate := base
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", base, i)
+	}
+	taken[candidate] = true
+	return candidate
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultThemeName is the theme used when none is configured
+const DefaultThemeName = "dark"
+
+// Theme is the TUI's color palette as hex colors, one per role
+type Theme struct {
+	Accent     string `json:"accent"`      // titles, selected rows and key names
+	AccentSoft string `json:"accent_soft"` // the loading spinner
+	Text       string `json:"text"`        // table rows and body text
+	Heading    string `json:"heading"`     // column headers
+	Muted      string `json:"muted"`       // hints and secondary details
+	Subtle     string `json:"subtle"`      // the footer and unknown statuses
+	Border     string `json:"border"`      // title and table rules
+	Panel      string `json:"panel"`       // the status bar and box borders
+	OnColor    string `json:"on_color"`    // text on accent, error and panel backgrounds
+	OnWarning  string `json:"on_warning"`  // text on the warning background
+	Success    string `json:"success"`
+	Warning    string `json:"warning"`
+	Caution    string `json:"caution"` // degraded tunnels
+	Error      string `json:"error"`
+}
+
+// Themes are the built-in presets the theme setting can name
+var Themes = map[string]Theme{
+	"dark": {
+		Accent:     "#7C3AED",
+		AccentSoft: "#A78BFA",
+		Text:       "#E5E7EB",
+		Heading:    "#F3F4F6",
+		Muted:      "#9CA3AF",
+		Subtle:     "#6B7280",
+		Border:     "#D1D5DB",
+		Panel:      "#374151",
+		OnColor:    "#FFFFFF",
+		OnWarning:  "#000000",
+		Success:    "#10B981",
+		Warning:    "#F59E0B",
+		Caution:    "#F97316",
+		Error:      "#EF4444",
+	},
+	"light": {
+		Accent:     "#6D28D9",
+		AccentSoft: "#7C3AED",
+		Text:       "#1F2937",
+		Heading:    "#111827",
+		Muted:      "#4B5563",
+		Subtle:     "#6B7280",
+		Border:     "#9CA3AF",
+		Panel:      "#4B5563",
+		OnColor:    "#FFFFFF",
+		OnWarning:  "#FFFFFF",
+		Success:    "#047857",
+		Warning:    "#B45309",
+		Caution:    "#C2410C",
+		Error:      "#B91C1C",
+	},
+	"high-contrast": {
+		Accent:     "#00FFFF",
+		AccentSoft: "#00FFFF",
+		Text:       "#FFFFFF",
+		Heading:    "#FFFFFF",
+		Muted:      "#E0E0E0",
+		Subtle:     "#D0D0D0",
+		Border:     "#FFFFFF",
+		Panel:      "#FFFFFF",
+		OnColor:    "#000000",
+		OnWarning:  "#000000",
+		Success:    "#00FF00",
+		Warning:    "#FFFF00",
+		Caution:    "#FF8800",
+		Error:      "#FF0000",
+	},
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// roles maps each role's config name to its color
+func (t *Theme) roles() map[string]*string {
+	return map[string]*string{
+		"accent":      &t.Accent,
+		"accent_soft": &t.AccentSoft,
+		"text":        &t.Text,
+		"heading":     &t.Heading,
+		"muted":       &t.Muted,
+		"subtle":      &t.Subtle,
+		"border":      &t.Border,
+		"panel":       &t.Panel,
+		"on_color":    &t.OnColor,
+		"on_warning":  &t.OnWarning,
+		"success":     &t.Success,
+		"warning":     &t.Warning,
+		"caution":     &t.Caution,
+		"error":       &t.Error,
+	}
+}
+
+// ThemeNames lists the built-in presets in order
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTheme resolves the configured preset and applies the theme_colors
+// overrides on top of it. Unknown presets, unknown roles and colors that
+// aren't hex are errors; the dark theme is returned alongside them.
+func (c *Config) LoadTheme() (Theme, error) {
+	fallback := Themes[DefaultThemeName]
+	if c == nil {
+		return fallback, nil
+	}
+
+	name := strings.TrimSpace(c.ThemeName)
+	if name == "" {
+		name = DefaultThemeName
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		return fallback, fmt.Errorf("unknown theme %q (expected one of %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+
+	roles := theme.roles()
+	overridden := make([]string, 0, len(c.ThemeColors))
+	for role := range c.ThemeColors {
+		overridden = append(overridden, role)
+	}
+	sort.Strings(overridden)
+	for _, role := range overridden {
+		color := strings.TrimSpace(c.ThemeColors[role])
+		target, known := roles[role]
+		if !known {
+			return fallback, fmt.Errorf("unknown theme color %q", role)
+		}
+		if !hexColorPattern.MatchString(color) {
+			return fallback, fmt.Errorf("theme color %s: %q is not a hex color like #7C3AED", role, color)
+		}
+		*target = color
+	}
+	return theme, nil
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"gopkg.in/yaml.v2"
+)
+
+// traefikNamePattern is what custom middleware names must look like; "@" is
+// reserved for Traefik's provider namespaces
+var traefikNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// traefikIDNA is the lookup profile plus DNS length checks, which also catch
+// empty labels
+var traefikIDNA = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true))
+
+// traefikHost returns the ASCII form of hostname that Traefik compares Host
+// headers with, converting IDN labels to punycode. Hostnames that aren't
+// valid DNS names are rejected rather than written into a rule.
+func traefikHost(hostname string) (host string, wildcard bool, err error) {
+	host = strings.TrimSuffix(hostname, ".")
+	if rest, ok := strings.CutPrefix(host, "*."); ok {
+		host, wildcard = rest, true
+	}
+	host, err = traefikIDNA.ToASCII(host)
+	if err != nil || host == "" || !strings.Contains(host, ".") {
+		return "", false, fmt.Errorf("%q is not a valid hostname for the auth proxy", hostname)
+	}
+	return host, wildcard, nil
+}
+
+// traefikIdentifier turns an ASCII hostname into a router, service or
+// middleware name: lowercase letters, digits and dashes only
+func traefikIdentifier(host string, wildcard bool) string {
+	var b strings.Builder
+	if wildcard {
+		b.WriteString("wildcard-")
+	}
+	for _, r := range strings.ToLower(host) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// traefikRule matches the hostname, or any single label under it for a
+// wildcard hostname
+func traefikRule(host string, wildcard bool) string {
+	if wildcard {
+		return fmt.Sprintf("HostRegexp(`^[^.]+\\.%s$`)", strings.ReplaceAll(host, ".", `\.`))
+	}
+	return fmt.Sprintf("Host(`%s`)", host)
+}
+
+// The Traefik file provider's dynamic configuration, limited to what the
+// auth proxy uses. Custom middleware definitions are passed through as is.
+type traefikDynamicConfig struct {
+	HTTP traefikHTTPConfig `yaml:"http"`
+}
+
+type traefikHTTPConfig struct {
+	Routers     map[string]traefikRouter  `yaml:"routers"`
+	Services    map[string]traefikService `yaml:"services"`
+	Middlewares map[string]interface{}    `yaml:"middlewares"`
+}
+
+type traefikRouter struct {
+	Rule        string   `yaml:"rule"`
+	Service     string   `yaml:"service"`
+	Middlewares []string `yaml:"middlewares,omitempty"`
+}
+
+type traefikService struct {
+	LoadBalancer traefikLoadBalancer `yaml:"loadBalancer"`
+}
+
+type traefikLoadBalancer struct {
+	Servers []traefikServer `yaml:"servers"`
+}
+
+type traefikServer struct {
+	URL string `yaml:"url"`
+}
+
+// TraefikMiddlewaresFor returns the custom Traefik middlewares configured for
+// hostname, merged from every matching pattern in traefik_middlewares. An
+// exact hostname entry wins over patterns defining the same middleware.
+func (c *Config) TraefikMiddlewaresFor(hostname string) map[string]interface{} {
+	if c == nil || len(c.TraefikMiddlewares) == 0 {
+		return nil
+	}
+
+	hostname = strings.ToLower(hostname)
+	patterns := make([]string, 0, len(c.TraefikMiddlewares))
+	for pattern := range c.TraefikMiddlewares {
+		patterns = append(patterns, pattern)
+	}
+	// Apply patterns first, in a stable order, so the exact entry overrides them
+	sort.Slice(patterns, func(i, j int) bool {
+		iExact := strings.EqualFold(patterns[i], hostname)
+		jExact := strings.EqualFold(patterns[j], hostname)
+		if iExact != jExact {
+			return jExact
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	middlewares := make(map[string]interface{})
+	for _, pattern := range patterns {
+		if matched, err := path.Match(strings.ToLower(pattern), hostname); err != nil || !matched {
+			continue
+		}
+		for name, definition := range c.TraefikMiddlewares[pattern] {
+			middlewares[name] = definition
+		}
+	}
+	return middlewares
+}
+
+// wholeNumbers turns the float64s JSON decoding produces back into ints where
+// they are whole, so e.g. stsSeconds isn't written as 3.1536e+07
+func wholeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v)
+		}
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = wholeNumbers(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = wholeNumbers(item)
+		}
+		return converted
+	}
+	return value
+}
+
+// buildTraefikConfig renders the auth proxy's dynamic configuration: one
+// router for hostname that applies basic auth for users and then the custom
+// middlewares, in name order, before forwarding to service
+func buildTraefikConfig(hostname, service string, users []AuthUser, custom map[string]interface{}) ([]byte, error) {
+	if len(users) == 0 {
+		return nil, fmt.Errorf("%s has no auth users", hostname)
+	}
+	host, wildcard, err := traefikHost(hostname)
+	if err != nil {
+		return nil, err
+	}
+	routerName := traefikIdentifier(host, wildcard)
+	serviceName := routerName + "-service"
+	authName := routerName + "-auth"
+
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, user.Name+":"+user.Hash)
+	}
+	middlewares := map[string]interface{}{
+		authName: map[string]interface{}{
+			"basicAuth": map[string]interface{}{
+				"users": logins,
+			},
+		},
+	}
+	chain := []string{authName}
+
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !traefikNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid Traefik middleware name %q (use letters, digits, - and _)", name)
+		}
+		if name == authName {
+			return nil, fmt.Errorf("custom middleware %q would replace the auth middleware", name)
+		}
+		middlewares[name] = wholeNumbers(custom[name])
+		chain = append(chain, name)
+	}
+
+	config := traefikDynamicConfig{HTTP: traefikHTTPConfig{
+		Routers: map[string]traefikRouter{
+			routerName: {
+				Rule:        traefikRule(host, wildcard),
+				Service:     serviceName,
+				Middlewares: chain,
+			},
+		},
+		Services: map[string]traefikService{
+			serviceName: {LoadBalancer: traefikLoadBalancer{Servers: []traefikServer{{URL: service}}}},
+		},
+		Middlewares: middlewares,
+	}}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Traefik config: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestTraefikHost(t *testing.T) {
+	tests := []struct {
+		hostname string
+		host     string
+		wildcard bool
+		id       string
+	}{
+		{"app.example.com", "app.example.com", false, "app-example-com"},
+		{"My-App.Example.com", "my-app.example.com", false, "my-app-example-com"},
+		{"api.v2.my-site.io.", "api.v2.my-site.io", false, "api-v2-my-site-io"},
+		{"bücher.example.com", "xn--bcher-kva.example.com", false, "xn--bcher-kva-example-com"},
+		{"xn--bcher-kva.example.com", "xn--bcher-kva.example.com", false, "xn--bcher-kva-example-com"},
+		{"日本.example.jp", "xn--wgv71a.example.jp", false, "xn--wgv71a-example-jp"},
+		{"*.example.com", "example.com", true, "wildcard-example-com"},
+	}
+	for _, tt := range tests {
+		host, wildcard, err := traefikHost(tt.hostname)
+		if err != nil {
+			t.Errorf("traefikHost(%q) failed: %v", tt.hostname, err)
+			continue
+		}
+		if host != tt.host || wildcard != tt.wildcard {
+			t.Errorf("traefikHost(%q) = %q, %v; want %q, %v", tt.hostname, host, wildcard, tt.host, tt.wildcard)
+		}
+		if id := traefikIdentifier(host, wildcard); id != tt.id {
+			t.Errorf("traefikIdentifier(%q) = %q; want %q", host, id, tt.id)
+		}
+	}
+}
+
+func TestTraefikHostInvalid(t *testing.T) {
+	for _, hostname := range []string{
+		"",
+		"localhost",
+		"bad host.example.com",
+		"app..example.com",
+		"-app.example.com",
+		"app_1.example.com",
+		"app.example.com`) || Host(`evil.com",
+	} {
+		if _, _, err := traefikHost(hostname); err == nil {
+			t.Errorf("traefikHost(%q) accepted an invalid hostname", hostname)
+		}
+	}
+}
+
+var testAuthUsers = []AuthUser{{Name: AuthUsername, Hash: "$2a$10$hash"}}
+
+func parseTraefikConfig(t *testing.T, data []byte) traefikDynamicConfig {
+	t.Helper()
+	var config traefikDynamicConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("generated config isn't valid YAML: %v\n%s", err, data)
+	}
+	return config
+}
+
+func TestBuildTraefikConfigIDN(t *testing.T) {
+	data, err := buildTraefikConfig("bücher.example.com", "http://host.docker.internal:8080", testAuthUsers, nil)
+	if err != nil {
+		t.Fatalf("buildTraefikConfig failed: %v", err)
+	}
+	config := parseTraefikConfig(t, data)
+
+	router, ok := config.HTTP.Routers["xn--bcher-kva-example-com"]
+	if !ok {
+		t.Fatalf("router not keyed by the sanitized hostname: %v", config.HTTP.Routers)
+	}
+	if router.Rule != "Host(`xn--bcher-kva.example.com`)" {
+		t.Errorf("rule = %q; want the punycode hostname", router.Rule)
+	}
+	if router.Service != "xn--bcher-kva-example-com-service" {
+		t.Errorf("service = %q", router.Service)
+	}
+	if _, ok := config.HTTP.Services[router.Service]; !ok {
+		t.Errorf("router points at missing service %q", router.Service)
+	}
+	if len(router.Middlewares) != 1 || router.Middlewares[0] != "xn--bcher-kva-example-com-auth" {
+		t.Errorf("middlewares = %v; want only the auth middleware", router.Middlewares)
+	}
+	for _, name := range router.Middlewares {
+		if _, ok := config.HTTP.Middlewares[name]; !ok {
+			t.Errorf("router references undefined middleware %q", name)
+		}
+	}
+}
+
+func TestBuildTraefikConfigWildcard(t *testing.T) {
+	data, err := buildTraefikConfig("*.example.com", "http://host.docker.internal:8080", testAuthUsers, nil)
+	if err != nil {
+		t.Fatalf("buildTraefikConfig failed: %v", err)
+	}
+	config := parseTraefikConfig(t, data)
+
+	router, ok := config.HTTP.Routers["wildcard-example-com"]
+	if !ok {
+		t.Fatalf("router not keyed by the sanitized hostname: %v", config.HTTP.Routers)
+	}
+	if want := "HostRegexp(`^[^.]+\\.example\\.com$`)"; router.Rule != want {
+		t.Errorf("rule = %q; want %q", router.Rule, want)
+	}
+}
+
+func TestBuildTraefikConfigMiddlewares(t *testing.T) {
+	custom := map[string]interface{}{
+		"ratelimit":  map[string]interface{}{"rateLimit": map[string]interface{}{"average": float64(100)}},
+		"headers_v2": map[string]interface{}{"headers": map[string]interface{}{"stsSeconds": float64(31536000)}},
+	}
+	data, err := buildTraefikConfig("app.example.com", "http://host.docker.internal:8080", testAuthUsers, custom)
+	if err != nil {
+		t.Fatalf("buildTraefikConfig failed: %v", err)
+	}
+	if strings.Contains(string(data), "e+07") {
+		t.Errorf("whole numbers written in exponent form:\n%s", data)
+	}
+	config := parseTraefikConfig(t, data)
+
+	want := []string{"app-example-com-auth", "headers_v2", "ratelimit"}
+	got := config.HTTP.Routers["app-example-com"].Middlewares
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("middleware chain = %v; want %v", got, want)
+	}
+}
+
+func TestBuildTraefikConfigUsers(t *testing.T) {
+	users := []AuthUser{{Name: "alice", Hash: "$2a$10$alice"}, {Name: "bob", Hash: "$2a$10$bob"}}
+	data, err := buildTraefikConfig("app.example.com", "http://host.docker.internal:8080", users, nil)
+	if err != nil {
+		t.Fatalf("buildTraefikConfig failed: %v", err)
+	}
+	var config struct {
+		HTTP struct {
+			Middlewares map[string]struct {
+				BasicAuth struct {
+					Users []string `yaml:"users"`
+				} `yaml:"basicAuth"`
+			} `yaml:"middlewares"`
+		} `yaml:"http"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("generated config isn't valid YAML: %v", err)
+	}
+	got := config.HTTP.Middlewares["app-example-com-auth"].BasicAuth.Users
+	want := []string{"alice:$2a$10$alice", "bob:$2a$10$bob"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("basic auth users = %v; want %v", got, want)
+	}
+
+	if _, err := buildTraefikConfig("app.example.com", "http://localhost", nil, nil); err == nil {
+		t.Error("config without users was accepted")
+	}
+}
+
+func TestBuildTraefikConfigRejectsBadMiddlewareNames(t *testing.T) {
+	for _, name := range []string{"app-example-com-auth", "auth@file", "has space", ""} {
+		custom := map[string]interface{}{name: map[string]interface{}{}}
+		if _, err := buildTraefikConfig("app.example.com", "http://localhost", testAuthUsers, custom); err == nil {
+			t.Errorf("middleware name %q was accepted", name)
+		}
+	}
+}
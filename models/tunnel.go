@@ -11,8 +11,16 @@ const (
 	StatusInactive TunnelStatus = "inactive"
 	StatusError    TunnelStatus = "error"
 	StatusUnknown  TunnelStatus = "unknown"
+	// StatusDegraded means the tunnel is serving traffic but some of its
+	// connections are down or reconnecting
+	StatusDegraded TunnelStatus = "degraded"
 )
 
+// IsUp reports whether a tunnel in this status is serving traffic
+func (s TunnelStatus) IsUp() bool {
+	return s == StatusActive || s == StatusDegraded
+}
+
 type TunnelConfig struct {
 	URL      string            `json:"url"`
 	Service  string            `json:"service"`
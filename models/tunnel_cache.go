@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const DefaultTunnelCacheFile = "tunnel_cache.json"
+
+// TunnelCache is the tunnel list and statuses from the last session, shown at
+// startup while the first refresh runs
+type TunnelCache struct {
+	SavedAt      time.Time               `json:"saved_at"`
+	Tunnels      []CLITunnel             `json:"tunnels"`
+	Statuses     map[string]TunnelStatus `json:"statuses,omitempty"`
+	DomainCounts map[string]int          `json:"domain_counts,omitempty"`
+	path         string
+}
+
+func GetTunnelCachePath() string {
+	return filepath.Join(GetStateDir(), DefaultTunnelCacheFile)
+}
+
+// LoadTunnelCache reads the cache at path, or the default location when path
+// is empty. A missing cache is returned empty.
+func LoadTunnelCache(path string) (*TunnelCache, error) {
+	if path == "" {
+		path = GetTunnelCachePath()
+	}
+
+	cache := &TunnelCache{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return cache, fmt.Errorf("failed to read tunnel cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		return cache, fmt.Errorf("failed to unmarshal tunnel cache: %w", err)
+	}
+
+	return cache, nil
+}
+
+// Update replaces the cached tunnels, statuses and hostname counts
+func (c *TunnelCache) Update(tunnels []CLITunnel, statuses map[string]TunnelStatus, domainCounts map[string]int, at time.Time) {
+	c.SavedAt = at
+	c.Tunnels = append([]CLITunnel{}, tunnels...)
+	c.Statuses = make(map[string]TunnelStatus, len(tunnels))
+	c.DomainCounts = make(map[string]int, len(tunnels))
+	for _, tunnel := range tunnels {
+		if status, ok := statuses[tunnel.ID]; ok {
+			c.Statuses[tunnel.ID] = status
+		}
+		if count, ok := domainCounts[tunnel.ID]; ok {
+			c.DomainCounts[tunnel.ID] = count
+		}
+	}
+}
+
+func (c *TunnelCache) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tunnel cache: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write tunnel cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultTunnelPageSize is used when paged listing is enabled by a name
+// prefix alone
+const DefaultTunnelPageSize = 100
+
+// TunnelListOptions filters and pages the account's tunnels server side
+type TunnelListOptions struct {
+	NamePrefix string
+	Page       int
+	PerPage    int
+}
+
+// UsePagedTunnelList reports whether tunnels should be listed page by page
+// through the API instead of with `cloudflared tunnel list`
+func (c *Config) UsePagedTunnelList() bool {
+	return c != nil && (c.TunnelPageSize > 0 || c.TunnelNamePrefix != "" || c.APIOnly)
+}
+
+// TunnelListPageSize returns the configured page size for paged listing
+func (c *Config) TunnelListPageSize() int {
+	if c == nil || c.TunnelPageSize <= 0 {
+		return DefaultTunnelPageSize
+	}
+	return c.TunnelPageSize
+}
+
+// ListTunnelsPage fetches one page of live tunnels from the API. Deleted
+// tunnels and names outside the prefix are filtered by Cloudflare rather
+// than locally. The second result reports whether more pages follow.
+func (c *CloudflareClient) ListTunnelsPage(ctx context.Context, opts TunnelListOptions) ([]CLITunnel, bool, error) {
+	if c.accountID == "" {
+		return nil, false, fmt.Errorf("account ID not available")
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PerPage < 1 {
+		opts.PerPage = DefaultTunnelPageSize
+	}
+
+	query := url.Values{}
+	query.Set("is_deleted", "false")
+	query.Set("page", strconv.Itoa(opts.Page))
+	query.Set("per_page", strconv.Itoa(opts.PerPage))
+	if opts.NamePrefix != "" {
+		query.Set("include_prefix", opts.NamePrefix)
+	}
+	endpoint := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/cfd_tunnel?%s", c.accountID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.CloudflareAPIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list tunnels: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Success bool `json:"success"`
+		Result  []struct {
+			ID          string                `json:"id"`
+			Name        string                `json:"name"`
+			CreatedAt   time.Time             `json:"created_at"`
+			DeletedAt   *time.Time            `json:"deleted_at"`
+			Connections []CLITunnelConnection `json:"connections"`
+		} `json:"result"`
+		ResultInfo struct {
+			Page       int `json:"page"`
+			PerPage    int `json:"per_page"`
+			TotalCount int `json:"total_count"`
+		} `json:"result_info"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, false, newAPIError(resp.StatusCode, nil)
+		}
+		return nil, false, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || !response.Success {
+		return nil, false, newAPIError(resp.StatusCode, response.Errors)
+	}
+
+	tunnels := make([]CLITunnel, 0, len(response.Result))
+	for _, t := range response.Result {
+		tunnel := CLITunnel{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt, Connections: t.Connections}
+		if t.DeletedAt != nil {
+			tunnel.DeletedAt = *t.DeletedAt
+		}
+		tunnels = append(tunnels, tunnel)
+	}
+
+	more := opts.Page*opts.PerPage < response.ResultInfo.TotalCount
+	return tunnels, more, nil
+}
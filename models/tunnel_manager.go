@@ -15,10 +15,21 @@ import (
 )
 
 type TunnelManager struct {
-	client    *CloudflareClient
-	processes map[string]*TunnelProcess
-	mutex     sync.RWMutex
-	configDir string
+	client      *CloudflareClient
+	processes   map[string]*TunnelProcess
+	mutex       sync.RWMutex
+	configDir   string
+	servers     map[string]*StaticServer
+	supervisors map[string]Supervisor
+	// serviceTunnels are tunnels whose connector runs as a system service,
+	// keyed by tunnel name or ID
+	serviceTunnels map[string]ServiceConnector
+	// logLevel and logLevels are passed to cloudflared as --loglevel, the
+	// latter keyed by tunnel name
+	logLevel  string
+	logLevels map[string]string
+	// runArgs are extra flags for `cloudflared tunnel`, keyed by tunnel name
+	runArgs map[string][]string
 }
 
 type TunnelProcess struct {
@@ -30,6 +41,9 @@ type TunnelProcess struct {
 	Status    TunnelStatus      `json:"status"`
 	Config    *TunnelConfigFile `json:"config,omitempty"`
 	Process   *os.Process       `json:"-"`
+	// Supervisor is how the connector is hosted; empty means a direct child process
+	Supervisor SupervisorKind `json:"supervisor,omitempty"`
+	supervisor Supervisor
 }
 
 type TunnelConfigFile struct {
@@ -58,66 +72,59 @@ func NewTunnelManager(client *CloudflareClient, configDir string) *TunnelManager
 	}
 
 	return &TunnelManager{
-		client:    client,
-		processes: make(map[string]*TunnelProcess),
-		configDir: configDir,
+		client:      client,
+		processes:   make(map[string]*TunnelProcess),
+		configDir:   configDir,
+		servers:     make(map[string]*StaticServer),
+		supervisors: make(map[string]Supervisor),
 	}
 }
 
-func getDefaultConfigDir() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ".cloudflared"
-	}
-	return filepath.Join(home, ".cloudflared")
-}
-
 // Process Management
 
 func (tm *TunnelManager) StartTunnel(ctx context.Context, tunnelName string, config *TunnelConfigFile) (*TunnelProcess, error) {
 	tm.mutex.Lock()
 	defer tm.mutex.Unlock()
 
+	return tm.startTunnelLocked(ctx, tunnelName, config)
+}
+
+// startTunnelLocked starts the tunnel's connector; callers must hold tm.mutex
+func (tm *TunnelManager) startTunnelLocked(ctx context.Context, tunnelName string, config *TunnelConfigFile) (*TunnelProcess, error) {
 	if process, exists := tm.processes[tunnelName]; exists && process.IsRunning() {
 		return nil, fmt.Errorf("tunnel %s is already running with PID %d", tunnelName, process.PID)
 	}
+	if err := tm.checkNotServiceManaged(tunnelName, ""); err != nil {
+		return nil, err
+	}
 
 	var args []string
 	var configPath string
+	logLevel, _ := tm.logLevelFor(tunnelName)
 
 	if config != nil {
+		if logLevel != "" {
+			config.LogLevel = logLevel
+		}
 		configPath = filepath.Join(tm.configDir, fmt.Sprintf("%s.yml", tunnelName))
 		if err := tm.SaveTunnelConfig(tunnelName, config); err != nil {
 			return nil, fmt.Errorf("failed to save config: %w", err)
 		}
-		args = []string{"tunnel", "--config", configPath, "run", tunnelName}
+		args = append([]string{"tunnel", "--config", configPath}, logLevelArgs(logLevel)...)
 	} else {
-		args = []string{"tunnel", "run", tunnelName}
-	}
-
-	cmd := exec.CommandContext(ctx, "cloudflared", args...)
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start tunnel: %w", err)
+		args = append([]string{"tunnel"}, logLevelArgs(logLevel)...)
 	}
+	args = append(args, tm.runArgs[tunnelName]...)
+	args = append(args, "run", tunnelName)
 
-	process := &TunnelProcess{
-		PID:       cmd.Process.Pid,
-		TunnelID:  tunnelName,
-		Name:      tunnelName,
-		Command:   append([]string{"cloudflared"}, args...),
-		StartTime: time.Now(),
-		Status:    StatusActive,
-		Config:    config,
-		Process:   cmd.Process,
+	process, err := tm.supervisorFor(tunnelName).Start(ctx, tunnelName, args)
+	if err != nil {
+		return nil, err
 	}
+	process.Config = config
 
 	tm.processes[tunnelName] = process
 
-	go tm.monitorProcess(tunnelName, cmd)
-
 	return process, nil
 }
 
@@ -128,30 +135,21 @@ func (tm *TunnelManager) StartTunnelWithURL(ctx context.Context, tunnelName, ser
 	if process, exists := tm.processes[tunnelName]; exists && process.IsRunning() {
 		return nil, fmt.Errorf("tunnel %s is already running with PID %d", tunnelName, process.PID)
 	}
-
-	args := []string{"tunnel", "--url", serviceURL, "run", tunnelName}
-	cmd := exec.CommandContext(ctx, "cloudflared", args...)
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start tunnel: %w", err)
+	if err := tm.checkNotServiceManaged(tunnelName, ""); err != nil {
+		return nil, err
 	}
 
-	process := &TunnelProcess{
-		PID:       cmd.Process.Pid,
-		TunnelID:  tunnelName,
-		Name:      tunnelName,
-		Command:   append([]string{"cloudflared"}, args...),
-		StartTime: time.Now(),
-		Status:    StatusActive,
-		Process:   cmd.Process,
+	logLevel, _ := tm.logLevelFor(tunnelName)
+	args := append([]string{"tunnel", "--url", serviceURL}, logLevelArgs(logLevel)...)
+	args = append(args, tm.runArgs[tunnelName]...)
+	args = append(args, "run", tunnelName)
+	process, err := tm.supervisorFor(tunnelName).Start(ctx, tunnelName, args)
+	if err != nil {
+		return nil, err
 	}
 
 	tm.processes[tunnelName] = process
 
-	go tm.monitorProcess(tunnelName, cmd)
-
 	return process, nil
 }
 
@@ -185,32 +183,16 @@ func (tm *TunnelManager) StopTunnelByPID(pid int) error {
 }
 
 func (tm *TunnelManager) stopProcess(process *TunnelProcess) error {
-	if process.Process == nil {
-		return fmt.Errorf("process handle not available")
-	}
-
-	err := process.Process.Signal(syscall.SIGTERM)
-	if err != nil {
-		return fmt.Errorf("failed to send SIGTERM: %w", err)
-	}
-
-	done := make(chan error, 1)
-	go func() {
-		_, err := process.Process.Wait()
-		done <- err
-	}()
+	return tm.supervisorOf(process).Stop(process)
+}
 
-	select {
-	case <-time.After(10 * time.Second):
-		if err := process.Process.Kill(); err != nil {
-			return fmt.Errorf("failed to kill process: %w", err)
-		}
-		process.Status = StatusInactive
-		return nil
-	case err := <-done:
-		process.Status = StatusInactive
-		return err
+// supervisorOf returns the supervisor hosting process; processes started
+// outside a supervisor are plain child processes
+func (tm *TunnelManager) supervisorOf(process *TunnelProcess) Supervisor {
+	if process.supervisor != nil {
+		return process.supervisor
 	}
+	return &execSupervisor{tm: tm}
 }
 
 func (tm *TunnelManager) monitorProcess(tunnelName string, cmd *exec.Cmd) {
@@ -284,12 +266,10 @@ func (tm *TunnelManager) GetAllProcesses() map[string]*TunnelProcess {
 }
 
 func (tp *TunnelProcess) IsRunning() bool {
-	if tp.Process == nil {
-		return false
+	if tp.supervisor != nil {
+		return tp.supervisor.IsRunning(tp)
 	}
-
-	err := tp.Process.Signal(syscall.Signal(0))
-	return err == nil
+	return processAlive(tp.Process)
 }
 
 func (tp *TunnelProcess) GetUptime() time.Duration {
@@ -297,6 +277,9 @@ func (tp *TunnelProcess) GetUptime() time.Duration {
 }
 
 func (tp *TunnelProcess) Stop() error {
+	if tp.supervisor != nil {
+		return tp.supervisor.Stop(tp)
+	}
 	if tp.Process == nil {
 		return fmt.Errorf("process handle not available")
 	}
@@ -380,6 +363,20 @@ func (tm *TunnelManager) DeleteTunnelConfig(tunnelName string) error {
 	return nil
 }
 
+func (tm *TunnelManager) DeleteCredentialsFile(tunnelID string) error {
+	credentialsPath := filepath.Join(tm.configDir, fmt.Sprintf("%s.json", tunnelID))
+
+	if _, err := os.Stat(credentialsPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := os.Remove(credentialsPath); err != nil {
+		return fmt.Errorf("failed to delete credentials file: %w", err)
+	}
+
+	return nil
+}
+
 func (tm *TunnelManager) ListConfigFiles() ([]string, error) {
 	files, err := filepath.Glob(filepath.Join(tm.configDir, "*.yml"))
 	if err != nil {
@@ -502,8 +499,8 @@ func (tm *TunnelManager) RestartTunnel(tunnelName string) error {
 
 	delete(tm.processes, tunnelName)
 
-	ctx := context.Background()
-	_, err := tm.StartTunnel(ctx, tunnelName, config)
+	// StartTunnel would take tm.mutex again
+	_, err := tm.startTunnelLocked(context.Background(), tunnelName, config)
 	return err
 }
 
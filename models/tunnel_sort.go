@@ -0,0 +1,88 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tunnel list sort columns. TunnelSortNone keeps the order tunnels are
+// listed in.
+const (
+	TunnelSortNone    = ""
+	TunnelSortName    = "name"
+	TunnelSortStatus  = "status"
+	TunnelSortDomains = "domains"
+	TunnelSortCreated = "created"
+)
+
+// TunnelSortColumns is the order the sort key cycles through
+var TunnelSortColumns = []string{TunnelSortNone, TunnelSortName, TunnelSortStatus, TunnelSortDomains, TunnelSortCreated}
+
+// NextTunnelSortColumn returns the column after column in TunnelSortColumns
+func NextTunnelSortColumn(column string) string {
+	for i, candidate := range TunnelSortColumns {
+		if candidate == column {
+			return TunnelSortColumns[(i+1)%len(TunnelSortColumns)]
+		}
+	}
+	return TunnelSortNone
+}
+
+// statusRank orders statuses from healthiest to unknown
+var statusRank = map[TunnelStatus]int{
+	StatusActive:   0,
+	StatusDegraded: 1,
+	StatusInactive: 2,
+	StatusError:    3,
+}
+
+func tunnelStatusRank(status TunnelStatus, known bool) int {
+	if rank, ok := statusRank[status]; ok && known {
+		return rank
+	}
+	return len(statusRank)
+}
+
+// SortTunnels orders tunnels by column using the statuses and domain counts
+// loaded so far. Ties keep their current order, and tunnels whose status or
+// count isn't loaded yet go last either way.
+func SortTunnels(tunnels []CLITunnel, column string, descending bool, statuses map[string]TunnelStatus, domainCounts map[string]int) {
+	var less func(a, b CLITunnel) bool
+	var missing func(t CLITunnel) bool
+
+	switch column {
+	case TunnelSortName:
+		less = func(a, b CLITunnel) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case TunnelSortStatus:
+		less = func(a, b CLITunnel) bool {
+			aStatus, aKnown := statuses[a.ID]
+			bStatus, bKnown := statuses[b.ID]
+			return tunnelStatusRank(aStatus, aKnown) < tunnelStatusRank(bStatus, bKnown)
+		}
+		missing = func(t CLITunnel) bool {
+			status, known := statuses[t.ID]
+			return tunnelStatusRank(status, known) == len(statusRank)
+		}
+	case TunnelSortDomains:
+		less = func(a, b CLITunnel) bool { return domainCounts[a.ID] < domainCounts[b.ID] }
+		missing = func(t CLITunnel) bool {
+			_, known := domainCounts[t.ID]
+			return !known
+		}
+	case TunnelSortCreated:
+		less = func(a, b CLITunnel) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	default:
+		return
+	}
+
+	sort.SliceStable(tunnels, func(i, j int) bool {
+		a, b := tunnels[i], tunnels[j]
+		if missing != nil && missing(a) != missing(b) {
+			return missing(b)
+		}
+		if descending {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+}
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultUptimeFile = "uptime.json"
+	// UptimeRetention is how long status transitions are kept on disk
+	UptimeRetention = 30 * 24 * time.Hour
+)
+
+// StatusTransition records the moment a tunnel's observed status changed
+type StatusTransition struct {
+	TunnelID   string       `json:"tunnel_id"`
+	TunnelName string       `json:"tunnel_name"`
+	Status     TunnelStatus `json:"status"`
+	At         time.Time    `json:"at"`
+}
+
+// UptimeLog is an on-disk log of tunnel status transitions
+type UptimeLog struct {
+	Transitions []StatusTransition `json:"transitions"`
+	path        string
+	mutex       sync.Mutex
+}
+
+// UptimeReport summarizes a tunnel's availability over a time window
+type UptimeReport struct {
+	TunnelID      string
+	TunnelName    string
+	UptimePercent float64
+	Observed      time.Duration
+	Downtimes     int
+	LastDowntime  time.Time
+}
+
+func GetUptimeLogPath() string {
+	return filepath.Join(GetStateDir(), DefaultUptimeFile)
+}
+
+func LoadUptimeLog(path string) (*UptimeLog, error) {
+	if path == "" {
+		path = GetUptimeLogPath()
+	}
+
+	log := &UptimeLog{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return log, fmt.Errorf("failed to read uptime log: %w", err)
+	}
+
+	if err := json.Unmarshal(data, log); err != nil {
+		return log, fmt.Errorf("failed to unmarshal uptime log: %w", err)
+	}
+
+	return log, nil
+}
+
+func (l *UptimeLog) Save() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal uptime log: %w", err)
+	}
+
+	if err := os.WriteFile(l.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write uptime log: %w", err)
+	}
+
+	return nil
+}
+
+// Record appends a transition if status differs from the tunnel's last known
+// status. It returns true when the log changed and should be saved.
+func (l *UptimeLog) Record(tunnelID, tunnelName string, status TunnelStatus, at time.Time) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for i := len(l.Transitions) - 1; i >= 0; i-- {
+		if l.Transitions[i].TunnelID == tunnelID {
+			if l.Transitions[i].Status == status {
+				return false
+			}
+			break
+		}
+	}
+
+	l.Transitions = append(l.Transitions, StatusTransition{
+		TunnelID:   tunnelID,
+		TunnelName: tunnelName,
+		Status:     status,
+		At:         at,
+	})
+	l.prune(at.Add(-UptimeRetention))
+	return true
+}
+
+// prune drops transitions older than cutoff, keeping the most recent older
+// transition per tunnel so the status at the start of the window is known
+func (l *UptimeLog) prune(cutoff time.Time) {
+	latestBefore := make(map[string]int)
+	for i, t := range l.Transitions {
+		if t.At.Before(cutoff) {
+			latestBefore[t.TunnelID] = i
+		}
+	}
+
+	kept := l.Transitions[:0]
+	for i, t := range l.Transitions {
+		if !t.At.Before(cutoff) || latestBefore[t.TunnelID] == i {
+			kept = append(kept, t)
+		}
+	}
+	l.Transitions = kept
+}
+
+// Report computes uptime per tunnel over the window ending at now. Time spent
+// in the unknown state is excluded from the observed duration.
+func (l *UptimeLog) Report(window time.Duration, now time.Time) []UptimeReport {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	start := now.Add(-window)
+	byTunnel := make(map[string][]StatusTransition)
+	for _, t := range l.Transitions {
+		byTunnel[t.TunnelID] = append(byTunnel[t.TunnelID], t)
+	}
+
+	var reports []UptimeReport
+	for tunnelID, transitions := range byTunnel {
+		report := UptimeReport{
+			TunnelID:   tunnelID,
+			TunnelName: transitions[len(transitions)-1].TunnelName,
+		}
+
+		var up time.Duration
+		for i, t := range transitions {
+			periodStart := t.At
+			periodEnd := now
+			if i+1 < len(transitions) {
+				periodEnd = transitions[i+1].At
+			}
+			if periodEnd.Before(start) {
+				continue
+			}
+			if periodStart.Before(start) {
+				periodStart = start
+			}
+
+			if !t.Status.IsUp() && t.Status != StatusUnknown {
+				report.LastDowntime = t.At
+				if !t.At.Before(start) {
+					report.Downtimes++
+				}
+			}
+
+			if t.Status == StatusUnknown {
+				continue
+			}
+
+			duration := periodEnd.Sub(periodStart)
+			report.Observed += duration
+			if t.Status.IsUp() {
+				up += duration
+			}
+		}
+
+		if report.Observed > 0 {
+			report.UptimePercent = float64(up) / float64(report.Observed) * 100
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].TunnelName < reports[j].TunnelName
+	})
+
+	return reports
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"fmt"
+	"log"
+)
+
+// Warning is a non-fatal problem hit while an operation otherwise went ahead
+type Warning struct {
+	Operation string
+	Message   string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Operation, w.Message)
+}
+
+// WarningReporter receives warnings as they are raised. It may be called from
+// any goroutine and must not block for long.
+type WarningReporter func(Warning)
+
+// SetWarningReporter routes warnings raised by the client to reporter. Without a
+// reporter warnings go to the standard logger, which is only safe outside the TUI.
+func (c *CloudflareClient) SetWarningReporter(reporter WarningReporter) {
+	c.reportWarning = reporter
+}
+
+// warnf raises a warning for operation
+func (c *CloudflareClient) warnf(operation, format string, args ...interface{}) {
+	w := Warning{Operation: operation, Message: fmt.Sprintf(format, args...)}
+	if c.reportWarning == nil {
+		log.Printf("Warning: %s", w)
+		return
+	}
+	c.reportWarning(w)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// defaultDomainCacheTTL is how long the zone list is reused when
+// DomainCacheMinutes isn't set
+const defaultDomainCacheTTL = 10 * time.Minute
+
+// DomainCacheTTL returns how long the available domains are reused before
+// the hostname forms fetch them again
+func (c *Config) DomainCacheTTL() time.Duration {
+	if c == nil || c.DomainCacheMinutes <= 0 {
+		return defaultDomainCacheTTL
+	}
+	return time.Duration(c.DomainCacheMinutes) * time.Minute
+}
+
+// ZoneForHostname returns the ID and name of the zone a hostname belongs to,
+// preferring the longest matching zone name (e.g. dev.example.com over example.com)
+func (c *CloudflareClient) ZoneForHostname(ctx context.Context, hostname string) (string, string, error) {
+	zones, err := c.api.ListZones(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	zoneID, zoneName := matchZone(zones, hostname)
+	if zoneID == "" {
+		return "", "", fmt.Errorf("no zone found for hostname: %s", hostname)
+	}
+
+	return zoneID, zoneName, nil
+}
+
+// matchZone picks the longest zone name that hostname falls under
+func matchZone(zones []cloudflare.Zone, hostname string) (string, string) {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	var zoneID, zoneName string
+	for _, zone := range zones {
+		name := strings.ToLower(zone.Name)
+		if (hostname == name || strings.HasSuffix(hostname, "."+name)) && len(name) > len(zoneName) {
+			zoneID, zoneName = zone.ID, zone.Name
+		}
+	}
+	return zoneID, zoneName
+}
+
+// SplitHostname splits a hostname into the part below its zone and the zone
+// itself, using the longest matching name from zones. The zone is empty when
+// none of the zones match.
+func SplitHostname(hostname string, zones []string) (string, string) {
+	lower := strings.ToLower(strings.TrimSuffix(hostname, "."))
+	var zoneName string
+	for _, zone := range zones {
+		name := strings.ToLower(zone)
+		if (lower == name || strings.HasSuffix(lower, "."+name)) && len(name) > len(zoneName) {
+			zoneName = zone
+		}
+	}
+	if zoneName == "" {
+		return hostname, ""
+	}
+
+	sub := strings.TrimSuffix(lower[:len(lower)-len(zoneName)], ".")
+	return sub, zoneName
+}
@@ -0,0 +1,76 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openApplySnippet renders the selected tunnel's hostnames as apply YAML
+func (m *Model) openApplySnippet() {
+	snippet, err := models.ApplySnippet(m.selectedTunnelName, m.tunnelHostnames)
+	if err != nil {
+		m.errorMessage = err.Error()
+		return
+	}
+	m.applySnippet = snippet
+	m.statusMessage = fmt.Sprintf("Apply YAML for %s (w: Write to file)", m.selectedTunnelName)
+}
+
+// writeApplySnippet saves the snippet being shown to the working directory
+func (m *Model) writeApplySnippet() {
+	name := strings.ReplaceAll(m.selectedTunnelName, "/", "-")
+	path := fmt.Sprintf("tunnelman-apply-%s.yml", name)
+	if err := os.WriteFile(path, []byte(m.applySnippet), 0644); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to write %s: %v", path, err)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Wrote apply YAML to %s", path)
+}
+
+func (m Model) handleApplySnippetInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "Y":
+		m.applySnippet = ""
+		m.statusMessage = "Closed apply YAML"
+
+	case "w":
+		m.writeApplySnippet()
+	}
+
+	return m, nil
+}
+
+func (m Model) renderApplySnippet() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	codeStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("📋 Apply YAML for %s", m.selectedTunnelName))}
+	for _, line := range strings.Split(strings.TrimRight(m.applySnippet, "\n"), "\n") {
+		lines = append(lines, codeStyle.Render(line))
+	}
+	lines = append(lines, "", hintStyle.Render("w: Write to tunnelman-apply-<tunnel>.yml • Escape: Close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
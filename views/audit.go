@@ -0,0 +1,164 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type auditLoadedMsg struct {
+	report *models.AuditReport
+}
+
+// openAudit runs the security review over every loaded tunnel
+func (m *Model) openAudit() tea.Cmd {
+	m.showAudit = true
+	m.auditReport = nil
+	m.selectedAuditRow = 0
+	m.statusMessage = "Reviewing exposed services..."
+
+	client := m.client
+	tunnels := m.tunnelsList
+	return tea.Cmd(func() tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		return auditLoadedMsg{report: client.Audit(context.Background(), tunnels)}
+	})
+}
+
+func (m *Model) handleAuditLoaded(report *models.AuditReport) {
+	m.auditReport = report
+	if !m.showAudit {
+		return
+	}
+	if len(report.Findings) == 0 {
+		m.statusMessage = fmt.Sprintf("No risky exposures found across %d hostnames", report.Hostnames)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Found %d issues (%d high) across %d hostnames", len(report.Findings), report.HighCount(), report.Hostnames)
+}
+
+func (m Model) handleAuditInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var findings []models.AuditFinding
+	if m.auditReport != nil {
+		findings = m.auditReport.Findings
+	}
+
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "V":
+		m.showAudit = false
+		m.statusMessage = "Closed security review"
+
+	case "up", "k":
+		if m.selectedAuditRow > 0 {
+			m.selectedAuditRow--
+		}
+
+	case "down", "j":
+		if m.selectedAuditRow < len(findings)-1 {
+			m.selectedAuditRow++
+		}
+
+	case "r":
+		return m, m.openAudit()
+
+	case "enter":
+		if m.selectedAuditRow >= len(findings) {
+			return m, nil
+		}
+		finding := findings[m.selectedAuditRow]
+		if finding.TunnelID == "" {
+			m.statusMessage = "DNS record findings aren't tied to a tunnel - fix them in the DNS tab or dashboard"
+			return m, nil
+		}
+		m.showAudit = false
+		hostname := models.TunnelHostname{TunnelID: finding.TunnelID, TunnelName: finding.TunnelName}
+		hostname.Hostname = strings.SplitN(finding.Hostname, "/", 2)[0]
+		return m, m.jumpToHostname(hostname)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderAudit() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading)
+
+	severityStyles := map[models.AuditSeverity]lipgloss.Style{
+		models.AuditHigh:   lipgloss.NewStyle().Foreground(colors.errorColor).Bold(true),
+		models.AuditMedium: lipgloss.NewStyle().Foreground(colors.warning),
+		models.AuditLow:    lipgloss.NewStyle().Foreground(colors.text),
+	}
+
+	cursorStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render("🛡️  Security Review")}
+
+	if m.auditReport == nil {
+		lines = append(lines, hintStyle.Render("Checking hostnames, Access applications and DNS records..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	report := m.auditReport
+	if len(report.Findings) == 0 {
+		lines = append(lines, severityStyles[models.AuditLow].Render(fmt.Sprintf("✓ No risky exposures found across %d hostnames", report.Hostnames)))
+	} else {
+		format := "%-8s %-40s %-20s %s"
+		lines = append(lines, headerStyle.Render(fmt.Sprintf(format, "SEVERITY", "HOSTNAME", "TUNNEL", "ISSUE")))
+		for i, finding := range report.Findings {
+			hostname := finding.Hostname
+			if len(hostname) > 40 {
+				hostname = hostname[:37] + "..."
+			}
+			tunnel := finding.TunnelName
+			if tunnel == "" {
+				tunnel = "-"
+			} else if len(tunnel) > 20 {
+				tunnel = tunnel[:17] + "..."
+			}
+
+			line := fmt.Sprintf(format, finding.Severity, hostname, tunnel, finding.Message)
+			if i == m.selectedAuditRow {
+				lines = append(lines, cursorStyle.Render(line))
+			} else {
+				lines = append(lines, severityStyles[finding.Severity].Render(line))
+			}
+		}
+	}
+
+	for _, note := range report.Notes {
+		lines = append(lines, hintStyle.Render("⚠ "+note))
+	}
+
+	lines = append(lines, "", hintStyle.Render(strings.Join([]string{
+		"↑↓: Navigate", "Enter: Open hostname", m.keys.Bound("r") + ": Re-run", "Escape: Close",
+	}, " • ")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
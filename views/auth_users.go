@@ -0,0 +1,235 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openAuthUsers shows the basic auth logins of the selected hostname
+func (m *Model) openAuthUsers() {
+	hostname := m.tunnelHostnames[m.selectedHostnameIndex]
+	m.authUsersHostname = hostname.Hostname
+	m.authUsersIndex = 0
+	m.authUserAdding = false
+	m.authUserReveal = ""
+	m.statusMessage = fmt.Sprintf("Auth users of %s", hostname.Hostname)
+}
+
+// authUsersTarget returns the hostname whose logins are being managed
+func (m *Model) authUsersTarget() *models.PublicHostname {
+	for i := range m.tunnelHostnames {
+		if m.tunnelHostnames[i].Hostname == m.authUsersHostname {
+			return &m.tunnelHostnames[i]
+		}
+	}
+	return nil
+}
+
+// saveAuthUsers records the hostname's new logins and, while its auth proxy
+// runs, rewrites the proxy's config so they take effect right away
+func (m *Model) saveAuthUsers(users []models.AuthUser, done string) tea.Cmd {
+	hostname := m.authUsersTarget()
+	if hostname == nil {
+		m.errorMessage = fmt.Sprintf("%s is no longer in the hostname list", m.authUsersHostname)
+		return nil
+	}
+	m.state.SetAuthUsers(hostname.Hostname, users)
+	m.state.Save()
+	if m.authUsersIndex >= len(users) && m.authUsersIndex > 0 {
+		m.authUsersIndex = len(users) - 1
+	}
+
+	if !hostname.AuthEnabled {
+		m.statusMessage = done + " - used when auth is enabled"
+		return nil
+	}
+	m.statusMessage = done + " - updating the auth proxy..."
+	client := m.client
+	name := hostname.Hostname
+	originalService := hostname.OriginalService
+	return func() tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		if err := client.SetHostnameAuthUsers(name, originalService, users); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to update auth users: %v", err))
+		}
+		return statusMsg(fmt.Sprintf("%s - the auth proxy of %s has %d login(s)", done, name, len(users)))
+	}
+}
+
+// setDefaultLoginPassword keeps the password shown under the hostname list in
+// step with the default login
+func (m *Model) setDefaultLoginPassword(password string) {
+	if hostname := m.authUsersTarget(); hostname != nil {
+		hostname.AuthPassword = password
+	}
+}
+
+func (m Model) handleAuthUsersInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.authUserAdding {
+		return m.handleAuthUserAddInput(msg)
+	}
+
+	users := append([]models.AuthUser(nil), m.state.AuthUsersFor(m.authUsersHostname)...)
+
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "u":
+		m.authUsersHostname = ""
+		m.authUserReveal = ""
+		m.statusMessage = "Closed auth users"
+
+	case "up", "k":
+		if m.authUsersIndex > 0 {
+			m.authUsersIndex--
+		}
+
+	case "down", "j":
+		if m.authUsersIndex < len(users)-1 {
+			m.authUsersIndex++
+		}
+
+	case "a":
+		m.authUserAdding = true
+		m.authUserReveal = ""
+		m.authUserInput = textinput.New()
+		m.authUserInput.Placeholder = "username"
+		m.authUserInput.CharLimit = 64
+		m.authUserInput.Width = 30
+		m.authUserInput.Focus()
+
+	case "r":
+		if m.authUsersIndex >= len(users) {
+			break
+		}
+		name := users[m.authUsersIndex].Name
+		user, password, err := models.NewAuthUser(name)
+		if err != nil {
+			m.errorMessage = err.Error()
+			break
+		}
+		users[m.authUsersIndex] = user
+		m.authUserReveal = fmt.Sprintf("%s:%s", name, password)
+		if name == models.AuthUsername {
+			m.setDefaultLoginPassword(password)
+		}
+		return m, m.saveAuthUsers(users, fmt.Sprintf("New password for %s", name))
+
+	case "d", "x":
+		if m.authUsersIndex >= len(users) {
+			break
+		}
+		if hostname := m.authUsersTarget(); len(users) == 1 && hostname != nil && hostname.AuthEnabled {
+			m.statusMessage = "Auth needs at least one user - disable it with 'A' instead"
+			break
+		}
+		name := users[m.authUsersIndex].Name
+		m.authUserReveal = ""
+		if name == models.AuthUsername {
+			m.setDefaultLoginPassword("")
+		}
+		return m, m.saveAuthUsers(append(users[:m.authUsersIndex], users[m.authUsersIndex+1:]...), fmt.Sprintf("Removed %s", name))
+	}
+
+	return m, nil
+}
+
+func (m Model) handleAuthUserAddInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.authUserAdding = false
+		m.statusMessage = "No user added"
+		return m, nil
+
+	case "enter":
+		name := strings.TrimSpace(m.authUserInput.Value())
+		users := m.state.AuthUsersFor(m.authUsersHostname)
+		if err := models.ValidateAuthUsername(name, users); err != nil {
+			m.statusMessage = err.Error()
+			return m, nil
+		}
+		user, password, err := models.NewAuthUser(name)
+		if err != nil {
+			m.errorMessage = err.Error()
+			return m, nil
+		}
+		m.authUserAdding = false
+		m.authUserReveal = fmt.Sprintf("%s:%s", name, password)
+		users = append(append([]models.AuthUser(nil), users...), user)
+		m.authUsersIndex = len(users) - 1
+		return m, m.saveAuthUsers(users, fmt.Sprintf("Added %s", name))
+	}
+
+	var cmd tea.Cmd
+	m.authUserInput, cmd = m.authUserInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderAuthUsers() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(colors.onColor).
+		Background(colors.accent).
+		Bold(true)
+
+	revealStyle := lipgloss.NewStyle().
+		Foreground(colors.warning).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("👥 Auth users of %s", m.authUsersHostname))}
+
+	users := m.state.AuthUsersFor(m.authUsersHostname)
+	if len(users) == 0 {
+		if hostname := m.authUsersTarget(); hostname != nil && hostname.AuthEnabled {
+			lines = append(lines, hintStyle.Render("No logins recorded - the auth proxy was started before users were tracked. Adding one replaces its login."))
+		} else {
+			lines = append(lines, hintStyle.Render("No users yet. Enabling auth with 'A' creates the tunnelman login."))
+		}
+	}
+	for i, user := range users {
+		if i == m.authUsersIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+user.Name))
+		} else {
+			lines = append(lines, rowStyle.Render("  "+user.Name))
+		}
+	}
+
+	if m.authUserReveal != "" {
+		lines = append(lines, "", revealStyle.Render("🔑 "+m.authUserReveal), hintStyle.Render("Note the password now - only its hash is kept"))
+	}
+
+	if m.authUserAdding {
+		lines = append(lines, "", rowStyle.Render("New username:"), m.authUserInput.View(), "", hintStyle.Render("Enter: Add with a generated password • Escape: Cancel"))
+	} else {
+		lines = append(lines, "", hintStyle.Render(fmt.Sprintf("%s: Add user • %s: Regenerate password • %s: Remove user • Escape: Close", m.keys.Bound("a"), m.keys.Bound("r"), m.keys.Bound("d"))))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,109 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inFlightOps tracks the creates and deletes currently running so Escape can
+// cancel them. Operations register themselves from their command goroutine,
+// so a command held back by a confirmation prompt or a task queue only
+// becomes cancellable once it actually starts.
+type inFlightOps struct {
+	mutex sync.Mutex
+	ops   map[*inFlightOp]bool
+}
+
+type inFlightOp struct {
+	label  string
+	cancel context.CancelFunc
+}
+
+func newInFlightOps() *inFlightOps {
+	return &inFlightOps{ops: make(map[*inFlightOp]bool)}
+}
+
+func (f *inFlightOps) add(op *inFlightOp) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.ops[op] = true
+}
+
+func (f *inFlightOps) remove(op *inFlightOp) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.ops, op)
+}
+
+// labels returns the running operations' labels, sorted
+func (f *inFlightOps) labels() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var labels []string
+	for op := range f.ops {
+		labels = append(labels, op.label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// cancelAll cancels every running operation and returns their labels
+func (f *inFlightOps) cancelAll() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var labels []string
+	for op := range f.ops {
+		op.cancel()
+		labels = append(labels, op.label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// cancellable returns a command running fn with a context Escape cancels.
+// If the cancellation makes fn fail, the cancellation is reported instead of
+// the error; an operation that finished anyway reports its result as usual.
+func (m Model) cancellable(label string, fn func(ctx context.Context) tea.Msg) tea.Cmd {
+	inFlight := m.inFlight
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		op := &inFlightOp{label: label, cancel: cancel}
+		inFlight.add(op)
+		defer inFlight.remove(op)
+
+		result := fn(ctx)
+		if _, failed := result.(errorMsg); failed && ctx.Err() != nil {
+			return statusMsg(fmt.Sprintf("Cancelled %s - refresh to check whether part of it was applied", label))
+		}
+		return result
+	}
+}
+
+// cancelInFlight cancels the running creates and deletes, reporting whether
+// there were any
+func (m *Model) cancelInFlight() bool {
+	labels := m.inFlight.cancelAll()
+	if len(labels) == 0 {
+		return false
+	}
+	m.statusMessage = fmt.Sprintf("Cancelling %s...", strings.Join(labels, ", "))
+	return true
+}
+
+// inFlightHint is the footer hint shown while operations can be cancelled
+func (m Model) inFlightHint() string {
+	labels := m.inFlight.labels()
+	switch len(labels) {
+	case 0:
+		return ""
+	case 1:
+		return "Esc: Cancel " + labels[0]
+	}
+	return fmt.Sprintf("Esc: Cancel %d operations", len(labels))
+}
@@ -0,0 +1,244 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tunnelDeleteWorkers bounds how many cloudflared delete commands run at once
+const tunnelDeleteWorkers = 4
+
+// tunnelDeletedMsg reports the outcome of deleting one tunnel of a batch
+type tunnelDeletedMsg struct {
+	name string
+	err  error
+}
+
+func (m *Model) openCleanup() {
+	m.showCleanup = true
+	m.cleanupConfirm = false
+	m.cleanupCursor = 0
+	m.cleanupSelected = make(map[string]bool)
+	m.cleanupResults = nil
+	m.cleanupDeleting = 0
+	m.staleTunnels = models.FindStaleTunnels(m.tunnelsList, m.tunnelDomainCounts, m.config.StaleAfter(), time.Now())
+	m.statusMessage = fmt.Sprintf("Found %d stale tunnels", len(m.staleTunnels))
+}
+
+// deleteTunnels deletes the tunnels concurrently, at most tunnelDeleteWorkers
+// at a time, reporting each one as it finishes so the UI stays responsive
+func (m *Model) deleteTunnels(tunnels []models.CLITunnel) tea.Cmd {
+	if m.client == nil {
+		return func() tea.Msg {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+	}
+
+	m.cleanupResults = make(map[string]error)
+	m.cleanupDeleting = len(tunnels)
+
+	client := m.client
+	slots := make(chan struct{}, tunnelDeleteWorkers)
+	cmds := make([]tea.Cmd, len(tunnels))
+	for i, tunnel := range tunnels {
+		name := tunnel.Name
+		cmds[i] = m.cancellable("delete "+name, func(ctx context.Context) tea.Msg {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if err := ctx.Err(); err != nil {
+				return tunnelDeletedMsg{name: name, err: err}
+			}
+			return tunnelDeletedMsg{name: name, err: client.DeleteTunnel(ctx, name)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleTunnelDeleted records one result of a batch delete and reports
+// whether the whole batch has finished
+func (m *Model) handleTunnelDeleted(msg tunnelDeletedMsg) bool {
+	if m.cleanupResults == nil {
+		return false
+	}
+	m.cleanupResults[msg.name] = msg.err
+	if len(m.cleanupResults) < m.cleanupDeleting {
+		m.statusMessage = fmt.Sprintf("Deleting stale tunnels... %d/%d done", len(m.cleanupResults), m.cleanupDeleting)
+		return false
+	}
+
+	var deleted int
+	var failures []string
+	for name, err := range m.cleanupResults {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%v)", name, err))
+			continue
+		}
+		deleted++
+	}
+	sort.Strings(failures)
+
+	m.statusMessage = fmt.Sprintf("Deleted %d tunnels", deleted)
+	if len(failures) > 0 {
+		m.errorMessage = fmt.Sprintf("Failed to delete %d tunnels: %s", len(failures), strings.Join(failures, "; "))
+	}
+	m.cleanupResults = nil
+	m.cleanupDeleting = 0
+	m.showCleanup = false
+	return true
+}
+
+func (m Model) selectedStaleTunnels() []models.CLITunnel {
+	var selected []models.CLITunnel
+	for _, tunnel := range m.staleTunnels {
+		if m.cleanupSelected[tunnel.ID] {
+			selected = append(selected, tunnel)
+		}
+	}
+	return selected
+}
+
+func (m Model) handleCleanupInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := m.keys.Translate(msg.String())
+	if key != "d" {
+		m.cleanupConfirm = false
+	}
+
+	if m.cleanupResults != nil {
+		// Selection is frozen while the batch runs; results land in this screen
+		if key == "ctrl+c" || key == "q" {
+			return m, m.quit()
+		}
+		return m, nil
+	}
+
+	switch key {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "X":
+		m.showCleanup = false
+		m.statusMessage = "Returned to tunnel list"
+
+	case "up", "k":
+		if m.cleanupCursor > 0 {
+			m.cleanupCursor--
+		}
+
+	case "down", "j":
+		if m.cleanupCursor < len(m.staleTunnels)-1 {
+			m.cleanupCursor++
+		}
+
+	case " ":
+		if m.cleanupCursor < len(m.staleTunnels) {
+			id := m.staleTunnels[m.cleanupCursor].ID
+			m.cleanupSelected[id] = !m.cleanupSelected[id]
+		}
+
+	case "a":
+		allSelected := len(m.selectedStaleTunnels()) == len(m.staleTunnels)
+		for _, tunnel := range m.staleTunnels {
+			m.cleanupSelected[tunnel.ID] = !allSelected
+		}
+
+	case "d":
+		selected := m.selectedStaleTunnels()
+		if len(selected) == 0 {
+			m.statusMessage = "No tunnels selected - press Space to select"
+		} else if !m.cleanupConfirm {
+			m.cleanupConfirm = true
+			m.statusMessage = fmt.Sprintf("Delete %d tunnels? Press '%s' to confirm, 'esc' to cancel", len(selected), m.keys.Bound("d"))
+		} else {
+			m.cleanupConfirm = false
+			m.statusMessage = fmt.Sprintf("Deleting %d stale tunnels... 0/%d done", len(selected), len(selected))
+			return m, m.deleteTunnels(selected)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) renderCleanup() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.accent).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	cursorStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	deletedStyle := lipgloss.NewStyle().
+		Foreground(colors.success)
+
+	failedStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	days := int(m.config.StaleAfter().Hours() / 24)
+	rows := []string{
+		titleStyle.Render(fmt.Sprintf("🧹 Stale Tunnels (no hostnames, no connections, idle %d+ days)", days)),
+	}
+
+	if len(m.staleTunnels) == 0 {
+		rows = append(rows, hintStyle.Render("No stale tunnels found"))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	rows = append(rows, rowStyle.Render(fmt.Sprintf("    %-30s %-20s %s", "NAME", "LAST ACTIVITY", "ID")))
+	for i, tunnel := range m.staleTunnels {
+		check := "[ ]"
+		if m.cleanupSelected[tunnel.ID] {
+			check = "[x]"
+		}
+
+		row := fmt.Sprintf("%s %-30s %-20s %s", check, tunnel.Name, tunnel.LastActivity().Format("2006-01-02"), tunnel.ID)
+		if m.cleanupResults != nil && m.cleanupSelected[tunnel.ID] {
+			err, done := m.cleanupResults[tunnel.Name]
+			switch {
+			case !done:
+				rows = append(rows, hintStyle.Render(row+"  deleting..."))
+			case err != nil:
+				rows = append(rows, failedStyle.Render(row+"  ✗ "+err.Error()))
+			default:
+				rows = append(rows, deletedStyle.Render(row+"  ✓ deleted"))
+			}
+			continue
+		}
+		if i == m.cleanupCursor {
+			rows = append(rows, cursorStyle.Render(row))
+		} else {
+			rows = append(rows, rowStyle.Render(row))
+		}
+	}
+
+	if m.cleanupResults != nil {
+		rows = append(rows, "", hintStyle.Render(fmt.Sprintf("Deleting %d/%d...", len(m.cleanupResults), m.cleanupDeleting)))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	rows = append(rows, "", hintStyle.Render(strings.Join([]string{
+		"Space: Select", m.keys.Bound("a") + ": Select all", m.keys.Bound("d") + ": Delete selected", "Escape: Back",
+	}, " • ")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,49 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyToClipboard copies text to the system clipboard, falling back to an
+// OSC52 sequence for SSH sessions and machines without clipboard tools
+func copyToClipboard(what, text string) tea.Cmd {
+	return func() tea.Msg {
+		err := models.CopyToClipboard(text)
+		if err == nil {
+			return statusMsg(fmt.Sprintf("Copied %s: %s", what, text))
+		}
+		if !errors.Is(err, models.ErrNoClipboard) {
+			return errorMsg(fmt.Sprintf("Failed to copy %s: %v", what, err))
+		}
+		if _, err := os.Stdout.WriteString(models.OSC52(text)); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to copy %s: %v", what, err))
+		}
+		return statusMsg(fmt.Sprintf("Copied %s through the terminal (OSC52): %s", what, text))
+	}
+}
+
+// copySelection copies the selected hostname's URL, or the selected
+// tunnel's ID, or its name when byName is set
+func (m Model) copySelection(byName bool) tea.Cmd {
+	if m.showTunnelHostnames {
+		if m.selectedHostnameIndex >= len(m.tunnelHostnames) {
+			return nil
+		}
+		return copyToClipboard("URL", "https://"+m.tunnelHostnames[m.selectedHostnameIndex].Hostname)
+	}
+
+	if m.activeTab != 0 || m.selectedTunnel >= len(m.tunnelsList) {
+		return nil
+	}
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	if byName {
+		return copyToClipboard("tunnel name", tunnel.Name)
+	}
+	return copyToClipboard("tunnel ID", tunnel.ID)
+}
@@ -0,0 +1,188 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// confirmDialog is a modal yes/no question in front of a destructive action.
+// The action gets the model as it is when the user answers, so it acts on
+// the current selection.
+type confirmDialog struct {
+	title     string
+	details   []string
+	cancelled string
+	action    func(m *Model) tea.Cmd
+}
+
+// confirm asks dialog's question and runs its action on y. With
+// skip_delete_confirm set the action runs right away.
+func (m *Model) confirm(dialog confirmDialog) tea.Cmd {
+	if m.config != nil && m.config.SkipDeleteConfirm {
+		return dialog.action(m)
+	}
+	m.confirmDialog = &dialog
+	m.statusMessage = dialog.title + " (y/n)"
+	return nil
+}
+
+// confirmDeleteTunnel asks before deleting the selected tunnel
+func (m *Model) confirmDeleteTunnel() tea.Cmd {
+	if m.selectedTunnel >= len(m.tunnelsList) {
+		return nil
+	}
+	tunnel := m.tunnelsList[m.selectedTunnel]
+
+	details := []string{fmt.Sprintf("ID: %s", tunnel.ID)}
+	if count := m.tunnelDomainCounts[tunnel.ID]; count > 0 {
+		details = append(details, fmt.Sprintf("Its %d public hostname(s) stop working; their DNS records are left in place.", count))
+	}
+	if m.runningLocally(tunnel.Name) {
+		details = append(details, "Its connector is running on this machine.")
+	}
+	details = append(details, "This can't be undone.")
+
+	return m.confirm(confirmDialog{
+		title:     fmt.Sprintf("Delete tunnel %s?", tunnel.Name),
+		details:   details,
+		cancelled: fmt.Sprintf("Deletion cancelled: %s was kept", tunnel.Name),
+		action: func(m *Model) tea.Cmd {
+			m.statusMessage = fmt.Sprintf("Deleting tunnel: %s", tunnel.Name)
+			return m.deleteTunnel()
+		},
+	})
+}
+
+// confirmDeleteHostname asks before deleting the selected public hostname
+// and its DNS record. Protected hostnames still have to be typed afterwards.
+func (m *Model) confirmDeleteHostname() tea.Cmd {
+	if m.selectedHostnameIndex >= len(m.tunnelHostnames) {
+		return nil
+	}
+	m.selectedHostname = m.tunnelHostnames[m.selectedHostnameIndex]
+	hostname := m.selectedHostname
+
+	details := []string{fmt.Sprintf("Service: %s", hostname.Service)}
+	if hostname.Path != "" && hostname.Path != "*" {
+		details = append(details, fmt.Sprintf("Path: %s", hostname.Path))
+	}
+	details = append(details, fmt.Sprintf("The route is removed from tunnel %s along with the hostname's DNS record.", m.selectedTunnelName))
+
+	return m.confirm(confirmDialog{
+		title:     fmt.Sprintf("Delete hostname %s?", hostname.Hostname),
+		details:   details,
+		cancelled: fmt.Sprintf("Deletion cancelled: %s was kept", hostname.Hostname),
+		action: func(m *Model) tea.Cmd {
+			label := fmt.Sprintf("delete %s", hostname.Hostname)
+			m.statusMessage = fmt.Sprintf("Deleting public hostname: %s", hostname.Hostname)
+			return m.guardHostname(hostname.Hostname, "delete", queueTask(m.selectedTunnelID, label, m.deleteTunnelHostnameWithDNS()))
+		},
+	})
+}
+
+// confirmWarpRouting asks before flipping WARP routing on the open tunnel.
+// The tunnel and the new state are fixed when asking, so the answer applies
+// to what the user saw.
+func (m *Model) confirmWarpRouting() {
+	tunnelID, tunnelName := m.selectedTunnelID, m.selectedTunnelName
+	enable := !m.warpRoutingEnabled
+	action, details := "Enable", "WARP clients in your organization can reach the tunnel's private network routes."
+	if !enable {
+		action, details = "Disable", "WARP clients lose access to the tunnel's private network routes."
+	}
+
+	dialog := confirmDialog{
+		title:     fmt.Sprintf("%s WARP routing for tunnel %s?", action, tunnelName),
+		details:   []string{details},
+		cancelled: "WARP routing change cancelled",
+		action: func(m *Model) tea.Cmd {
+			m.statusMessage = "Updating WARP routing..."
+			return queueTask(tunnelID, "update WARP routing", m.toggleWarpRouting(tunnelID, enable))
+		},
+	}
+	m.confirmDialog = &dialog
+	m.statusMessage = dialog.title + " (y/n)"
+}
+
+// confirmSSHConfig shows the SSH config entry for hostname and asks before
+// appending it
+func (m *Model) confirmSSHConfig(hostname string) {
+	path := models.GetSSHConfigPath()
+	details := strings.Split(strings.TrimSuffix(models.SSHConfigStanza(hostname), "\n"), "\n")
+
+	dialog := confirmDialog{
+		title:     fmt.Sprintf("Append this entry to %s?", path),
+		details:   details,
+		cancelled: "SSH config unchanged",
+		action: func(m *Model) tea.Cmd {
+			added, err := models.AppendSSHConfig("", hostname)
+			if err != nil {
+				m.errorMessage = err.Error()
+			} else if added {
+				m.statusMessage = fmt.Sprintf("Added %s to %s - connect with: ssh %s", hostname, path, hostname)
+			} else {
+				m.statusMessage = fmt.Sprintf("%s already has an entry in %s", hostname, path)
+			}
+			return nil
+		},
+	}
+	m.confirmDialog = &dialog
+	m.statusMessage = dialog.title + " (y/n)"
+}
+
+func (m Model) handleConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "y", "Y":
+		dialog := m.confirmDialog
+		m.confirmDialog = nil
+		return m, dialog.action(&m)
+
+	default:
+		// Anything but y cancels, so a stray key never confirms later
+		m.statusMessage = m.confirmDialog.cancelled
+		m.confirmDialog = nil
+	}
+
+	return m, nil
+}
+
+func (m Model) renderConfirm() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.errorColor).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.errorColor).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colors.errorColor).
+		Padding(1, 3)
+
+	lines := []string{titleStyle.Render("⚠️  " + m.confirmDialog.title)}
+	for _, detail := range m.confirmDialog.details {
+		lines = append(lines, rowStyle.Render(detail))
+	}
+	lines = append(lines, "", hintStyle.Render("y: Yes • any other key: No"))
+
+	box := boxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	// Center the dialog in the content area, inside its border and padding
+	return lipgloss.Place(m.width-12, m.height-14, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,89 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// connectorEventRows is how many connector events the detail view lists
+const connectorEventRows = 8
+
+type connectorsLoadedMsg struct {
+	tunnelID   string
+	connectors []models.TunnelConnector
+	err        error
+	at         time.Time
+}
+
+// loadConnectors fetches the tunnel's registered connectors so changes show
+// up as events in the detail view
+func (m Model) loadConnectors(tunnelID string) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		if client == nil {
+			return nil
+		}
+		connectors, err := client.GetTunnelConnectors(context.Background(), tunnelID)
+		return connectorsLoadedMsg{tunnelID: tunnelID, connectors: connectors, err: err, at: time.Now()}
+	}
+}
+
+func (m *Model) handleConnectorsLoaded(msg connectorsLoadedMsg) {
+	log, exists := m.connectorEvents[msg.tunnelID]
+	if !exists {
+		log = models.NewConnectorEventLog()
+		m.connectorEvents[msg.tunnelID] = log
+	}
+	if msg.err != nil {
+		log.ObserveError(msg.err, msg.at)
+		return
+	}
+	log.Observe(msg.connectors, msg.at)
+}
+
+// renderConnectorEvents lists the open tunnel's recent connector events,
+// with flapping summarized over the last hour
+func (m Model) renderConnectorEvents() string {
+	log, exists := m.connectorEvents[m.selectedTunnelID]
+	if !exists || len(log.Events) == 0 {
+		return ""
+	}
+
+	sectionStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading).
+		MarginTop(2)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	problemStyle := lipgloss.NewStyle().
+		Foreground(colors.warning)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	title := "📡 Connector Events"
+	if problems := log.Problems(time.Now().Add(-time.Hour)); problems > 0 {
+		title += fmt.Sprintf(" - %d disconnect(s) or error(s) in the last hour", problems)
+	}
+	rows := []string{sectionStyle.Render(title)}
+
+	for _, event := range log.Recent(connectorEventRows) {
+		if event.IsProblem() {
+			rows = append(rows, problemStyle.Render(event.String()))
+		} else {
+			rows = append(rows, rowStyle.Render(event.String()))
+		}
+	}
+	rows = append(rows, hintStyle.Render("Disconnects are seen while this view is refreshed; 'l' on the tunnel list streams the connectors' logs"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
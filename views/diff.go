@@ -0,0 +1,79 @@
+package views
+
+import (
+	"fmt"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// configDiffMsg asks the user to approve a pending configuration write. The
+// command that issued the write blocks until a value is sent on reply.
+type configDiffMsg struct {
+	tunnelID string
+	changes  []models.IngressChange
+	reply    chan bool
+}
+
+// ConfigDiffConfirmer returns a confirmer that shows each ingress diff in the
+// running program and waits for the user to accept or reject it
+func ConfigDiffConfirmer(p *tea.Program) models.ConfigUpdateConfirmer {
+	return func(tunnelID string, changes []models.IngressChange) bool {
+		reply := make(chan bool, 1)
+		p.Send(configDiffMsg{tunnelID: tunnelID, changes: changes, reply: reply})
+		return <-reply
+	}
+}
+
+func (m Model) handleConfigDiffInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.configDiff.reply <- true
+		m.configDiff = nil
+		m.statusMessage = "Applying configuration changes..."
+
+	case "n", "esc", "escape":
+		m.configDiff.reply <- false
+		m.configDiff = nil
+		m.statusMessage = "Configuration change cancelled"
+
+	case "ctrl+c":
+		m.configDiff.reply <- false
+		return m, m.quit()
+	}
+
+	return m, nil
+}
+
+func (m Model) renderConfigDiff() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.warning).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.warning).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	kindColors := map[models.IngressChangeKind]lipgloss.TerminalColor{
+		models.IngressAdded:   colors.success,
+		models.IngressRemoved: colors.errorColor,
+		models.IngressChanged: colors.warning,
+	}
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("📝 Review changes to tunnel %s", m.tunnelName(m.configDiff.tunnelID))),
+	}
+	for _, change := range m.configDiff.changes {
+		lines = append(lines, lipgloss.NewStyle().Foreground(kindColors[change.Kind]).Render(change.String()))
+	}
+	lines = append(lines, "", hintStyle.Render("y/Enter: Apply • n/Escape: Cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
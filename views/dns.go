@@ -0,0 +1,343 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dnsCountWorkers bounds how many zones are counted at once so large
+// accounts don't trip the API rate limit
+const dnsCountWorkers = 4
+
+type dnsZonesLoadedMsg struct {
+	zones []models.DNSZone
+	err   error
+}
+type dnsZoneCountsMsg map[string]int
+type dnsZoneRecordsLoadedMsg struct {
+	zoneID  string
+	records []models.DNSRecord
+	err     error
+}
+
+// dnsRow is one line of the DNS tab: a zone header, or one of its records
+// when the zone is expanded
+type dnsRow struct {
+	zone   models.DNSZone
+	record *models.DNSRecord
+}
+
+func (m Model) dnsRows() []dnsRow {
+	var rows []dnsRow
+	for _, zone := range m.dnsZones {
+		rows = append(rows, dnsRow{zone: zone})
+		if !m.dnsExpanded[zone.ID] {
+			continue
+		}
+		records := m.dnsRecords[zone.ID]
+		for i := range records {
+			rows = append(rows, dnsRow{zone: zone, record: &records[i]})
+		}
+	}
+	return rows
+}
+
+// openDNSTab switches to the DNS tab, listing zones the first time it is shown
+func (m *Model) openDNSTab() tea.Cmd {
+	m.activeTab = dnsTab
+	m.statusMessage = "Showing DNS zones"
+	if m.dnsZones == nil && !m.dnsZonesLoading {
+		return m.loadDNSZones()
+	}
+	return nil
+}
+
+func (m *Model) loadDNSZones() tea.Cmd {
+	m.dnsZonesLoading = true
+	m.dnsRecords = make(map[string][]models.DNSRecord)
+	m.dnsExpanded = make(map[string]bool)
+	m.dnsLoadingZones = make(map[string]bool)
+	m.selectedDNSRow = 0
+
+	client := m.client
+	return tea.Cmd(func() tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		zones, err := client.ListDNSZones(context.Background())
+		return dnsZonesLoadedMsg{zones: zones, err: err}
+	})
+}
+
+// loadDNSZoneCounts counts every zone's records with one small request each,
+// so the collapsed list shows sizes without fetching the records themselves
+func (m Model) loadDNSZoneCounts(zones []models.DNSZone) tea.Cmd {
+	client := m.client
+	return tea.Cmd(func() tea.Msg {
+		counts := make(map[string]int)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		jobs := make(chan string)
+
+		for i := 0; i < dnsCountWorkers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for zoneID := range jobs {
+					count, err := client.CountDNSRecords(context.Background(), zoneID)
+					if err != nil {
+						continue
+					}
+					mu.Lock()
+					counts[zoneID] = count
+					mu.Unlock()
+				}
+			}()
+		}
+		for _, zone := range zones {
+			jobs <- zone.ID
+		}
+		close(jobs)
+		wg.Wait()
+
+		return dnsZoneCountsMsg(counts)
+	})
+}
+
+func (m Model) loadDNSZoneRecords(zoneID string) tea.Cmd {
+	client := m.client
+	return tea.Cmd(func() tea.Msg {
+		records, err := client.ListZoneDNSRecords(context.Background(), zoneID)
+		return dnsZoneRecordsLoadedMsg{zoneID: zoneID, records: records, err: err}
+	})
+}
+
+func (m *Model) handleDNSZonesLoaded(msg dnsZonesLoadedMsg) tea.Cmd {
+	m.dnsZonesLoading = false
+	if msg.err != nil {
+		m.errorMessage = msg.err.Error()
+		return nil
+	}
+	m.dnsZones = msg.zones
+	m.statusMessage = fmt.Sprintf("Loaded %d DNS zones", len(msg.zones))
+	if len(msg.zones) == 0 {
+		return nil
+	}
+	return m.loadDNSZoneCounts(msg.zones)
+}
+
+func (m *Model) handleDNSZoneCounts(msg dnsZoneCountsMsg) {
+	for i, zone := range m.dnsZones {
+		if count, ok := msg[zone.ID]; ok {
+			m.dnsZones[i].RecordCount = count
+		}
+	}
+}
+
+func (m *Model) handleDNSZoneRecordsLoaded(msg dnsZoneRecordsLoadedMsg) {
+	delete(m.dnsLoadingZones, msg.zoneID)
+	if msg.err != nil {
+		m.dnsExpanded[msg.zoneID] = false
+		m.errorMessage = msg.err.Error()
+		return
+	}
+	m.dnsRecords[msg.zoneID] = msg.records
+	for i, zone := range m.dnsZones {
+		if zone.ID == msg.zoneID {
+			m.dnsZones[i].RecordCount = len(msg.records)
+		}
+	}
+}
+
+// toggleDNSZone expands or collapses the zone under the cursor, loading its
+// records the first time it is expanded
+func (m *Model) toggleDNSZone() tea.Cmd {
+	rows := m.dnsRows()
+	if m.selectedDNSRow >= len(rows) {
+		return nil
+	}
+	zone := rows[m.selectedDNSRow].zone
+
+	if m.dnsExpanded[zone.ID] {
+		m.dnsExpanded[zone.ID] = false
+		// Move the cursor back onto the header so it doesn't land in the
+		// next zone's records
+		for i, row := range m.dnsRows() {
+			if row.record == nil && row.zone.ID == zone.ID {
+				m.selectedDNSRow = i
+				break
+			}
+		}
+		return nil
+	}
+
+	m.dnsExpanded[zone.ID] = true
+	if _, loaded := m.dnsRecords[zone.ID]; loaded || m.dnsLoadingZones[zone.ID] {
+		return nil
+	}
+	m.dnsLoadingZones[zone.ID] = true
+	m.statusMessage = fmt.Sprintf("Loading DNS records for %s...", zone.Name)
+	return m.loadDNSZoneRecords(zone.ID)
+}
+
+func (m Model) handleDNSInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.dnsRows()
+
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "tab", "esc", "escape":
+		m.activeTab = tunnelsTab
+		m.statusMessage = "Returned to tunnel list"
+
+	case "shift+tab":
+		m.activeTab = processesTab
+		m.selectedProcess = 0
+		m.statusMessage = "Showing managed processes"
+		return m, m.loadProcessStats()
+
+	case "h", "?":
+		m.showHelp = !m.showHelp
+		m.state.ToggleHelp()
+
+	case "up", "k":
+		if m.selectedDNSRow > 0 {
+			m.selectedDNSRow--
+		}
+
+	case "down", "j":
+		if m.selectedDNSRow < len(rows)-1 {
+			m.selectedDNSRow++
+		}
+
+	case "enter", " ":
+		return m, m.toggleDNSZone()
+
+	case "r":
+		if m.dnsZonesLoading {
+			return m, nil
+		}
+		m.statusMessage = "Refreshing DNS zones..."
+		return m, m.loadDNSZones()
+	}
+
+	return m, nil
+}
+
+func (m Model) renderDNS() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	zoneStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	proxiedStyle := lipgloss.NewStyle().
+		Foreground(colors.warning)
+
+	cursorStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render("🌐 DNS Zones")}
+
+	if m.dnsZonesLoading && len(m.dnsZones) == 0 {
+		lines = append(lines, hintStyle.Render("Loading zones..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	rows := m.dnsRows()
+	if len(rows) == 0 {
+		lines = append(lines, hintStyle.Render("No zones are accessible to this API token"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	// Only render the rows around the cursor; an expanded zone can hold
+	// thousands of records
+	visible := m.height - 14
+	if visible < 10 {
+		visible = 10
+	}
+	start := 0
+	if m.selectedDNSRow >= visible {
+		start = m.selectedDNSRow - visible + 1
+	}
+	end := start + visible
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	format := "    %-40s %-6s %s"
+	for i := start; i < end; i++ {
+		row := rows[i]
+		var line string
+		style := rowStyle
+		if row.record == nil {
+			marker := "▸"
+			if m.dnsExpanded[row.zone.ID] {
+				marker = "▾"
+			}
+			count := "…"
+			if row.zone.RecordCount >= 0 {
+				count = fmt.Sprintf("%d records", row.zone.RecordCount)
+			}
+			line = fmt.Sprintf("%s %s (%s)", marker, row.zone.Name, count)
+			if m.dnsLoadingZones[row.zone.ID] {
+				line += " loading..."
+			}
+			style = zoneStyle
+		} else {
+			record := row.record
+			name := record.Name
+			if len(name) > 40 {
+				name = name[:37] + "..."
+			}
+			content := record.Content
+			if len(content) > 60 {
+				content = content[:57] + "..."
+			}
+			line = fmt.Sprintf(format, name, record.Type, content)
+			if record.Proxied {
+				line += " " + proxiedStyle.Render("proxied")
+			}
+		}
+
+		if i == m.selectedDNSRow {
+			lines = append(lines, cursorStyle.Render(line))
+		} else {
+			lines = append(lines, style.Render(line))
+		}
+	}
+
+	if len(rows) > end-start {
+		lines = append(lines, hintStyle.Render(fmt.Sprintf("Showing %d-%d of %d rows", start+1, end, len(rows))))
+	}
+
+	lines = append(lines, "", hintStyle.Render(strings.Join([]string{
+		"↑↓: Navigate", "Enter/Space: Expand/collapse zone", m.keys.Bound("r") + ": Refresh", "Tab/Escape: Back to tunnels",
+	}, " • ")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,134 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type dnsCheckedMsg struct {
+	hostname     string
+	verification *models.DNSVerification
+	err          error
+}
+
+// openDNSCheck asks public resolvers about the selected hostname and compares
+// their answers with the tunnel's CNAME
+func (m *Model) openDNSCheck() tea.Cmd {
+	if m.selectedHostnameIndex >= len(m.tunnelHostnames) {
+		return nil
+	}
+	hostname := m.tunnelHostnames[m.selectedHostnameIndex].Hostname
+	m.dnsCheckHostname = hostname
+	m.dnsCheck = nil
+	m.statusMessage = fmt.Sprintf("Checking %s with public resolvers...", hostname)
+
+	client := m.client
+	tunnelID := m.selectedTunnelID
+	return tea.Cmd(func() tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		verification, err := client.VerifyHostnameDNS(context.Background(), hostname, tunnelID)
+		return dnsCheckedMsg{hostname: hostname, verification: verification, err: err}
+	})
+}
+
+func (m *Model) handleDNSChecked(msg dnsCheckedMsg) {
+	if msg.hostname != m.dnsCheckHostname {
+		return
+	}
+	if msg.err != nil {
+		m.dnsCheckHostname = ""
+		m.errorMessage = string(apiErrorMsg("check DNS", msg.err))
+		return
+	}
+	m.dnsCheck = msg.verification
+	if msg.verification.OK() {
+		m.statusMessage = fmt.Sprintf("All resolvers see %s pointing at the tunnel", msg.hostname)
+	} else {
+		m.statusMessage = fmt.Sprintf("Public DNS for %s doesn't point at the tunnel everywhere", msg.hostname)
+	}
+}
+
+func (m Model) handleDNSCheckInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "C":
+		m.dnsCheckHostname = ""
+		m.dnsCheck = nil
+		m.statusMessage = "Closed DNS check"
+
+	case "r":
+		return m, m.openDNSCheck()
+	}
+
+	return m, nil
+}
+
+func (m Model) renderDNSCheck() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	okStyle := lipgloss.NewStyle().
+		Foreground(colors.success)
+
+	badStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("🔎 Public DNS for %s", m.dnsCheckHostname))}
+
+	if m.dnsCheck == nil {
+		lines = append(lines, hintStyle.Render("Querying 1.1.1.1 and 8.8.8.8 over DNS-over-HTTPS..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	check := m.dnsCheck
+	lines = append(lines, rowStyle.Render("Expected CNAME: "+check.Expected))
+	if check.Record != nil {
+		lines = append(lines, rowStyle.Render(fmt.Sprintf("Account record: %s %s (proxied: %t)", check.Record.Type, check.Record.Content, check.Record.Proxied)))
+	} else {
+		lines = append(lines, rowStyle.Render("Account record: none - the hostname's DNS is managed elsewhere"))
+	}
+	lines = append(lines, "")
+
+	for _, answer := range check.Answers {
+		line := fmt.Sprintf("%-22s %s", answer.Resolver, answer.Verdict)
+		if answer.OK {
+			lines = append(lines, okStyle.Render("✓ "+line))
+		} else {
+			lines = append(lines, badStyle.Render("✗ "+line))
+		}
+		if answer.CNAME != "" || len(answer.Addresses) > 0 {
+			seen := append([]string{}, answer.Addresses...)
+			if answer.CNAME != "" {
+				seen = append([]string{answer.CNAME}, seen...)
+			}
+			lines = append(lines, hintStyle.Render("    answered "+strings.Join(seen, ", ")))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render(m.keys.Bound("r")+": Re-check • Escape: Close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
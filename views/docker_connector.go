@@ -0,0 +1,44 @@
+package views
+
+import (
+	"context"
+	"fmt"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleDockerConnector starts the tunnel's connector in a cloudflared
+// container, or removes the container if one is already running
+func (m Model) toggleDockerConnector(tunnel models.CLITunnel) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return errorMsg("Tunnel manager not initialized")
+		}
+
+		if m.tunnelManager.HasDockerConnector(tunnel.Name) {
+			if err := m.tunnelManager.StopDockerConnector(tunnel.Name); err != nil {
+				return errorMsg(fmt.Sprintf("Failed to stop Docker connector: %v", err))
+			}
+			return statusMsg(fmt.Sprintf("Removed Docker connector for %s", tunnel.Name))
+		}
+
+		process, err := m.tunnelManager.StartDockerConnector(context.Background(), tunnel.ID, tunnel.Name)
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Failed to start Docker connector: %v", err))
+		}
+		return statusMsg(fmt.Sprintf("Started %s in container %s (PID %d) - see the Processes tab",
+			tunnel.Name, models.GetConnectorContainerName(tunnel.Name), process.PID))
+	})
+}
+
+// adoptDockerConnectors picks up connector containers from earlier sessions
+func (m Model) adoptDockerConnectors() tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager != nil {
+			m.tunnelManager.AdoptDockerConnectors()
+		}
+		return nil
+	})
+}
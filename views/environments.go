@@ -0,0 +1,209 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// envColumn fits an ENV cell into width, leaving it empty while the column
+// is hidden
+func envColumn(width int, env string) string {
+	if width == 0 {
+		return ""
+	}
+	if len(env) > width-1 {
+		env = env[:width-2] + "…"
+	}
+	return env
+}
+
+// openEnvironments lists the configured environments for environment-wide
+// start and stop
+func (m *Model) openEnvironments() {
+	if len(m.config.Environments()) == 0 {
+		m.statusMessage = "No environments configured - map tunnel name patterns to them with tunnel_environments"
+		return
+	}
+	m.showEnvironments = true
+	m.environmentIndex = 0
+	if m.selectedTunnel < len(m.tunnelsList) {
+		current := m.config.TunnelEnvironment(m.tunnelsList[m.selectedTunnel].Name)
+		for i, env := range m.config.Environments() {
+			if env == current {
+				m.environmentIndex = i
+			}
+		}
+	}
+	m.statusMessage = "Environments"
+}
+
+// stopEnvironment stops every local connector of the environment's tunnels
+func (m Model) stopEnvironment(env string) tea.Cmd {
+	tunnelManager := m.tunnelManager
+	var names []string
+	for _, tunnel := range m.config.TunnelsInEnvironment(m.tunnelsList, env) {
+		if m.runningLocally(tunnel.Name) {
+			names = append(names, tunnel.Name)
+		}
+	}
+	if len(names) == 0 {
+		return func() tea.Msg {
+			return processActionMsg{message: fmt.Sprintf("No %s tunnels are running locally", env)}
+		}
+	}
+
+	return func() tea.Msg {
+		var errs []error
+		stopped := 0
+		for _, name := range names {
+			if err := tunnelManager.StopTunnel(name); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			stopped++
+		}
+		return processActionMsg{
+			message: fmt.Sprintf("Stopped %d %s tunnel(s): %s", stopped, env, strings.Join(names, ", ")),
+			err:     errors.Join(errs...),
+		}
+	}
+}
+
+// startEnvironment starts a local connector for each of the environment's
+// tunnels that isn't running, from its local config or its remote ingress.
+// Tunnels with neither are skipped, since they'd need a URL to serve.
+func (m Model) startEnvironment(env string) tea.Cmd {
+	tunnelManager := m.tunnelManager
+	var tunnels []models.CLITunnel
+	for _, tunnel := range m.config.TunnelsInEnvironment(m.tunnelsList, env) {
+		if !m.runningLocally(tunnel.Name) {
+			tunnels = append(tunnels, tunnel)
+		}
+	}
+	domainCounts := make(map[string]int, len(tunnels))
+	for _, tunnel := range tunnels {
+		domainCounts[tunnel.ID] = m.tunnelDomainCounts[tunnel.ID]
+	}
+
+	return func() tea.Msg {
+		var errs []error
+		var started, skipped []string
+		for _, tunnel := range tunnels {
+			config, err := tunnelManager.LoadTunnelConfig(tunnel.Name)
+			if err != nil {
+				if domainCounts[tunnel.ID] == 0 {
+					skipped = append(skipped, tunnel.Name)
+					continue
+				}
+				config = nil
+			}
+			if _, err := tunnelManager.StartTunnel(context.Background(), tunnel.Name, config); err != nil {
+				errs = append(errs, fmt.Errorf("failed to start %s: %w", tunnel.Name, err))
+				continue
+			}
+			started = append(started, tunnel.Name)
+		}
+
+		message := fmt.Sprintf("Started %d %s tunnel(s)", len(started), env)
+		if len(started) > 0 {
+			message += ": " + strings.Join(started, ", ")
+		}
+		if len(skipped) > 0 {
+			message += fmt.Sprintf(" (skipped %s - no hostnames or local config)", strings.Join(skipped, ", "))
+		}
+		return processActionMsg{message: message, err: errors.Join(errs...)}
+	}
+}
+
+func (m Model) handleEnvironmentsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	environments := m.config.Environments()
+
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "g":
+		m.showEnvironments = false
+		m.statusMessage = "Closed environments"
+
+	case "up", "k":
+		if m.environmentIndex > 0 {
+			m.environmentIndex--
+		}
+
+	case "down", "j":
+		if m.environmentIndex < len(environments)-1 {
+			m.environmentIndex++
+		}
+
+	case "s", "x":
+		if m.environmentIndex >= len(environments) {
+			break
+		}
+		if m.tunnelManager == nil {
+			m.errorMessage = "Tunnel manager not initialized"
+			break
+		}
+		env := environments[m.environmentIndex]
+		if msg.String() == "x" {
+			m.statusMessage = fmt.Sprintf("Stopping %s tunnels...", env)
+			return m, m.stopEnvironment(env)
+		}
+		m.statusMessage = fmt.Sprintf("Starting %s tunnels...", env)
+		return m, m.startEnvironment(env)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderEnvironments() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	selectedStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render("🗂  Environments")}
+
+	for i, env := range m.config.Environments() {
+		tunnels := m.config.TunnelsInEnvironment(m.tunnelsList, env)
+		running := 0
+		for _, tunnel := range tunnels {
+			if m.runningLocally(tunnel.Name) {
+				running++
+			}
+		}
+		row := fmt.Sprintf("%-12s %3d tunnel(s), %d running locally", env, len(tunnels), running)
+		if i == m.environmentIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+row))
+		} else {
+			lines = append(lines, rowStyle.Render("  "+row))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render("s: Start all locally • x: Stop all local connectors • Escape: Close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,20 @@
+package views
+
+import (
+	"fmt"
+
+	"tunnelman/models"
+)
+
+// apiErrorMsg reports a failed Cloudflare operation by class, so an invalid
+// token or a rate limit reads the same wherever it happens. action completes
+// "Failed to ...", e.g. "load tunnels".
+func apiErrorMsg(action string, err error) errorMsg {
+	switch {
+	case models.IsAuthenticationError(err):
+		return errorMsg("Authentication failed - check API key/token and permissions")
+	case models.IsRateLimitError(err):
+		return errorMsg(fmt.Sprintf("Failed to %s: Cloudflare is rate limiting requests - try again in a minute", action))
+	}
+	return errorMsg(fmt.Sprintf("Failed to %s: %v", action, err))
+}
@@ -0,0 +1,69 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tunnelman/models"
+)
+
+// exportTable builds the table for the current view without truncation
+func (m Model) exportTable() (string, models.ExportTable) {
+	if m.showTunnelHostnames {
+		table := models.ExportTable{
+			Title:   "Public hostnames for tunnel " + m.selectedTunnelName,
+			Headers: []string{"Hostname", "Path", "Service", "Auth", "Expires"},
+		}
+		for _, hostname := range m.tunnelHostnames {
+			path := hostname.Path
+			if path == "" {
+				path = "*"
+			}
+			service := hostname.Service
+			if original, inMaintenance := m.state.MaintenanceOriginal(hostname.Hostname); inMaintenance {
+				service = "maintenance (was " + original + ")"
+			}
+			auth := "no"
+			if hostname.AuthEnabled {
+				auth = "yes"
+			}
+			expires := ""
+			if temp, exists := m.state.GetTemporaryHostname(hostname.Hostname); exists {
+				expires = temp.ExpiresAt.Format(time.RFC3339)
+			}
+			table.Rows = append(table.Rows, []string{"https://" + hostname.Hostname, path, service, auth, expires})
+		}
+		return "hostnames-" + m.selectedTunnelName, table
+	}
+
+	table := models.ExportTable{
+		Title:   "Tunnels",
+		Headers: []string{"Name", "Status", "Domains", "ID", "Created"},
+	}
+	for _, tunnel := range m.tunnelsList {
+		table.Rows = append(table.Rows, []string{
+			tunnel.Name,
+			m.tunnelIndicator(tunnel.ID).label,
+			fmt.Sprintf("%d", m.tunnelDomainCounts[tunnel.ID]),
+			tunnel.ID,
+			tunnel.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return "tunnels", table
+}
+
+// exportView writes the current table to the working directory
+func (m *Model) exportView(format models.ExportFormat) {
+	m.showExportPrompt = false
+
+	name, table := m.exportTable()
+	name = strings.ReplaceAll(name, "/", "-")
+	path, err := models.WriteExport(".", "tunnelman-"+name, format, table)
+	if err != nil {
+		m.errorMessage = err.Error()
+		return
+	}
+
+	m.statusMessage = fmt.Sprintf("Exported %d rows to %s", len(table.Rows), path)
+}
@@ -0,0 +1,37 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+)
+
+// startServedFolders restarts folder servers from a previous session on their
+// recorded ports, which the tunnels' ingress rules still point at
+func (m *Model) startServedFolders() {
+	if m.tunnelManager == nil {
+		return
+	}
+
+	var failed []string
+	for _, folder := range m.state.ServedFolders {
+		if _, err := m.tunnelManager.ServeFolder(folder.Hostname, folder.Root, folder.Port); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", folder.Hostname, err))
+		}
+	}
+	if len(failed) > 0 {
+		m.errorMessage = "Failed to restart folder servers: " + strings.Join(failed, "; ")
+	}
+}
+
+// stopServedFolder stops the folder server behind a removed hostname
+func (m *Model) stopServedFolder(hostname string) {
+	if !m.state.RemoveServedFolder(hostname) {
+		return
+	}
+	m.state.Save()
+	if m.tunnelManager != nil {
+		m.tunnelManager.StopStaticServer(models.FolderServerKey(hostname))
+	}
+}
@@ -0,0 +1,86 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// guardHostname returns cmd unchanged for ordinary hostnames. For hostnames
+// matching a protected pattern it holds cmd back and asks the user to type
+// the hostname first; the command runs once the typed name matches.
+func (m *Model) guardHostname(hostname, action string, cmd tea.Cmd) tea.Cmd {
+	if !m.config.IsProtectedHostname(hostname) {
+		return cmd
+	}
+
+	m.loading = false
+	m.showTypedConfirm = true
+	m.typedConfirmHostname = hostname
+	m.typedConfirmAction = action
+	m.pendingAction = cmd
+
+	m.typedConfirmInput = textinput.New()
+	m.typedConfirmInput.Placeholder = hostname
+	m.typedConfirmInput.CharLimit = 253
+	m.typedConfirmInput.Width = 50
+	m.typedConfirmInput.Focus()
+
+	m.statusMessage = fmt.Sprintf("%s is protected - type the hostname to %s it", hostname, action)
+	return nil
+}
+
+func (m Model) handleTypedConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.showTypedConfirm = false
+		m.pendingAction = nil
+		m.statusMessage = fmt.Sprintf("Cancelled: %s was not modified", m.typedConfirmHostname)
+		return m, nil
+
+	case "enter":
+		if m.typedConfirmInput.Value() != m.typedConfirmHostname {
+			m.statusMessage = "Hostname did not match - type it exactly or press Escape to cancel"
+			return m, nil
+		}
+
+		cmd := m.pendingAction
+		m.showTypedConfirm = false
+		m.pendingAction = nil
+		m.loading = true
+		m.statusMessage = fmt.Sprintf("Confirmed: %s %s", m.typedConfirmAction, m.typedConfirmHostname)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.typedConfirmInput, cmd = m.typedConfirmInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderTypedConfirm() string {
+	warningStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.errorColor).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.errorColor).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		warningStyle.Render(fmt.Sprintf("🛡  %s is a protected hostname", m.typedConfirmHostname)),
+		fmt.Sprintf("Type the hostname to %s it:", m.typedConfirmAction),
+		m.typedConfirmInput.View(),
+		"",
+		hintStyle.Render("Enter: Confirm • Escape: Cancel"),
+	)
+}
@@ -0,0 +1,107 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpScreens names the screens the help overlay describes
+var helpScreens = map[keyScope]string{
+	scopeTunnelList:   "Tunnel List",
+	scopeHostnames:    "Hostnames",
+	scopeProcesses:    "Processes Tab",
+	scopeDNS:          "DNS Tab",
+	scopeHostnameForm: "Hostname Form",
+	scopeTunnelForm:   "New Tunnel Form",
+}
+
+// helpScope is the screen under the help overlay
+func (m Model) helpScope() keyScope {
+	switch {
+	case m.showAddHostname || m.showEditHostname:
+		return scopeHostnameForm
+	case m.showNewTunnel:
+		return scopeTunnelForm
+	case m.activeTab == processesTab:
+		return scopeProcesses
+	case m.activeTab == dnsTab:
+		return scopeDNS
+	case m.showTunnelHostnames:
+		return scopeHostnames
+	}
+	return scopeTunnelList
+}
+
+// handleHelpInput closes the help on any key, leaving the screen under it
+// as it was
+func (m Model) handleHelpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, m.quit()
+	}
+	m.showHelp = false
+	m.state.ToggleHelp()
+	m.statusMessage = "Closed help"
+	return m, nil
+}
+
+// helpKey renders a key padded to the help screen's key column
+func helpKey(keyStyle lipgloss.Style, key string) string {
+	padding := 11 - lipgloss.Width(key)
+	if padding < 1 {
+		padding = 1
+	}
+	return keyStyle.Render(key) + strings.Repeat(" ", padding)
+}
+
+// renderHelp lists the key bindings of the screen under the help, with the
+// keys as configured
+func (m Model) renderHelp() string {
+	helpStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colors.accent).
+		Padding(2).
+		Width(m.width - 4).
+		Height(m.height - 4)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		Align(lipgloss.Center).
+		MarginBottom(2)
+
+	keyStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.success)
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(colors.border)
+
+	scope := m.helpScope()
+	content := []string{
+		titleStyle.Render("🔧 Tunnelman Help: " + helpScreens[scope]),
+		"",
+		strings.ToUpper(helpScreens[scope]) + ":",
+	}
+
+	// Keys that work the same across screens go last
+	var general []string
+	for _, binding := range bindingsFor(scope) {
+		line := fmt.Sprintf("  %s %s", helpKey(keyStyle, keyLabel(m.keys.Bound(binding.key))), descStyle.Render(binding.help))
+		if binding.scope&scopeScreens == scopeScreens || binding.scope&scopeForms == scopeForms {
+			general = append(general, line)
+			continue
+		}
+		content = append(content, line)
+	}
+	if len(general) > 0 {
+		content = append(content, "", "GENERAL:")
+		content = append(content, general...)
+	}
+
+	content = append(content, "", descStyle.Render("Press any key to close this help."))
+
+	return helpStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+}
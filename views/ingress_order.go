@@ -0,0 +1,88 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ingressOrderFixedMsg struct {
+	tunnelID string
+	fixed    models.IngressOrderProblem
+}
+
+// fixIngressOrder adds a missing catch-all and moves shadowed rules in front
+// of the catch-all
+func (m Model) fixIngressOrder(tunnelID string) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		fixed, err := client.FixTunnelIngressOrder(context.Background(), tunnelID)
+		if err != nil {
+			return apiErrorMsg("fix ingress rule order", err)
+		}
+		return ingressOrderFixedMsg{tunnelID: tunnelID, fixed: fixed}
+	}
+}
+
+func (m *Model) handleIngressOrderFixed(msg ingressOrderFixedMsg) tea.Cmd {
+	m.loading = false
+	delete(m.ingressProblems, msg.tunnelID)
+
+	var changes []string
+	if msg.fixed.MissingCatchAll {
+		changes = append(changes, "added a catch-all answering "+models.DefaultCatchAllService)
+	}
+	if moved := len(msg.fixed.Unreachable); moved > 0 {
+		changes = append(changes, fmt.Sprintf("moved %d rule(s) in front of the catch-all", moved))
+	}
+	if len(changes) == 0 {
+		m.statusMessage = "Ingress rules were already in order"
+	} else {
+		m.statusMessage = "Fixed ingress rules: " + strings.Join(changes, ", ")
+	}
+
+	if m.showTunnelHostnames && m.selectedTunnelID == msg.tunnelID {
+		return tea.Batch(m.loadTunnelHostnames(msg.tunnelID), m.loadTunnelDetails(msg.tunnelID))
+	}
+	return nil
+}
+
+// renderIngressOrderWarning explains what's wrong with the open tunnel's
+// remote ingress order, if anything
+func (m Model) renderIngressOrderWarning() string {
+	problem, checked := m.ingressProblems[m.selectedTunnelID]
+	if !checked || !problem.Any() {
+		return ""
+	}
+
+	warnStyle := lipgloss.NewStyle().
+		Foreground(colors.warning).
+		MarginTop(1)
+
+	var lines []string
+	if problem.MissingCatchAll {
+		lines = append(lines, "⚠ No catch-all rule: cloudflared needs a last rule without a hostname for unmatched requests")
+	}
+	if len(problem.Unreachable) > 0 {
+		var targets []string
+		for _, rule := range problem.Unreachable {
+			target := rule.Hostname + rule.Path
+			if target == "" {
+				target = "another catch-all"
+			}
+			targets = append(targets, target)
+		}
+		lines = append(lines, fmt.Sprintf("⚠ %d rule(s) after the catch-all never match: %s", len(problem.Unreachable), strings.Join(targets, ", ")))
+	}
+	lines = append(lines, "  f: Fix the rule order")
+
+	return warnStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,130 @@
+package views
+
+import (
+	"context"
+	"fmt"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ingressRulesLoadedMsg struct {
+	tunnelID string
+	rules    []models.TunnelConfigIngress
+}
+
+// openIngressSimulator starts the "which rule matches this URL?" prompt for
+// the open tunnel and loads its full ingress, including the catch-all
+func (m *Model) openIngressSimulator() tea.Cmd {
+	m.showIngressSim = true
+	m.ingressSimRules = nil
+	m.ingressSimInput = textinput.New()
+	m.ingressSimInput.Placeholder = "https://app.example.com/api/users"
+	if m.selectedHostnameIndex < len(m.tunnelHostnames) {
+		m.ingressSimInput.SetValue("https://" + m.tunnelHostnames[m.selectedHostnameIndex].Hostname + "/")
+	}
+	m.ingressSimInput.CharLimit = 500
+	m.ingressSimInput.Width = 80
+	m.ingressSimInput.Focus()
+	m.ingressSimInput.CursorEnd()
+	m.statusMessage = "Type a URL to see which ingress rule serves it"
+
+	tunnelID := m.selectedTunnelID
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		config, err := m.client.GetTunnelConfiguration(context.Background(), tunnelID)
+		if err != nil {
+			return apiErrorMsg("load ingress rules", err)
+		}
+		return ingressRulesLoadedMsg{tunnelID: tunnelID, rules: config.Config.Ingress}
+	})
+}
+
+func (m Model) handleIngressSimInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape", "enter":
+		m.showIngressSim = false
+		m.statusMessage = "Closed ingress simulator"
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.ingressSimInput, cmd = m.ingressSimInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderIngressSim() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	ruleStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	matchStyle := lipgloss.NewStyle().
+		Foreground(colors.success).
+		Bold(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("🧭 Which rule serves this URL? (%s)", m.selectedTunnelName)),
+		m.ingressSimInput.View(),
+		"",
+	}
+
+	if m.ingressSimRules == nil {
+		lines = append(lines, hintStyle.Render("Loading ingress rules..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	matched, err := models.MatchIngress(m.ingressSimRules, m.ingressSimInput.Value())
+	switch {
+	case err != nil:
+		lines = append(lines, errorStyle.Render("✗ "+err.Error()))
+	case matched < 0:
+		lines = append(lines, errorStyle.Render("✗ No rule matches - cloudflared needs a catch-all rule last"))
+	default:
+		rule := m.ingressSimRules[matched]
+		lines = append(lines, matchStyle.Render(fmt.Sprintf("✓ Rule %d serves it: %s", matched+1, rule.Service)))
+	}
+
+	lines = append(lines, "", "Rules in match order:")
+	for i, rule := range m.ingressSimRules {
+		hostname := rule.Hostname
+		if hostname == "" {
+			hostname = "(catch-all)"
+		}
+		path := rule.Path
+		if path == "" {
+			path = "*"
+		}
+		line := fmt.Sprintf("  %d. %-35s %-15s → %s", i+1, hostname, path, rule.Service)
+		if i == matched {
+			lines = append(lines, matchStyle.Render("▶"+line[1:]))
+		} else {
+			lines = append(lines, ruleStyle.Render(line))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render("Results update as you type • Enter/Escape: Close"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
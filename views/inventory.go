@@ -0,0 +1,220 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inventoryColumns are the sortable columns of the All hostnames view
+var inventoryColumns = []string{"HOSTNAME", "TUNNEL", "SERVICE", "AUTH", "DNS"}
+
+type inventoryDNSLoadedMsg struct {
+	statuses map[string]models.DNSStatus
+	err      error
+}
+
+// openInventory shows every tunnel's public hostnames in one table
+func (m *Model) openInventory() tea.Cmd {
+	m.showInventory = true
+	m.selectedInventoryRow = 0
+	m.inventoryDNS = nil
+	m.allHostnames = nil
+	m.statusMessage = "Loading hostnames across all tunnels..."
+	return m.loadAllHostnames()
+}
+
+func (m Model) loadInventoryDNS(hostnames []models.TunnelHostname) tea.Cmd {
+	client := m.client
+	return tea.Cmd(func() tea.Msg {
+		statuses, err := client.HostnameDNSStatuses(context.Background(), hostnames)
+		return inventoryDNSLoadedMsg{statuses: statuses, err: err}
+	})
+}
+
+func (m Model) inventoryDNSStatus(hostname models.TunnelHostname) string {
+	if m.inventoryDNS == nil {
+		return "…"
+	}
+	if status, ok := m.inventoryDNS[strings.ToLower(hostname.Hostname)]; ok {
+		return string(status)
+	}
+	return "?"
+}
+
+func inventoryAuth(hostname models.TunnelHostname) string {
+	if hostname.AuthEnabled {
+		return "yes"
+	}
+	return "no"
+}
+
+// inventoryRows returns the hostnames sorted by the selected column, with
+// hostname as the tie breaker
+func (m Model) inventoryRows() []models.TunnelHostname {
+	rows := append([]models.TunnelHostname{}, m.allHostnames...)
+	key := func(h models.TunnelHostname) string {
+		switch m.inventorySort {
+		case 1:
+			return strings.ToLower(h.TunnelName)
+		case 2:
+			return strings.ToLower(h.Service)
+		case 3:
+			return inventoryAuth(h)
+		case 4:
+			return m.inventoryDNSStatus(h)
+		}
+		return strings.ToLower(h.Hostname)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := key(rows[i]), key(rows[j])
+		if a == b {
+			return strings.ToLower(rows[i].Hostname+rows[i].Path) < strings.ToLower(rows[j].Hostname+rows[j].Path)
+		}
+		if m.inventoryDesc {
+			return a > b
+		}
+		return a < b
+	})
+	return rows
+}
+
+func (m Model) handleInventoryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.inventoryRows()
+
+	switch key := m.keys.Translate(msg.String()); key {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "H":
+		m.showInventory = false
+		m.statusMessage = "Closed hostname inventory"
+
+	case "up", "k":
+		if m.selectedInventoryRow > 0 {
+			m.selectedInventoryRow--
+		}
+
+	case "down", "j":
+		if m.selectedInventoryRow < len(rows)-1 {
+			m.selectedInventoryRow++
+		}
+
+	case "1", "2", "3", "4", "5":
+		column := int(key[0] - '1')
+		if column == m.inventorySort {
+			m.inventoryDesc = !m.inventoryDesc
+		} else {
+			m.inventorySort = column
+			m.inventoryDesc = false
+		}
+		m.selectedInventoryRow = 0
+
+	case "r":
+		return m, m.openInventory()
+
+	case "enter":
+		if m.selectedInventoryRow < len(rows) {
+			m.showInventory = false
+			return m, m.jumpToHostname(rows[m.selectedInventoryRow])
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) renderInventory() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	problemStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	cursorStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render("🗂️  All Hostnames")}
+
+	if m.allHostnames == nil {
+		lines = append(lines, hintStyle.Render("Loading hostnames from all tunnels..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	rows := m.inventoryRows()
+	if len(rows) == 0 {
+		lines = append(lines, hintStyle.Render("No tunnel has any public hostnames"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	headers := make([]interface{}, len(inventoryColumns))
+	for i, column := range inventoryColumns {
+		label := fmt.Sprintf("%d:%s", i+1, column)
+		if i == m.inventorySort {
+			if m.inventoryDesc {
+				label += " ▼"
+			} else {
+				label += " ▲"
+			}
+		}
+		headers[i] = label
+	}
+	format := "%-40s %-20s %-32s %-8s %s"
+	lines = append(lines, headerStyle.Render(fmt.Sprintf(format, headers...)))
+
+	for i, row := range rows {
+		hostname := row.Hostname + row.Path
+		if len(hostname) > 40 {
+			hostname = hostname[:37] + "..."
+		}
+		tunnel := row.TunnelName
+		if len(tunnel) > 20 {
+			tunnel = tunnel[:17] + "..."
+		}
+		service := row.Service
+		if len(service) > 32 {
+			service = service[:29] + "..."
+		}
+		dns := m.inventoryDNSStatus(row)
+
+		line := fmt.Sprintf(format, hostname, tunnel, service, inventoryAuth(row), dns)
+		if i == m.selectedInventoryRow {
+			lines = append(lines, cursorStyle.Render(line))
+		} else if dns != string(models.DNSStatusOK) && m.inventoryDNS != nil {
+			lines = append(lines, problemStyle.Render(line))
+		} else {
+			lines = append(lines, rowStyle.Render(line))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render(strings.Join([]string{
+		"↑↓: Navigate", "1-5: Sort by column (again to reverse)", "Enter: Open hostname", m.keys.Bound("r") + ": Refresh", "Escape: Close",
+	}, " • ")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,181 @@
+package views
+
+import (
+	"strings"
+	"unicode"
+
+	"tunnelman/models"
+)
+
+// keyScope is the set of screens a key binding applies to
+type keyScope int
+
+const (
+	scopeTunnelList keyScope = 1 << iota
+	scopeHostnames
+	scopeProcesses
+	scopeDNS
+	scopeHostnameForm
+	scopeTunnelForm
+
+	scopeBoth    = scopeTunnelList | scopeHostnames
+	scopeScreens = scopeBoth | scopeProcesses | scopeDNS
+	scopeForms   = scopeHostnameForm | scopeTunnelForm
+)
+
+// keyBinding is one key the views handle. Bindings with a name are palette
+// commands, which run by replaying key, so the palette and the help screen
+// both describe exactly what the key does. key is the built-in key; the
+// configured one is looked up with KeyMap.Bound. TestHelpMatchesDispatch
+// keeps the table in step with the screens' key switches.
+type keyBinding struct {
+	key   string
+	name  string
+	help  string
+	scope keyScope
+}
+
+var keyBindings = []keyBinding{
+	{"r", "Refresh", "Refresh tunnels, or the selected tunnel's hostnames", scopeBoth},
+	{"o", "Open tunnel in browser", "Open the tunnel's configuration in the Cloudflare dashboard", scopeBoth},
+	{"a", "Add hostname", "Add a public hostname to the selected tunnel", scopeBoth},
+	{"E", "Export table", "Export the current tunnel or hostname table to Markdown or CSV", scopeBoth},
+	{"h", "Help", "Show help for the current screen (also ?, or F1 in forms)", scopeScreens},
+	{"c", "Dismiss notifications", "Dismiss the notifications in the corner", scopeBoth},
+	{"!", "Error history", "Review this session's errors and warnings after their notifications expire", scopeBoth},
+	{"ctrl+p", "", "Command palette: search every action by name and run it (also :)", scopeBoth},
+	{"q", "Quit", "Quit (also Ctrl+C)", scopeScreens},
+
+	{"↑/↓ k/j", "", "Move the selection", scopeScreens},
+	{"enter", "View hostnames", "View the selected tunnel's public hostnames (also Space)", scopeTunnelList},
+	{"tab", "Switch to Processes tab", "Show the processes tunnelman manages", scopeTunnelList},
+	{"shift+tab", "Switch to DNS tab", "Show DNS records across zones", scopeTunnelList},
+	{"n", "New tunnel", "Create a tunnel, optionally with a first hostname and its connector started locally", scopeTunnelList},
+	{"s", "Start/stop tunnel locally", "Start or stop a local connector for the tunnel (asks first when other connectors already serve it)", scopeTunnelList},
+	{"d", "Delete tunnel", "Delete the selected tunnel (asks first, then cleans up its DNS)", scopeTunnelList},
+	{"t", "Self test", "Route a throwaway hostname to a local echo server and request it through the edge", scopeTunnelList},
+	{"l", "Stream remote logs", "Stream the tunnel's connector logs from the edge (cloudflared tail), wherever the connector runs", scopeTunnelList},
+	{"g", "Environments", "Start or stop all of a tunnel_environments environment's tunnels locally", scopeTunnelList},
+	{">", "Sort tunnels", "Sort by name, status, domain count or creation date (saved in the config)", scopeTunnelList},
+	{"<", "Reverse sort order", "Reverse the sort order", scopeTunnelList},
+	{"p", "Pin/unpin tunnel", "Pin or unpin the selected tunnel at the top of the list", scopeTunnelList},
+	{"y", "Copy tunnel ID", "Copy the tunnel's ID (OSC52 over SSH)", scopeTunnelList},
+	{"Y", "Copy tunnel name", "Copy the tunnel's name", scopeTunnelList},
+	{"F", "Filter tunnels by prefix", "Filter tunnels by name prefix on the server; the list then loads page by page", scopeTunnelList},
+	{"O", "Edit run arguments", "Edit extra cloudflared run arguments (e.g. --edge-ip-version 4) for the tunnel", scopeTunnelList},
+	{"L", "View local config", "View the tunnel's cloudflared YAML, edit it in $EDITOR (validated before saving) or start with it", scopeTunnelList},
+	{"D", "Toggle Docker connector", "Run the tunnel's connector in a cloudflare/cloudflared container (toggle)", scopeTunnelList},
+	{"G", "Search hostnames", "Search hostnames across all tunnels and jump to one", scopeTunnelList},
+	{"H", "All hostnames", "Table of every tunnel's hostnames with service, auth and DNS status", scopeTunnelList},
+	{"V", "Security review", "Flag hostnames without auth, admin ports, wildcards and unproxied DNS records", scopeTunnelList},
+	{"U", "Uptime report", "Show the 7/30 day uptime report for all tunnels", scopeTunnelList},
+	{"X", "Clean up stale tunnels", "Clean up stale tunnels (no hostnames, no connections, idle for days)", scopeTunnelList},
+
+	{"esc", "Back to tunnel list", "Back to the tunnel list (also Space)", scopeHostnames},
+	{"e", "Edit hostname", "Edit the selected public hostname", scopeHostnames},
+	{"s", "Edit service URL", "Edit just the service URL inline", scopeHostnames},
+	{"d", "Delete hostname", "Delete the selected hostname (asks first, then cleans up its DNS)", scopeHostnames},
+	{"y", "Copy hostname URL", "Copy the hostname's https:// URL (OSC52 over SSH)", scopeHostnames},
+	{"O", "Open hostname in browser", "Open the hostname's https:// URL in the browser", scopeHostnames},
+	{"A", "Toggle auth", "Toggle authentication for the hostname (6-digit password)", scopeHostnames},
+	{"u", "Manage auth users", "Manage the hostname's basic auth users: add, remove, regenerate passwords", scopeHostnames},
+	{"T", "Toggle access tcp client", "Start or stop a local cloudflared access tcp client for tcp:// hostnames", scopeHostnames},
+	{"S", "Add SSH config entry", "Append a cloudflared access ssh entry to ~/.ssh/config for ssh:// hostnames", scopeHostnames},
+	{"B", "Toggle browser rendering", "Toggle in-browser SSH/VNC rendering for ssh:// and vnc:// hostnames already behind an Access application", scopeHostnames},
+	{"N", "Toggle branded 404 page", "Point the catch-all rule at a branded 404 page served by tunnelman (toggle)", scopeHostnames},
+	{"R", "Recent requests", "Show recent requests (time, method, status, path) to the hostname", scopeHostnames},
+	{"I", "Simulate ingress match", "Type a URL and see which of the tunnel's ingress rules would serve it", scopeHostnames},
+	{"Y", "Show apply YAML", "Show the tunnel's hostnames as tunnelman apply YAML to copy or write to a file", scopeHostnames},
+	{"X", "Reverse proxy snippet", "Show a Caddyfile or nginx block matching the hostname's Host header and port", scopeHostnames},
+	{"C", "Check DNS resolution", "Ask 1.1.1.1 and 8.8.8.8 (over HTTPS) where the hostname points and compare with the tunnel CNAME", scopeHostnames},
+	{"P", "Split into path rules", "Split the hostname into path-based rules (e.g. /api → 8080, /app → 3000)", scopeHostnames},
+	{"M", "Toggle maintenance mode", "Toggle maintenance mode (serve 503 / maintenance service, restore the original later)", scopeHostnames},
+	{"W", "Toggle WARP routing", "Toggle WARP/ICMP private network routing for the tunnel (with confirmation)", scopeHostnames},
+	{"f", "Fix ingress rule order", "Fix the ingress order when the view warns of a missing catch-all or rules that never match", scopeHostnames},
+
+	{"x", "", "Stop the selected connector, access client or built-in server", scopeProcesses},
+	{"R", "", "Restart the selected process with the same command line", scopeProcesses},
+	{"r", "", "Refresh process stats", scopeProcesses},
+	{"tab", "", "Switch to the DNS tab", scopeProcesses},
+	{"esc", "", "Back to the tunnel list", scopeProcesses},
+	{"shift+tab", "", "Back to the tunnel list too", scopeProcesses},
+
+	{"enter", "", "Expand or collapse the selected zone (also Space)", scopeDNS},
+	{"r", "", "Refresh zones", scopeDNS},
+	{"shift+tab", "", "Switch to the Processes tab", scopeDNS},
+	{"esc", "", "Back to the tunnel list", scopeDNS},
+	{"tab", "", "Back to the tunnel list too", scopeDNS},
+
+	{"tab", "", "Next field (Shift+Tab for the previous one)", scopeForms},
+	{"↑/↓", "", "Pick the zone on the domain field", scopeHostnameForm},
+	{"enter", "", "Next field, or submit on the domain field", scopeHostnameForm},
+	{"ctrl+r", "", "Cycle recent services on the service field, or refetch the zones on the domain field", scopeHostnameForm},
+	{"ctrl+t", "", "Cycle homelab presets (Home Assistant, Jellyfin, Proxmox...) with their service, options and auth advice", scopeHostnameForm},
+	{"enter", "", "Next field, or create the tunnel on the last one", scopeTunnelForm},
+	{" ", "", "Toggle starting the connector locally", scopeTunnelForm},
+	{"esc", "", "Cancel the form", scopeForms},
+	{"f1", "", "Show this help", scopeForms},
+	{"ctrl+c", "", "Quit", scopeForms},
+}
+
+// keyAliases are the keys behind table entries that stand for several
+var keyAliases = map[string][]string{"↑/↓ k/j": {"up", "down", "k", "j"}, "↑/↓": {"up", "down"}}
+
+// aliasKeys are the second keys the table mentions only in its help text
+var aliasKeys = map[string]string{"ctrl+c": "Quit", "?": "Help", ":": "Command palette", " ": "View hostnames or go back", "escape": "Go back"}
+
+// reservedKeys maps the keys the screens handle themselves to what they do,
+// for KeyMap to refuse rebinding an action onto one of them. The rebindable
+// actions' own keys are left to KeyMap's collision check.
+func reservedKeys() map[string]string {
+	rebindable := make(map[string]bool, len(models.DefaultKeyBindings))
+	for _, key := range models.DefaultKeyBindings {
+		rebindable[key] = true
+	}
+	reserved := make(map[string]string)
+	for _, binding := range bindingsFor(scopeScreens) {
+		keys, ok := keyAliases[binding.key]
+		if !ok {
+			keys = []string{binding.key}
+		}
+		for _, key := range keys {
+			if _, seen := reserved[key]; !seen && !rebindable[key] {
+				reserved[key] = binding.help
+			}
+		}
+	}
+	for key, does := range aliasKeys {
+		if _, seen := reserved[key]; !seen {
+			reserved[key] = does
+		}
+	}
+	return reserved
+}
+
+// keyLabels are how keys other than plain characters are shown
+var keyLabels = map[string]string{"enter": "Enter", "esc": "Escape", "tab": "Tab", "shift+tab": "Shift+Tab", " ": "Space", "f1": "F1"}
+
+// keyLabel shows key the way the footers do, e.g. Shift+O for O
+func keyLabel(key string) string {
+	if label, ok := keyLabels[key]; ok {
+		return label
+	}
+	if rest, ok := strings.CutPrefix(key, "ctrl+"); ok {
+		return "Ctrl+" + strings.ToUpper(rest)
+	}
+	if len(key) == 1 && unicode.IsUpper(rune(key[0])) {
+		return "Shift+" + key
+	}
+	return key
+}
+
+// bindingsFor returns the bindings that apply on the screens in scope
+func bindingsFor(scope keyScope) []keyBinding {
+	var bindings []keyBinding
+	for _, binding := range keyBindings {
+		if binding.scope&scope != 0 {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings
+}
@@ -0,0 +1,124 @@
+package views
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// dispatchers are the functions whose `switch m.keys.Translate(msg.String())`
+// handles each screen's keys. The tunnel list and hostnames share one.
+var dispatchers = map[keyScope]struct{ file, function string }{
+	scopeTunnelList: {"main.go", "update"},
+	scopeHostnames:  {"main.go", "update"},
+	scopeProcesses:  {"processes.go", "handleProcessesInput"},
+	scopeDNS:        {"dns.go", "handleDNSInput"},
+}
+
+// dispatchedKeys returns the case labels of function's translated key switch
+func dispatchedKeys(t *testing.T, file, function string) map[string]bool {
+	t.Helper()
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", file, err)
+	}
+
+	var keys map[string]bool
+	for _, decl := range parsed.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != function {
+			continue
+		}
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			sw, ok := node.(*ast.SwitchStmt)
+			if !ok || keys != nil || !isTranslatedKey(sw.Tag) {
+				return true
+			}
+			keys = make(map[string]bool)
+			for _, stmt := range sw.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						key, _ := strconv.Unquote(lit.Value)
+						keys[key] = true
+					}
+				}
+			}
+			return false
+		})
+	}
+	if keys == nil {
+		t.Fatalf("%s in %s has no switch on m.keys.Translate(msg.String()), so rebound keys don't reach it", function, file)
+	}
+	return keys
+}
+
+// isTranslatedKey reports whether expr is m.keys.Translate(msg.String())
+func isTranslatedKey(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Translate" {
+		return false
+	}
+	arg, ok := call.Args[0].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	argSel, ok := arg.Fun.(*ast.SelectorExpr)
+	return ok && argSel.Sel.Name == "String"
+}
+
+// bindingKeys expands a table entry into the keys it stands for
+func bindingKeys(key string) []string {
+	if keys, ok := keyAliases[key]; ok {
+		return keys
+	}
+	return []string{key}
+}
+
+// TestHelpMatchesDispatch checks both ways that the help table and the key
+// switches agree: every key the help lists is handled on its screen, and
+// every key a screen handles is in the help.
+func TestHelpMatchesDispatch(t *testing.T) {
+	for scope, dispatcher := range dispatchers {
+		handled := dispatchedKeys(t, dispatcher.file, dispatcher.function)
+
+		documented := make(map[string]bool)
+		for _, binding := range bindingsFor(scope) {
+			for _, key := range bindingKeys(binding.key) {
+				documented[key] = true
+				if !handled[key] {
+					t.Errorf("%s help lists %q (%s) but %s doesn't handle it", helpScreens[scope], key, binding.help, dispatcher.function)
+				}
+			}
+		}
+
+		// The shared switch handles both screens' keys, so check it against
+		// their combined help
+		if dispatcher.function == "update" {
+			for _, binding := range bindingsFor(scopeBoth) {
+				for _, key := range bindingKeys(binding.key) {
+					documented[key] = true
+				}
+			}
+		}
+
+		var undocumented []string
+		for key := range handled {
+			if !documented[key] {
+				if _, alias := aliasKeys[key]; !alias {
+					undocumented = append(undocumented, key)
+				}
+			}
+		}
+		sort.Strings(undocumented)
+		for _, key := range undocumented {
+			t.Errorf("%s handles %q on the %s screen but the help doesn't list it", dispatcher.function, key, helpScreens[scope])
+		}
+	}
+}
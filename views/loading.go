@@ -0,0 +1,48 @@
+package views
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newLoadingSpinner() spinner.Model {
+	return spinner.New(
+		spinner.WithSpinner(spinner.Dot),
+		spinner.WithStyle(lipgloss.NewStyle().Foreground(colors.accentSoft)),
+	)
+}
+
+// keepSpinning starts the status bar spinner when a load begins. It stops by
+// itself on the first tick after loading finishes, so an idle screen isn't
+// redrawn ten times a second.
+func (m *Model) keepSpinning() tea.Cmd {
+	if !m.loading || m.spinning {
+		return nil
+	}
+	m.spinning = true
+	return m.spinner.Tick
+}
+
+func (m *Model) handleSpinnerTick(msg spinner.TickMsg) tea.Cmd {
+	if !m.loading {
+		m.spinning = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+// loadingPlaceholder reports whether the screen has nothing to show yet
+// besides the loading message. Otherwise the current table stays up while
+// the load completes in the background.
+func (m Model) loadingPlaceholder() bool {
+	if !m.loading {
+		return false
+	}
+	if m.showTunnelHostnames {
+		return len(m.tunnelHostnames) == 0 && !m.showAddHostname && !m.showEditHostname
+	}
+	return m.activeTab == 0 && len(m.tunnelsList) == 0
+}
@@ -0,0 +1,237 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type localConfigsLoadedMsg map[string]models.LocalConfig
+type localConfigEditedMsg struct {
+	path string
+	err  error
+}
+
+// loadLocalConfigs scans the cloudflared directory for YAML configs that
+// belong to the listed tunnels
+func (m Model) loadLocalConfigs(tunnels []models.CLITunnel) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return localConfigsLoadedMsg(nil)
+		}
+		configs, err := m.tunnelManager.ScanLocalConfigs()
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Failed to scan local configs: %v", err))
+		}
+		return localConfigsLoadedMsg(models.MatchLocalConfigs(configs, tunnels))
+	})
+}
+
+func (m *Model) handleLocalConfigsLoaded(msg localConfigsLoadedMsg) {
+	m.localConfigs = msg
+	if m.state.LocalConfigsScanned {
+		return
+	}
+	// Point out existing configs once, the first time tunnelman finds them
+	m.state.LocalConfigsScanned = true
+	m.state.Save()
+	if len(msg) > 0 {
+		m.statusMessage = fmt.Sprintf("Found %d existing cloudflared configs for your tunnels in %s - press L on a tunnel to view", len(msg), models.GetCloudflaredDir())
+	}
+}
+
+// openLocalConfig shows the local config of the selected tunnel
+func (m *Model) openLocalConfig() {
+	if m.selectedTunnel >= len(m.tunnelsList) {
+		return
+	}
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	local, ok := m.localConfigs[tunnel.ID]
+	if !ok {
+		m.statusMessage = fmt.Sprintf("No config for %s in %s", tunnel.Name, models.GetCloudflaredDir())
+		return
+	}
+
+	data, err := os.ReadFile(local.Path)
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to read %s: %v", local.Path, err)
+		return
+	}
+	m.localConfigTunnel = tunnel
+	m.localConfigYAML = string(data)
+	m.localConfigError = nil
+}
+
+// editLocalConfig hands the config being viewed to $VISUAL or $EDITOR. The
+// edit happens on a temporary copy that only replaces the real file once it
+// validates.
+func (m Model) editLocalConfig() tea.Cmd {
+	tmp, err := os.CreateTemp("", "tunnelman-*.yml")
+	if err != nil {
+		return func() tea.Msg { return errorMsg(fmt.Sprintf("Failed to create temporary file: %v", err)) }
+	}
+	_, err = tmp.WriteString(m.localConfigYAML)
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return func() tea.Msg { return errorMsg(fmt.Sprintf("Failed to write temporary file: %v", err)) }
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := append(strings.Fields(editor), tmp.Name())
+
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), func(err error) tea.Msg {
+		return localConfigEditedMsg{path: tmp.Name(), err: err}
+	})
+}
+
+// handleLocalConfigEdited validates an edited config and saves it over the
+// original only when it is valid; otherwise the edit is kept for another try
+func (m *Model) handleLocalConfigEdited(msg localConfigEditedMsg) {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		m.errorMessage = fmt.Sprintf("Editor failed: %v", msg.err)
+		return
+	}
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to read edited config: %v", err)
+		return
+	}
+	m.localConfigYAML = string(data)
+
+	config, err := m.tunnelManager.ValidateConfigFile(msg.path)
+	if err != nil {
+		var configErr *models.ConfigError
+		if !errors.As(err, &configErr) {
+			configErr = &models.ConfigError{Message: err.Error()}
+		}
+		m.localConfigError = configErr
+		m.statusMessage = fmt.Sprintf("Not saved - fix the highlighted error (%s: Edit again, Escape: Discard)", m.keys.Bound("e"))
+		return
+	}
+
+	local := m.localConfigs[m.localConfigTunnel.ID]
+	if err := os.WriteFile(local.Path, data, 0644); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save %s: %v", local.Path, err)
+		return
+	}
+	local.Config = config
+	m.localConfigs[m.localConfigTunnel.ID] = local
+	m.localConfigError = nil
+	m.statusMessage = fmt.Sprintf("Saved %s", local.Path)
+}
+
+func (m Model) startLocalConfig(tunnel models.CLITunnel, local models.LocalConfig) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return errorMsg("Tunnel manager not initialized")
+		}
+		process, err := m.tunnelManager.StartLocalConfig(context.Background(), tunnel.Name, local)
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Failed to start %s: %v", tunnel.Name, err))
+		}
+		return statusMsg(fmt.Sprintf("Started %s with %s (PID %d)", tunnel.Name, local.Path, process.PID))
+	})
+}
+
+func (m Model) handleLocalConfigInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "L":
+		if m.localConfigError != nil {
+			m.statusMessage = "Discarded unsaved edits"
+		} else {
+			m.statusMessage = "Closed local config"
+		}
+		m.localConfigYAML = ""
+		m.localConfigError = nil
+
+	case "e":
+		if m.tunnelManager == nil {
+			m.errorMessage = "Tunnel manager not initialized"
+			return m, nil
+		}
+		return m, m.editLocalConfig()
+
+	case "s":
+		if m.localConfigError != nil {
+			m.statusMessage = "Fix or discard the unsaved edit before starting the tunnel"
+			return m, nil
+		}
+		tunnel := m.localConfigTunnel
+		m.localConfigYAML = ""
+		m.statusMessage = fmt.Sprintf("Checking %s's connectors...", tunnel.Name)
+		return m, m.checkReplicas(tunnel, func(m *Model) tea.Cmd {
+			m.statusMessage = fmt.Sprintf("Starting %s...", tunnel.Name)
+			return m.startLocalConfig(tunnel, m.localConfigs[tunnel.ID])
+		})
+	}
+
+	return m, nil
+}
+
+func (m Model) renderLocalConfig() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	codeStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	errorLineStyle := lipgloss.NewStyle().
+		Background(colors.errorColor).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	local := m.localConfigs[m.localConfigTunnel.ID]
+	lines := []string{titleStyle.Render(fmt.Sprintf("📄 %s (%s)", local.Path, m.localConfigTunnel.Name))}
+	if m.localConfigError != nil {
+		lines = append(lines, errorStyle.Render("✗ "+m.localConfigError.Error()), "")
+	}
+	for i, line := range strings.Split(strings.TrimRight(m.localConfigYAML, "\n"), "\n") {
+		numbered := fmt.Sprintf("%3d │ %s", i+1, line)
+		if m.localConfigError != nil && m.localConfigError.Line == i+1 {
+			lines = append(lines, errorLineStyle.Render(numbered))
+		} else {
+			lines = append(lines, codeStyle.Render(numbered))
+		}
+	}
+	hint := m.keys.Bound("e") + ": Edit in $EDITOR • s: Start tunnel with this config • Escape: Close"
+	if m.localConfigError != nil {
+		hint = m.keys.Bound("e") + ": Edit again • Escape: Discard changes"
+	}
+	lines = append(lines, "", hintStyle.Render(hint))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
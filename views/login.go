@@ -0,0 +1,104 @@
+package views
+
+import (
+	"fmt"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// notLoggedInMsg reports that cloudflared has no cert.pem, which the tunnel
+// list depends on unless api_only is set
+type notLoggedInMsg struct{}
+type cloudflaredLoginFinishedMsg struct {
+	err error
+}
+
+// runCloudflaredLogin hands the terminal to `cloudflared tunnel login`, which
+// prints a URL to authorize in the browser and writes cert.pem
+func (m Model) runCloudflaredLogin() tea.Cmd {
+	return tea.ExecProcess(models.CloudflaredLoginCommand(), func(err error) tea.Msg {
+		return cloudflaredLoginFinishedMsg{err: err}
+	})
+}
+
+// switchToAPIOnly saves api_only to config.json and reloads the tunnel list
+// through the API
+func (m *Model) switchToAPIOnly() tea.Cmd {
+	m.showLoginHelp = false
+	m.config.APIOnly = true
+	if err := m.config.Save(); err != nil {
+		m.errorMessage = fmt.Sprintf("Switched to API mode for this session, but failed to save config: %v", err)
+	} else {
+		m.statusMessage = "Switched to API mode (saved api_only to config.json)"
+	}
+	m.loading = true
+	return m.loadTunnels()
+}
+
+func (m *Model) handleCloudflaredLoginFinished(msg cloudflaredLoginFinishedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.errorMessage = fmt.Sprintf("cloudflared login failed: %v", msg.err)
+		return nil
+	}
+	m.showLoginHelp = false
+	m.errorMessage = ""
+	m.statusMessage = "cloudflared logged in - reloading tunnels..."
+	m.loading = true
+	return m.loadTunnels()
+}
+
+func (m Model) handleLoginHelpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "l":
+		return m, m.runCloudflaredLogin()
+
+	case "a":
+		return m, m.switchToAPIOnly()
+
+	case "esc", "escape":
+		m.showLoginHelp = false
+		m.statusMessage = "cloudflared is not logged in - tunnels can't be listed"
+	}
+
+	return m, nil
+}
+
+func (m Model) renderLoginHelp() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.warning).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(colors.success).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("🔑 cloudflared is not logged in"),
+		textStyle.Render("cloudflared couldn't find its origin certificate (cert.pem), so it can't list"),
+		textStyle.Render("or create tunnels. Pick one of:"),
+		"",
+		keyStyle.Render("  l")+textStyle.Render("  Run 'cloudflared tunnel login' now (authorize a zone in the browser)"),
+		keyStyle.Render("  a")+textStyle.Render("  Use API mode: list, inspect and delete tunnels with your API token only."),
+		textStyle.Render("     Saves \"api_only\": true to config.json. Creating tunnels still needs login."),
+		"",
+		hintStyle.Render(fmt.Sprintf("l: Login • %s: API mode • Escape: Dismiss • %s: Quit", m.keys.Bound("a"), m.keys.Bound("q"))),
+	)
+}
@@ -10,81 +10,298 @@ import (
 
 	"tunnelman/models"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 type Model struct {
-	state                 *models.AppState
-	client                *models.CloudflareClient
-	tunnelManager         *models.TunnelManager
-	activeTab             int
-	tabs                  []string
-	width                 int
-	height                int
-	statusMessage         string
-	errorMessage          string
-	showHelp              bool
-	tunnelsList           []models.CLITunnel
-	dnsList               []models.DNSRecord
-	selectedTunnel        int
-	loading               bool
-	lastUpdate            time.Time
-	showTunnelHostnames   bool
-	tunnelHostnames       []models.PublicHostname
-	selectedTunnelName    string
-	selectedTunnelID      string
-	showAddHostname       bool
-	showEditHostname      bool
-	selectedHostname      models.PublicHostname
-	selectedHostnameIndex int
-	textInputs            []textinput.Model
-	focusIndex            int
-	availableDomains      []string
-	selectedDomainIndex   int
-	tunnelDomainCounts    map[string]int
-	tunnelStatuses        map[string]models.TunnelStatus
-	showDeleteConfirm     bool
-	deleteTarget          string // "hostname" or "tunnel"
+	state                  *models.AppState
+	config                 *models.Config
+	client                 *models.CloudflareClient
+	tunnelManager          *models.TunnelManager
+	activeTab              int
+	tabs                   []string
+	width                  int
+	height                 int
+	statusMessage          string
+	errorMessage           string
+	warningMessage         string
+	showHelp               bool
+	tunnelsList            []models.CLITunnel
+	dnsList                []models.DNSRecord
+	selectedTunnel         int
+	loading                bool
+	lastUpdate             time.Time
+	showTunnelHostnames    bool
+	tunnelHostnames        []models.PublicHostname
+	tunnelRoutes           []models.TunnelRoute
+	warpRoutingEnabled     bool
+	selectedTunnelName     string
+	selectedTunnelID       string
+	showAddHostname        bool
+	showEditHostname       bool
+	selectedHostname       models.PublicHostname
+	selectedHostnameIndex  int
+	textInputs             []textinput.Model
+	focusIndex             int
+	availableDomains       []string
+	domainsLoadedAt        time.Time
+	domainsRefreshing      bool
+	selectedDomainIndex    int
+	tunnelDomainCounts     map[string]int
+	tunnelStatuses         map[string]models.TunnelStatus
+	statusHistory          map[string]*models.StatusHistory
+	uptimeLog              *models.UptimeLog
+	tunnelCache            *models.TunnelCache
+	tunnelsStale           bool
+	showUptimeReport       bool
+	expiring               bool
+	recentServiceIndex     int
+	presetIndex            int
+	serviceLimits          []models.ServiceLimitation
+	serviceLimitsAck       string
+	showSearch             bool
+	searchInput            textinput.Model
+	allHostnames           []models.TunnelHostname
+	selectedSearchResult   int
+	pendingHostnameSelect  string
+	showTypedConfirm       bool
+	typedConfirmInput      textinput.Model
+	typedConfirmHostname   string
+	typedConfirmAction     string
+	pendingAction          tea.Cmd
+	showCleanup            bool
+	staleTunnels           []models.CLITunnel
+	cleanupSelected        map[string]bool
+	cleanupCursor          int
+	cleanupConfirm         bool
+	cleanupResults         map[string]error
+	cleanupDeleting        int
+	restoringUI            bool
+	showExportPrompt       bool
+	configDiff             *configDiffMsg
+	originProbes           map[string]models.ProbeResult
+	probing                bool
+	tunnelMetadata         map[string]models.TunnelMetadata
+	confirmDialog          *confirmDialog
+	taskQueues             map[string][]queuedTask
+	serviceEdit            *models.PublicHostname
+	serviceEditInput       textinput.Model
+	pathRulesHostname      string
+	pathRulesInput         textinput.Model
+	selectedProcess        int
+	processStats           map[string]models.ProcessStats
+	processBreaches        map[string]int
+	localConfigs           map[string]models.LocalConfig
+	localConfigTunnel      models.CLITunnel
+	localConfigYAML        string
+	localConfigError       *models.ConfigError
+	showIngressSim         bool
+	ingressSimInput        textinput.Model
+	ingressSimRules        []models.TunnelConfigIngress
+	requestSamplesHostname string
+	requestSamples         []models.RequestSample
+	requestSamplesErr      error
+	requestSamplesLoading  bool
+	tunnelPrefix           string
+	tunnelPages            int
+	moreTunnels            bool
+	loadingMoreTunnels     bool
+	showTunnelFilter       bool
+	tunnelFilterInput      textinput.Model
+	dnsZones               []models.DNSZone
+	dnsZonesLoading        bool
+	dnsRecords             map[string][]models.DNSRecord
+	dnsExpanded            map[string]bool
+	dnsLoadingZones        map[string]bool
+	selectedDNSRow         int
+	applySnippet           string
+	proxySnippetHostname   string
+	proxySnippetKind       string
+	proxySnippetRule       *models.TunnelConfigIngress
+	showInventory          bool
+	inventoryDNS           map[string]models.DNSStatus
+	inventorySort          int
+	inventoryDesc          bool
+	selectedInventoryRow   int
+	showAudit              bool
+	auditReport            *models.AuditReport
+	selectedAuditRow       int
+	showLoginHelp          bool
+	showNewTunnel          bool
+	newTunnelInputs        []textinput.Model
+	newTunnelFocus         int
+	newTunnelStart         bool
+	propagating            map[string]time.Time
+	propagationChecks      map[string]bool
+	dnsCheckHostname       string
+	dnsCheck               *models.DNSVerification
+	inFlight               *inFlightOps
+	selfTestTunnel         string
+	selfTest               *models.SelfTestResult
+	runArgsTunnel          string
+	runArgsInput           textinput.Model
+	runURLTunnel           string
+	runURLInput            textinput.Model
+	authUsersHostname      string
+	authUsersIndex         int
+	authUserAdding         bool
+	authUserInput          textinput.Model
+	authUserReveal         string
+	remoteLogs             *remoteLogSession
+	remoteLogLines         []models.RemoteLogLine
+	remoteLogErr           string
+	connectorEvents        map[string]*models.ConnectorEventLog
+	showEnvironments       bool
+	environmentIndex       int
+	startupProbe           *models.StartupProbe
+	startupBanner          bool
+	hostnamePreviews       map[string]hostnamePreview
+	spinner                spinner.Model
+	spinning               bool
+	tunnelScroll           int
+	showPalette            bool
+	paletteInput           textinput.Model
+	paletteIndex           int
+	ingressProblems        map[string]models.IngressOrderProblem
+	keys                   *models.KeyMap
+	toasts                 []toast
+	toastHistory           []toast
+	showToastHistory       bool
 }
 
 type tickMsg time.Time
 type tunnelsLoadedMsg []models.CLITunnel
 type dnsLoadedMsg []models.DNSRecord
 type tunnelHostnamesLoadedMsg []models.PublicHostname
+type tunnelDetailsLoadedMsg struct {
+	tunnelID     string
+	routes       []models.TunnelRoute
+	warpRouting  bool
+	ingressOrder *models.IngressOrderProblem
+}
+type warpRoutingToggledMsg struct {
+	tunnelID string
+	enabled  bool
+}
 type domainsLoadedMsg []string
 type tunnelDomainCountsLoadedMsg map[string]int
 type tunnelStatusesLoadedMsg map[string]models.TunnelStatus
+type tunnelMetadataLoadedMsg map[string]models.TunnelMetadata
 type errorMsg string
 type statusMsg string
 type hostnameDeletedMsg struct {
 	message  string
 	tunnelID string
+	hostname string
 }
 type hostnameAuthToggledMsg struct {
 	hostname models.PublicHostname
 	tunnelID string
+	// users are the logins auth was enabled with; password is set when the
+	// default login was generated for it
+	users    []models.AuthUser
+	password string
+}
+type maintenanceToggledMsg struct {
+	hostname        string
+	service         string
+	originalService string
+	enabled         bool
+}
+type hostnameCreatedMsg struct {
+	hostname  string
+	tunnelID  string
+	path      string
+	expiresAt time.Time
+	folder    *models.ServedFolder
+}
+type hostnameUpdatedMsg struct {
+	tunnelID  string
+	previous  string
+	hostname  string
+	path      string
+	expiresAt time.Time
+}
+type hostnameSmokeTestedMsg models.SmokeTestResult
+type hostnamesExpiredMsg struct {
+	removed []string
+	failed  map[string]string
+}
+type originsProbedMsg map[string]models.ProbeResult
+type accessClientToggledMsg struct {
+	hostname string
+	address  string
+	running  bool
+}
+type browserRenderingToggledMsg struct {
+	hostname    models.PublicHostname
+	launcherURL string
 }
 
-func NewModel(state *models.AppState, client *models.CloudflareClient, tunnelManager *models.TunnelManager) Model {
-	return Model{
+func NewModel(state *models.AppState, client *models.CloudflareClient, tunnelManager *models.TunnelManager, config *models.Config) Model {
+	// A corrupt or unreadable uptime log shouldn't block startup; start a fresh one
+	uptimeLog, _ := models.LoadUptimeLog("")
+	// Same for the warm-start cache: without it the first refresh just takes longer to show
+	tunnelCache, _ := models.LoadTunnelCache("")
+
+	if client != nil && state.GetSelectedDomain() != "" {
+		client.SetSelectedDomain(state.GetSelectedDomain())
+	}
+
+	m := Model{
 		state:              state,
+		config:             config,
 		client:             client,
 		tunnelManager:      tunnelManager,
-		tabs:               []string{"Tunnels"},
+		tabs:               []string{"Tunnels", "Processes", "DNS"},
 		activeTab:          0,
 		statusMessage:      "Ready",
 		lastUpdate:         time.Now(),
 		tunnelDomainCounts: make(map[string]int),
 		tunnelStatuses:     make(map[string]models.TunnelStatus),
+		statusHistory:      make(map[string]*models.StatusHistory),
+		taskQueues:         make(map[string][]queuedTask),
+		inFlight:           newInFlightOps(),
+		connectorEvents:    make(map[string]*models.ConnectorEventLog),
+		hostnamePreviews:   make(map[string]hostnamePreview),
+		ingressProblems:    make(map[string]models.IngressOrderProblem),
+		spinner:            newLoadingSpinner(),
+		uptimeLog:          uptimeLog,
+		tunnelCache:        tunnelCache,
+		showHelp:           state.UI.ShowHelp,
+		restoringUI:        true,
+		startupBanner:      true,
+	}
+	if config != nil {
+		m.tunnelPrefix = config.TunnelNamePrefix
+	}
+	keys, err := config.KeyMap(reservedKeys())
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Using the default keys: %v", err)
 	}
+	m.keys = keys
+	theme, err := config.LoadTheme()
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Using the dark theme: %v", err)
+	}
+	colors = newPalette(theme)
+	m.warmStart()
+	m.startNotFoundServer()
+	m.startServedFolders()
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		tickCmd(),
 		m.loadTunnels(),
+		m.adoptDockerConnectors(),
+		m.detectServiceTunnels(),
+		m.probeStartup(),
+		m.startPolling(),
 	)
 }
 
@@ -95,6 +312,10 @@ func tickCmd() tea.Cmd {
 }
 
 func (m Model) loadTunnels() tea.Cmd {
+	if m.usePagedTunnels() {
+		return m.loadTunnelPage(1)
+	}
+
 	return tea.Cmd(func() tea.Msg {
 		if m.client == nil {
 			return errorMsg("Cloudflare client not initialized - check your API credentials")
@@ -103,10 +324,10 @@ func (m Model) loadTunnels() tea.Cmd {
 		ctx := context.Background()
 		tunnels, err := m.client.ListTunnels(ctx)
 		if err != nil {
-			if models.IsAuthenticationError(err) {
-				return errorMsg("Authentication failed - check API key/token and permissions")
+			if models.IsNotLoggedInError(err) {
+				return notLoggedInMsg{}
 			}
-			return errorMsg(fmt.Sprintf("Failed to load tunnels: %v", err))
+			return apiErrorMsg("load tunnels", err)
 		}
 		return tunnelsLoadedMsg(tunnels)
 	})
@@ -121,24 +342,113 @@ func (m Model) loadTunnelHostnames(tunnelID string) tea.Cmd {
 		ctx := context.Background()
 		hostnames, err := m.client.GetPublicHostnames(ctx, tunnelID)
 		if err != nil {
-			if models.IsAuthenticationError(err) {
-				return errorMsg("Authentication failed - check API key/token and permissions")
-			}
-			return errorMsg(fmt.Sprintf("Failed to load public hostnames: %v", err))
+			return apiErrorMsg("load public hostnames", err)
 		}
 
+		m.client.AnnotateBrowserRendering(ctx, hostnames)
+
 		return tunnelHostnamesLoadedMsg(hostnames)
 	})
 }
 
+// probeOrigins checks every hostname's service with a bounded worker pool and
+// reports the whole sweep in a single message
+func (m Model) probeOrigins(hostnames []models.PublicHostname) tea.Cmd {
+	services := make([]string, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		services = append(services, hostname.Service)
+	}
+
+	return tea.Cmd(func() tea.Msg {
+		return originsProbedMsg(models.ProbeOrigins(context.Background(), services, models.DefaultProbeWorkers, models.DefaultProbeTimeout))
+	})
+}
+
+// toggleAccessClient starts or stops a `cloudflared access tcp` client for a
+// tcp:// hostname
+func (m Model) toggleAccessClient(hostname models.PublicHostname) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return errorMsg("Tunnel manager not initialized")
+		}
+
+		if _, running := m.tunnelManager.GetAccessProcess(hostname.Hostname); running {
+			if err := m.tunnelManager.StopAccessTCP(hostname.Hostname); err != nil {
+				return errorMsg(fmt.Sprintf("Failed to stop access client: %v", err))
+			}
+			return accessClientToggledMsg{hostname: hostname.Hostname}
+		}
+
+		process, err := m.tunnelManager.StartAccessTCP(hostname.Hostname, hostname.Service, 0)
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Failed to start access client: %v", err))
+		}
+		return accessClientToggledMsg{hostname: hostname.Hostname, address: process.LocalAddress(), running: true}
+	})
+}
+
+func (m Model) loadTunnelDetails(tunnelID string) tea.Cmd {
+	return tea.Batch(m.loadConnectors(tunnelID), func() tea.Msg {
+		if m.client == nil {
+			return nil
+		}
+
+		ctx := context.Background()
+		details := tunnelDetailsLoadedMsg{tunnelID: tunnelID}
+
+		// Private routes need extra token permissions; treat failures as "no routes"
+		if routes, err := m.client.GetTunnelRoutes(ctx, tunnelID); err == nil {
+			details.routes = routes
+		}
+		if enabled, err := m.client.GetWarpRouting(ctx, tunnelID); err == nil {
+			details.warpRouting = enabled
+		}
+		if problem, err := m.client.GetIngressOrderProblem(ctx, tunnelID); err == nil {
+			details.ingressOrder = &problem
+		}
+
+		return details
+	})
+}
+
+func (m Model) toggleWarpRouting(tunnelID string, enabled bool) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		ctx := context.Background()
+		if err := m.client.SetWarpRouting(ctx, tunnelID, enabled); err != nil {
+			return apiErrorMsg("update WARP routing", err)
+		}
+
+		return warpRoutingToggledMsg{tunnelID: tunnelID, enabled: enabled}
+	})
+}
+
 func (m Model) toggleHostnameAuth(tunnelID, hostname string) tea.Cmd {
+	users := m.state.AuthUsersFor(hostname)
 	return tea.Cmd(func() tea.Msg {
 		if m.client == nil {
 			return errorMsg("Cloudflare client not initialized - check your API credentials")
 		}
 
+		// Hostnames without recorded logins get the default user, made only
+		// once auth is known to be turning on
+		var password string
+		logins := func() ([]models.AuthUser, error) {
+			if len(users) == 0 {
+				user, generated, err := models.NewAuthUser(models.AuthUsername)
+				if err != nil {
+					return nil, err
+				}
+				users, password = []models.AuthUser{user}, generated
+			}
+			return users, nil
+		}
+
 		ctx := context.Background()
-		updatedHostname, err := m.client.ToggleHostnameAuth(ctx, tunnelID, hostname)
+		updatedHostname, err := m.client.ToggleHostnameAuth(ctx, tunnelID, hostname, logins)
 		if err != nil {
 			return errorMsg(fmt.Sprintf("Failed to toggle auth: %v", err))
 		}
@@ -146,6 +456,57 @@ func (m Model) toggleHostnameAuth(tunnelID, hostname string) tea.Cmd {
 		return hostnameAuthToggledMsg{
 			hostname: *updatedHostname,
 			tunnelID: tunnelID,
+			users:    users,
+			password: password,
+		}
+	})
+}
+
+func (m Model) toggleBrowserRendering(hostname models.PublicHostname) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		ctx := context.Background()
+		updatedHostname, err := m.client.ToggleBrowserRendering(ctx, hostname)
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Failed to toggle browser rendering: %v", err))
+		}
+
+		result := browserRenderingToggledMsg{hostname: *updatedHostname}
+		if updatedHostname.BrowserRendering {
+			result.launcherURL = m.client.AccessAppLauncherURL(ctx)
+		}
+		return result
+	})
+}
+
+func (m Model) toggleMaintenance(tunnelID string, hostname models.PublicHostname) tea.Cmd {
+	originalService, inMaintenance := m.state.MaintenanceOriginal(hostname.Hostname)
+	maintenanceService := m.config.MaintenanceService()
+
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		ctx := context.Background()
+		if inMaintenance {
+			if err := m.client.UpdatePublicHostname(ctx, tunnelID, hostname.Hostname, hostname.Hostname, hostname.Path, originalService); err != nil {
+				return errorMsg(fmt.Sprintf("Failed to end maintenance: %v", err))
+			}
+			return maintenanceToggledMsg{hostname: hostname.Hostname, service: originalService, enabled: false}
+		}
+
+		if err := m.client.UpdatePublicHostname(ctx, tunnelID, hostname.Hostname, hostname.Hostname, hostname.Path, maintenanceService); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to start maintenance: %v", err))
+		}
+		return maintenanceToggledMsg{
+			hostname:        hostname.Hostname,
+			service:         maintenanceService,
+			originalService: hostname.Service,
+			enabled:         true,
 		}
 	})
 }
@@ -159,17 +520,17 @@ func (m Model) loadDomains() tea.Cmd {
 		ctx := context.Background()
 		domains, err := m.client.GetAvailableDomains(ctx)
 		if err != nil {
-			if models.IsAuthenticationError(err) {
-				return errorMsg("Authentication failed - check API key/token and permissions")
-			}
-			return errorMsg(fmt.Sprintf("Failed to load domains: %v", err))
+			return apiErrorMsg("load domains", err)
 		}
 
 		return domainsLoadedMsg(domains)
 	})
 }
 
-func (m Model) loadTunnelDomainCounts() tea.Cmd {
+func (m Model) loadTunnelDomainCounts(tunnels []models.CLITunnel) tea.Cmd {
+	if len(tunnels) == 0 {
+		return nil
+	}
 	return tea.Cmd(func() tea.Msg {
 		if m.client == nil {
 			return errorMsg("Cloudflare client not initialized")
@@ -179,7 +540,7 @@ func (m Model) loadTunnelDomainCounts() tea.Cmd {
 		domainCounts := make(map[string]int)
 
 		// Count domains for each tunnel
-		for _, tunnel := range m.tunnelsList {
+		for _, tunnel := range tunnels {
 			hostnames, err := m.client.GetPublicHostnames(ctx, tunnel.ID)
 			if err != nil {
 				// If we can't get hostnames, set count to 0 instead of failing
@@ -214,7 +575,10 @@ func (m Model) updateSingleTunnelDomainCount(tunnelID string) tea.Cmd {
 	})
 }
 
-func (m Model) loadTunnelStatuses() tea.Cmd {
+func (m Model) loadTunnelStatuses(tunnels []models.CLITunnel) tea.Cmd {
+	if len(tunnels) == 0 {
+		return nil
+	}
 	return tea.Cmd(func() tea.Msg {
 		if m.client == nil {
 			return errorMsg("Cloudflare client not initialized")
@@ -224,7 +588,7 @@ func (m Model) loadTunnelStatuses() tea.Cmd {
 		statuses := make(map[string]models.TunnelStatus)
 
 		// Get status for each tunnel
-		for _, tunnel := range m.tunnelsList {
+		for _, tunnel := range tunnels {
 			status, err := m.client.GetTunnelStatus(ctx, tunnel.ID)
 			if err != nil {
 				// If we can't get status, set to unknown instead of failing
@@ -238,23 +602,97 @@ func (m Model) loadTunnelStatuses() tea.Cmd {
 	})
 }
 
-func (m Model) createTunnelHostname(hostname, path, service string) tea.Cmd {
+// loadTunnelMetadata fetches Cloudflare's own record of each tunnel. Failures
+// are ignored since the CLI-derived status is still usable.
+func (m Model) loadTunnelMetadata() tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return nil
+		}
+
+		metadata, err := m.client.ListTunnelMetadata(context.Background())
+		if err != nil {
+			return nil
+		}
+		return tunnelMetadataLoadedMsg(metadata)
+	})
+}
+
+func (m Model) createTunnelHostname(hostname, path, service string, originRequest map[string]interface{}, ttl time.Duration) tea.Cmd {
+	tunnelID := m.selectedTunnelID
+	tunnels := m.tunnelsList
+
+	return m.cancellable("create "+hostname, func(ctx context.Context) tea.Msg {
 		if m.client == nil {
 			return errorMsg("Cloudflare client not initialized - check your API credentials")
 		}
 
-		ctx := context.Background()
-		err := m.client.AddPublicHostname(ctx, m.selectedTunnelID, hostname, path, service)
+		// Refuse to create a hostname that another tunnel or DNS record already owns
+		conflict, err := m.client.CheckHostnameConflict(ctx, hostname, tunnelID, tunnels)
 		if err != nil {
-			return errorMsg(fmt.Sprintf("Failed to create public hostname: %v", err))
+			return apiErrorMsg("check for hostname conflicts", err)
+		}
+		if conflict != nil {
+			return errorMsg(fmt.Sprintf("Not created: %v", conflict))
+		}
+
+		// folder:<path> services are served by tunnelman itself
+		var folder *models.ServedFolder
+		if root, isFolder := models.FolderServiceRoot(service); isFolder {
+			if m.tunnelManager == nil {
+				return errorMsg("Tunnel manager not initialized")
+			}
+			root, err = models.ResolveServedPath(root)
+			if err != nil {
+				return errorMsg(fmt.Sprintf("Failed to serve folder: %v", err))
+			}
+			server, err := m.tunnelManager.ServeFolder(hostname, root, 0)
+			if err != nil {
+				return errorMsg(fmt.Sprintf("Failed to serve folder: %v", err))
+			}
+			folder = &models.ServedFolder{Hostname: hostname, Root: root, Port: server.Port}
+			service = server.URL()
 		}
 
-		return statusMsg(fmt.Sprintf("Successfully created public hostname: %s", hostname))
+		err = m.client.AddPublicHostnameWithOptions(ctx, tunnelID, hostname, path, service, originRequest)
+		if err != nil {
+			if folder != nil {
+				m.tunnelManager.StopStaticServer(models.FolderServerKey(hostname))
+			}
+			return apiErrorMsg("create public hostname", err)
+		}
+
+		created := hostnameCreatedMsg{hostname: hostname, tunnelID: tunnelID, path: path, folder: folder}
+		if ttl > 0 {
+			created.expiresAt = time.Now().Add(ttl)
+		}
+		return created
 	})
 }
 
-func (m Model) updateTunnelHostname(hostname, path, service string) tea.Cmd {
+func (m Model) expireTemporaryHostnames(expired []models.TemporaryHostname) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return nil
+		}
+
+		ctx := context.Background()
+		result := hostnamesExpiredMsg{failed: make(map[string]string)}
+		for _, temp := range expired {
+			if err := m.client.RemoveHostnameWithDNS(ctx, temp.TunnelID, temp.Hostname, temp.Path); err != nil {
+				result.failed[temp.Hostname] = err.Error()
+				continue
+			}
+			result.removed = append(result.removed, temp.Hostname)
+		}
+
+		return result
+	})
+}
+
+// updateTunnelHostname updates the selected hostname. A ttl above zero
+// makes it temporary, recorded only once the update went through.
+func (m Model) updateTunnelHostname(hostname, path, service string, ttl time.Duration) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
 		if m.client == nil {
 			return errorMsg("Cloudflare client not initialized - check your API credentials")
@@ -263,10 +701,14 @@ func (m Model) updateTunnelHostname(hostname, path, service string) tea.Cmd {
 		ctx := context.Background()
 		err := m.client.UpdatePublicHostname(ctx, m.selectedTunnelID, m.selectedHostname.Hostname, hostname, path, service)
 		if err != nil {
-			return errorMsg(fmt.Sprintf("Failed to update public hostname: %v", err))
+			return apiErrorMsg("update public hostname", err)
 		}
 
-		return statusMsg(fmt.Sprintf("Successfully updated public hostname: %s", hostname))
+		msg := hostnameUpdatedMsg{tunnelID: m.selectedTunnelID, previous: m.selectedHostname.Hostname, hostname: hostname, path: path}
+		if ttl > 0 {
+			msg.expiresAt = time.Now().Add(ttl)
+		}
+		return msg
 	})
 }
 
@@ -285,7 +727,7 @@ func (m Model) deleteTunnelHostname() tea.Cmd {
 		ctx := context.Background()
 		err := m.client.RemovePublicHostname(ctx, m.selectedTunnelID, hostname, path)
 		if err != nil {
-			return errorMsg(fmt.Sprintf("Failed to delete public hostname: %v", err))
+			return apiErrorMsg("delete public hostname", err)
 		}
 
 		return statusMsg(fmt.Sprintf("Successfully deleted public hostname: %s", hostname))
@@ -293,7 +735,7 @@ func (m Model) deleteTunnelHostname() tea.Cmd {
 }
 
 func (m Model) deleteTunnelHostnameWithDNS() tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
+	return m.cancellable("delete "+m.selectedHostname.Hostname, func(ctx context.Context) tea.Msg {
 		if m.client == nil {
 			return errorMsg("Cloudflare client not initialized - check your API credentials")
 		}
@@ -304,12 +746,10 @@ func (m Model) deleteTunnelHostnameWithDNS() tea.Cmd {
 			path = "*"
 		}
 
-		ctx := context.Background()
-
 		// First remove the public hostname from tunnel config
 		err := m.client.RemovePublicHostname(ctx, m.selectedTunnelID, hostname, path)
 		if err != nil {
-			return errorMsg(fmt.Sprintf("Failed to delete public hostname: %v", err))
+			return apiErrorMsg("delete public hostname", err)
 		}
 
 		// Then try to delete the DNS record
@@ -321,12 +761,14 @@ func (m Model) deleteTunnelHostnameWithDNS() tea.Cmd {
 			return hostnameDeletedMsg{
 				message:  fmt.Sprintf("Deleted hostname: %s (DNS record deletion failed - might not exist or be managed externally)", hostname),
 				tunnelID: m.selectedTunnelID,
+				hostname: hostname,
 			}
 		}
 
 		return hostnameDeletedMsg{
 			message:  fmt.Sprintf("Successfully deleted hostname: %s and its DNS record", hostname),
 			tunnelID: m.selectedTunnelID,
+			hostname: hostname,
 		}
 	})
 }
@@ -342,22 +784,8 @@ func (m Model) openTunnelInBrowser(tunnelID string) tea.Cmd {
 			return errorMsg("Account ID not available")
 		}
 
-		url := fmt.Sprintf("https://one.dash.cloudflare.com/%s/networks/tunnels/cfd_tunnel/%s/edit?tab=publicHostname", accountID, tunnelID)
-
-		// Use different commands based on the operating system
-		var cmd *exec.Cmd
-		switch runtime.GOOS {
-		case "darwin":
-			cmd = exec.Command("open", url)
-		case "linux":
-			cmd = exec.Command("xdg-open", url)
-		case "windows":
-			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-		default:
-			return errorMsg(fmt.Sprintf("Unsupported operating system: %s", runtime.GOOS))
-		}
-
-		if err := cmd.Start(); err != nil {
+		url := m.client.TunnelDashboardURL(tunnelID) + "?tab=publicHostname"
+		if err := openInBrowser(url); err != nil {
 			return errorMsg(fmt.Sprintf("Failed to open browser: %v", err))
 		}
 
@@ -365,7 +793,92 @@ func (m Model) openTunnelInBrowser(tunnelID string) tea.Cmd {
 	})
 }
 
+// openHostnameInBrowser opens the public hostname itself
+func openHostnameInBrowser(hostname string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		url := "https://" + hostname
+		if err := openInBrowser(url); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to open browser: %v", err))
+		}
+		return statusMsg("Opened " + url + " in browser")
+	})
+}
+
+// openInBrowser opens url with the operating system's default browser
+func openInBrowser(url string) error {
+	// Use different commands based on the operating system
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux":
+		cmd = exec.Command("xdg-open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return fmt.Errorf("unsupported operating system %s", runtime.GOOS)
+	}
+	return cmd.Start()
+}
+
+// openTunnelHostnames switches to the hostname list of the selected tunnel
+func (m *Model) openTunnelHostnames() tea.Cmd {
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	if tunnel.ID != m.selectedTunnelID {
+		m.selectedHostnameIndex = 0
+	}
+	m.selectedTunnelName = tunnel.Name
+	m.selectedTunnelID = tunnel.ID
+	m.showTunnelHostnames = true
+	m.loading = true
+	m.statusMessage = fmt.Sprintf("Loading public hostnames for tunnel: %s", tunnel.Name)
+	cmds := []tea.Cmd{m.loadTunnelHostnames(tunnel.ID), m.loadTunnelDetails(tunnel.ID)}
+	// Zones are needed for the ZONE column
+	if m.domainsStale() {
+		cmds = append(cmds, m.loadDomains())
+	}
+	return tea.Batch(cmds...)
+}
+
+// openAddHostname opens the add-hostname form for the selected tunnel
+func (m *Model) openAddHostname() tea.Cmd {
+	m.showAddHostname = true
+	m.initializeTextInputs()
+	m.preselectTunnelDomain()
+	m.statusMessage = fmt.Sprintf("Enter hostname for new public hostname on %s", m.selectedTunnelName)
+	// Load domains unless the cached list is still fresh
+	if m.domainsStale() {
+		return m.loadDomains()
+	}
+	return nil
+}
+
+// closeAddHostname leaves the hostname form. Opened from the tunnel list,
+// it also lets go of the tunnel it was adding to.
+func (m *Model) closeAddHostname() {
+	m.showAddHostname = false
+	m.showEditHostname = false
+	m.textInputs = nil
+	m.focusIndex = 0
+	if !m.showTunnelHostnames {
+		m.selectedTunnelName = ""
+		m.selectedTunnelID = ""
+	}
+}
+
+// Update hands msg to update and shows the errors and warnings it left
+// behind as toasts
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.update(msg)
+	next, ok := updated.(Model)
+	if !ok {
+		return updated, cmd
+	}
+	toasts := next.collectToasts()
+	return next, tea.Batch(cmd, toasts)
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -375,7 +888,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state.UpdateWindowSize(msg.Width, msg.Height)
 
 	case tea.KeyMsg:
-		if m.loading {
+		// The launch summary stays up until the first key press
+		m.startupBanner = false
+
+		// A pending config diff blocks a running command, so it takes
+		// priority over the loading state
+		if m.configDiff != nil {
+			return m.handleConfigDiffInput(msg)
+		}
+
+		// Escape cancels running creates and deletes before it closes anything
+		if (msg.String() == "esc" || msg.String() == "escape") && m.cancelInFlight() {
+			return m, nil
+		}
+
+		// Help covers the whole screen, so it takes keys before the screen
+		// under it. F1 opens it from anywhere, forms included.
+		if m.showHelp {
+			return m.handleHelpInput(msg)
+		}
+		if msg.String() == "f1" {
+			m.showHelp = true
+			m.state.ToggleHelp()
 			return m, nil
 		}
 
@@ -384,9 +918,144 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleFormInput(msg)
 		}
 
-		switch msg.String() {
+		if m.showNewTunnel {
+			return m.handleNewTunnelInput(msg)
+		}
+
+		if m.serviceEdit != nil {
+			return m.handleServiceEditInput(msg)
+		}
+
+		if m.pathRulesHostname != "" {
+			return m.handlePathRulesInput(msg)
+		}
+
+		if m.showSearch {
+			return m.handleSearchInput(msg)
+		}
+
+		if m.showInventory {
+			return m.handleInventoryInput(msg)
+		}
+
+		if m.showLoginHelp {
+			return m.handleLoginHelpInput(msg)
+		}
+
+		if m.showAudit {
+			return m.handleAuditInput(msg)
+		}
+
+		if m.confirmDialog != nil {
+			return m.handleConfirmInput(msg)
+		}
+
+		if m.showTypedConfirm {
+			return m.handleTypedConfirmInput(msg)
+		}
+
+		if m.showCleanup {
+			return m.handleCleanupInput(msg)
+		}
+
+		if m.showExportPrompt {
+			switch msg.String() {
+			case "m":
+				m.exportView(models.ExportMarkdown)
+			case "c":
+				m.exportView(models.ExportCSV)
+			default:
+				m.showExportPrompt = false
+				m.statusMessage = "Export cancelled"
+			}
+			return m, nil
+		}
+
+		if m.localConfigYAML != "" {
+			return m.handleLocalConfigInput(msg)
+		}
+
+		if m.applySnippet != "" {
+			return m.handleApplySnippetInput(msg)
+		}
+
+		if m.proxySnippetHostname != "" {
+			return m.handleProxySnippetInput(msg)
+		}
+
+		if m.dnsCheckHostname != "" {
+			return m.handleDNSCheckInput(msg)
+		}
+
+		if m.selfTestTunnel != "" {
+			return m.handleSelfTestInput(msg)
+		}
+
+		if m.runArgsTunnel != "" {
+			return m.handleRunArgsInput(msg)
+		}
+
+		if m.runURLTunnel != "" {
+			return m.handleRunURLInput(msg)
+		}
+
+		if m.authUsersHostname != "" {
+			return m.handleAuthUsersInput(msg)
+		}
+
+		if m.remoteLogs != nil {
+			return m.handleRemoteLogsInput(msg)
+		}
+
+		if m.showEnvironments {
+			return m.handleEnvironmentsInput(msg)
+		}
+
+		if m.showPalette {
+			return m.handlePaletteInput(msg)
+		}
+
+		if m.showToastHistory {
+			return m.handleToastHistoryInput(msg)
+		}
+
+		if m.showIngressSim {
+			return m.handleIngressSimInput(msg)
+		}
+
+		if m.showTunnelFilter {
+			return m.handleTunnelFilterInput(msg)
+		}
+
+		if m.requestSamplesHostname != "" {
+			return m.handleRequestSamplesInput(msg)
+		}
+
+		if m.activeTab == processesTab && !m.showTunnelHostnames {
+			return m.handleProcessesInput(msg)
+		}
+
+		if m.activeTab == dnsTab && !m.showTunnelHostnames {
+			return m.handleDNSInput(msg)
+		}
+
+		// Rebound keys are handled as the built-in key of their action
+		switch m.keys.Translate(msg.String()) {
 		case "ctrl+c", "q":
-			return m, tea.Quit
+			return m, m.quit()
+
+		case "shift+tab":
+			if !m.showTunnelHostnames {
+				cmds = append(cmds, m.openDNSTab())
+			}
+
+		case "tab":
+			if !m.showTunnelHostnames {
+				m.activeTab = processesTab
+				m.selectedProcess = 0
+				m.statusMessage = "Showing managed processes"
+				cmds = append(cmds, m.loadProcessStats())
+			}
 
 		case "h", "?":
 			m.showHelp = !m.showHelp
@@ -399,14 +1068,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.showTunnelHostnames && m.selectedTunnelID != "" {
 				// Refresh hostname list if we're viewing hostnames
 				cmds = append(cmds, m.loadTunnelHostnames(m.selectedTunnelID))
+				cmds = append(cmds, m.loadTunnelDetails(m.selectedTunnelID))
 			} else {
 				// Refresh tunnel list if we're in main view
+				m.hostnamePreviews = make(map[string]hostnamePreview)
 				cmds = append(cmds, m.loadTunnels())
+				cmds = append(cmds, m.loadTunnelStatuses(m.tunnelsList))
+				cmds = append(cmds, m.loadTunnelDomainCounts(m.tunnelsList))
+				cmds = append(cmds, m.detectServiceTunnels())
 			}
 
 		case "c":
-			m.errorMessage = ""
-			m.statusMessage = "Error cleared"
+			m.toasts = nil
+			m.statusMessage = "Notifications dismissed"
+
+		case "!":
+			m.showToastHistory = true
+			m.statusMessage = "Error history"
 
 		case "up", "k":
 			if m.showTunnelHostnames {
@@ -424,32 +1102,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			} else if m.selectedTunnel < len(m.tunnelsList)-1 {
 				m.selectedTunnel++
+			} else {
+				cmds = append(cmds, m.loadMoreTunnels())
 			}
 
 		case "d":
-			if m.showDeleteConfirm {
-				// Handle deletion confirmation
-				if m.deleteTarget == "hostname" {
-					m.loading = true
-					m.statusMessage = fmt.Sprintf("Deleting public hostname: %s", m.selectedHostname.Hostname)
-					cmds = append(cmds, m.deleteTunnelHostnameWithDNS())
-				} else if m.deleteTarget == "tunnel" {
-					cmds = append(cmds, m.deleteTunnel())
-				}
-				m.showDeleteConfirm = false
-				m.deleteTarget = ""
-			} else if m.showTunnelHostnames && !m.showAddHostname && !m.showEditHostname && len(m.tunnelHostnames) > 0 {
-				// Show confirmation for hostname deletion
-				m.selectedHostname = m.tunnelHostnames[m.selectedHostnameIndex]
-				m.showDeleteConfirm = true
-				m.deleteTarget = "hostname"
-				m.statusMessage = fmt.Sprintf("Delete hostname %s and its DNS record? Press 'd' to confirm, 'esc' to cancel", m.selectedHostname.Hostname)
+			if m.showTunnelHostnames && !m.showAddHostname && !m.showEditHostname && len(m.tunnelHostnames) > 0 {
+				cmds = append(cmds, m.confirmDeleteHostname())
 			} else if !m.showTunnelHostnames && len(m.tunnelsList) > 0 {
-				// Show confirmation for tunnel deletion
-				m.showDeleteConfirm = true
-				m.deleteTarget = "tunnel"
-				tunnel := m.tunnelsList[m.selectedTunnel]
-				m.statusMessage = fmt.Sprintf("Delete tunnel %s? Press 'd' to confirm, 'esc' to cancel", tunnel.Name)
+				cmds = append(cmds, m.confirmDeleteTunnel())
 			}
 
 		case " ":
@@ -459,48 +1120,146 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedTunnelName = ""
 				m.selectedTunnelID = ""
 				m.tunnelHostnames = nil
+				m.tunnelRoutes = nil
+				m.warpRoutingEnabled = false
 				m.statusMessage = "Returned to main view"
 			} else if m.activeTab == 0 && len(m.tunnelsList) > 0 {
 				// Show public hostnames for selected tunnel
+				cmds = append(cmds, m.openTunnelHostnames())
+			}
+
+		case "W": // Shift+W for WARP routing toggle
+			if m.showTunnelHostnames && m.selectedTunnelID != "" {
+				m.confirmWarpRouting()
+			}
+
+		case "G": // Shift+G for global hostname search
+			cmds = append(cmds, m.openSearch())
+
+		case "V": // Shift+V for the security review of exposed services
+			if !m.showTunnelHostnames {
+				cmds = append(cmds, m.openAudit())
+			}
+
+		case "H": // Shift+H for the table of every tunnel's hostnames
+			if !m.showTunnelHostnames {
+				cmds = append(cmds, m.openInventory())
+			}
+
+		case "E": // Shift+E to export the current table
+			if !m.showUptimeReport {
+				m.showExportPrompt = true
+				m.statusMessage = "Export as: m = Markdown, c = CSV (any other key cancels)"
+			}
+
+		case "p":
+			if !m.showTunnelHostnames {
+				m.togglePinnedTunnel()
+			}
+
+		case "n":
+			if !m.showTunnelHostnames && m.activeTab == 0 {
+				m.openNewTunnel()
+			}
+
+		case "t":
+			if !m.showTunnelHostnames && m.activeTab == 0 {
+				cmds = append(cmds, m.openSelfTest())
+			}
+
+		case "l":
+			if !m.showTunnelHostnames && m.activeTab == 0 {
+				cmds = append(cmds, m.openRemoteLogs())
+			}
+
+		case "g":
+			if !m.showTunnelHostnames && m.activeTab == 0 {
+				m.openEnvironments()
+			}
+
+		case "ctrl+p", ":":
+			m.openPalette()
+
+		case ">":
+			if !m.showTunnelHostnames && m.activeTab == 0 {
+				cmds = append(cmds, m.changeTunnelSort(false))
+			}
+
+		case "<":
+			if !m.showTunnelHostnames && m.activeTab == 0 {
+				cmds = append(cmds, m.changeTunnelSort(true))
+			}
+
+		case "O": // Shift+O to edit the tunnel's extra cloudflared run arguments, or open the hostname
+			if !m.showTunnelHostnames && m.activeTab == 0 {
+				m.openRunArgs()
+			} else if m.showTunnelHostnames && len(m.tunnelHostnames) > 0 {
+				cmds = append(cmds, openHostnameInBrowser(m.tunnelHostnames[m.selectedHostnameIndex].Hostname))
+			}
+
+		case "F": // Shift+F to filter tunnels by name prefix
+			if !m.showTunnelHostnames {
+				m.openTunnelFilter()
+			}
+
+		case "L": // Shift+L to view the tunnel's local cloudflared config
+			if !m.showTunnelHostnames {
+				m.openLocalConfig()
+			}
+
+		case "D": // Shift+D to run the connector in Docker
+			if !m.showTunnelHostnames && m.selectedTunnel < len(m.tunnelsList) {
 				tunnel := m.tunnelsList[m.selectedTunnel]
-				m.selectedTunnelName = tunnel.Name
-				m.selectedTunnelID = tunnel.ID
-				m.showTunnelHostnames = true
-				m.loading = true
-				m.statusMessage = fmt.Sprintf("Loading public hostnames for tunnel: %s", tunnel.Name)
-				cmds = append(cmds, m.loadTunnelHostnames(tunnel.ID))
+				if connector, ok := m.serviceConnector(tunnel); ok {
+					m.statusMessage = fmt.Sprintf("%s is %s", tunnel.Name, connector.Label())
+					break
+				}
+				m.statusMessage = fmt.Sprintf("Toggling Docker connector for %s...", tunnel.Name)
+				cmds = append(cmds, m.toggleDockerConnector(tunnel))
+			}
+
+		case "X": // Shift+X for stale tunnel cleanup, or a reverse proxy snippet for the hostname
+			if !m.showTunnelHostnames {
+				m.openCleanup()
+			} else if len(m.tunnelHostnames) > 0 {
+				cmds = append(cmds, m.openProxySnippet())
+			}
+
+		case "U": // Shift+U for uptime report
+			if !m.showTunnelHostnames {
+				m.showUptimeReport = !m.showUptimeReport
+				if m.showUptimeReport {
+					m.statusMessage = "Showing uptime report"
+				} else {
+					m.statusMessage = "Returned to tunnel list"
+				}
 			}
 
 		case "esc", "escape":
-			// Cancel delete confirmation if active
-			if m.showDeleteConfirm {
-				m.showDeleteConfirm = false
-				m.deleteTarget = ""
-				m.statusMessage = "Deletion cancelled"
-			} else if m.showHelp {
-				// Close help if open
-				m.showHelp = false
-				m.state.ToggleHelp()
-				m.statusMessage = "Closed help"
+			if m.showUptimeReport {
+				m.showUptimeReport = false
+				m.statusMessage = "Returned to tunnel list"
 			} else if m.showTunnelHostnames {
 				// Return from hostname list to tunnel list
 				m.showTunnelHostnames = false
 				m.selectedTunnelName = ""
 				m.selectedTunnelID = ""
 				m.tunnelHostnames = nil
+				m.tunnelRoutes = nil
+				m.warpRoutingEnabled = false
 				m.statusMessage = "Returned to tunnel list"
 			}
 			// Note: Escape from main tunnel list does nothing (use 'q' to quit)
 
 		case "a":
 			if m.showTunnelHostnames {
-				m.showAddHostname = true
-				m.initializeTextInputs()
-				m.statusMessage = "Enter hostname for new public hostname"
-				// Load domains if not already loaded
-				if len(m.availableDomains) == 0 {
-					cmds = append(cmds, m.loadDomains())
-				}
+				cmds = append(cmds, m.openAddHostname())
+			} else if m.activeTab == 0 && len(m.tunnelsList) > 0 {
+				// Straight from the tunnel list; the form returns to the list
+				tunnel := m.tunnelsList[m.selectedTunnel]
+				m.selectedTunnelName = tunnel.Name
+				m.selectedTunnelID = tunnel.ID
+				cmds = append(cmds, m.openAddHostname())
 			}
 
 		case "A": // Shift+A for auth toggle
@@ -508,7 +1267,104 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				hostname := m.tunnelHostnames[m.selectedHostnameIndex]
 				tunnel := m.tunnelsList[m.selectedTunnel]
 				m.statusMessage = "Toggling authentication..."
-				cmds = append(cmds, m.toggleHostnameAuth(tunnel.ID, hostname.Hostname))
+				task := queueTask(tunnel.ID, "toggle auth for "+hostname.Hostname, m.toggleHostnameAuth(tunnel.ID, hostname.Hostname))
+				cmds = append(cmds, m.guardHostname(hostname.Hostname, "modify", task))
+			}
+
+		case "u":
+			if m.showTunnelHostnames && !m.showAddHostname && !m.showEditHostname && len(m.tunnelHostnames) > 0 {
+				m.openAuthUsers()
+			}
+
+		case "B": // Shift+B for browser rendering toggle
+			if m.showTunnelHostnames && !m.showAddHostname && !m.showEditHostname && len(m.tunnelHostnames) > 0 {
+				hostname := m.tunnelHostnames[m.selectedHostnameIndex]
+				if models.BrowserRenderingType(hostname.Service) == "" {
+					m.statusMessage = "Browser rendering is only available for ssh:// and vnc:// services"
+				} else {
+					m.statusMessage = "Toggling browser rendering..."
+					task := queueTask(m.selectedTunnelID, "toggle browser rendering for "+hostname.Hostname, m.toggleBrowserRendering(hostname))
+					cmds = append(cmds, m.guardHostname(hostname.Hostname, "modify", task))
+				}
+			}
+
+		case "S": // Shift+S to add an SSH config entry for ssh:// hostnames
+			if m.showTunnelHostnames && len(m.tunnelHostnames) > 0 {
+				hostname := m.tunnelHostnames[m.selectedHostnameIndex]
+				if !strings.HasPrefix(hostname.Service, "ssh://") {
+					m.statusMessage = "SSH config is only available for ssh:// hostnames"
+				} else {
+					m.confirmSSHConfig(hostname.Hostname)
+				}
+			}
+
+		case "T": // Shift+T to start/stop a local access client for tcp:// hostnames
+			if m.showTunnelHostnames && len(m.tunnelHostnames) > 0 {
+				hostname := m.tunnelHostnames[m.selectedHostnameIndex]
+				if !strings.HasPrefix(hostname.Service, "tcp://") {
+					m.statusMessage = "Access clients are only available for tcp:// hostnames"
+				} else {
+					m.loading = true
+					m.statusMessage = fmt.Sprintf("Toggling access client for %s...", hostname.Hostname)
+					cmds = append(cmds, m.toggleAccessClient(hostname))
+				}
+			}
+
+		case "R": // Shift+R for recent requests to the selected hostname
+			if m.showTunnelHostnames {
+				cmds = append(cmds, m.openRequestSamples())
+			}
+
+		case "I": // Shift+I to test which ingress rule serves a URL
+			if m.showTunnelHostnames && m.selectedTunnelID != "" {
+				cmds = append(cmds, m.openIngressSimulator())
+			}
+
+		case "Y": // Shift+Y to show the tunnel's hostnames as apply YAML, or copy the tunnel's name
+			if m.showTunnelHostnames && m.selectedTunnelID != "" {
+				m.openApplySnippet()
+			} else if !m.showTunnelHostnames {
+				cmds = append(cmds, m.copySelection(true))
+			}
+
+		case "y":
+			cmds = append(cmds, m.copySelection(false))
+
+		case "C": // Shift+C to check the hostname's public DNS against the tunnel
+			if m.showTunnelHostnames && m.selectedTunnelID != "" {
+				cmds = append(cmds, m.openDNSCheck())
+			}
+
+		case "N": // Shift+N to toggle the tunnelman 404 page on the catch-all rule
+			if m.showTunnelHostnames && m.selectedTunnelID != "" {
+				m.statusMessage = "Updating catch-all rule..."
+				cmds = append(cmds, queueTask(m.selectedTunnelID, "update catch-all rule", m.toggleNotFoundPage(m.selectedTunnelID)))
+			}
+
+		case "f":
+			if m.showTunnelHostnames && m.ingressProblems[m.selectedTunnelID].Any() {
+				m.statusMessage = "Fixing ingress rule order..."
+				cmds = append(cmds, queueTask(m.selectedTunnelID, "fix ingress rule order", m.fixIngressOrder(m.selectedTunnelID)))
+			}
+
+		case "P": // Shift+P to split a hostname into path-based rules
+			if m.showTunnelHostnames && len(m.tunnelHostnames) > 0 {
+				m.openPathRules()
+			}
+
+		case "M": // Shift+M for maintenance mode toggle
+			if m.showTunnelHostnames && !m.showAddHostname && !m.showEditHostname && len(m.tunnelHostnames) > 0 {
+				hostname := m.tunnelHostnames[m.selectedHostnameIndex]
+				m.statusMessage = fmt.Sprintf("Toggling maintenance mode for %s...", hostname.Hostname)
+				task := queueTask(m.selectedTunnelID, "toggle maintenance for "+hostname.Hostname, m.toggleMaintenance(m.selectedTunnelID, hostname))
+				cmds = append(cmds, m.guardHostname(hostname.Hostname, "change maintenance mode for", task))
+			}
+
+		case "s":
+			if m.showTunnelHostnames && len(m.tunnelHostnames) > 0 {
+				m.startServiceEdit()
+			} else if !m.showTunnelHostnames && m.activeTab == 0 {
+				cmds = append(cmds, m.toggleTunnelRun())
 			}
 
 		case "e":
@@ -517,8 +1373,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedHostname = m.tunnelHostnames[m.selectedHostnameIndex]
 				m.initializeTextInputsForEdit()
 				m.statusMessage = "Editing public hostname"
-				// Load domains if not already loaded
-				if len(m.availableDomains) == 0 {
+				// Load domains unless the cached list is still fresh
+				if m.domainsStale() {
 					cmds = append(cmds, m.loadDomains())
 				}
 			}
@@ -526,13 +1382,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			if len(m.tunnelsList) > 0 && !m.showTunnelHostnames {
 				// Show public hostnames for selected tunnel
-				tunnel := m.tunnelsList[m.selectedTunnel]
-				m.selectedTunnelName = tunnel.Name
-				m.selectedTunnelID = tunnel.ID
-				m.showTunnelHostnames = true
-				m.loading = true
-				m.statusMessage = fmt.Sprintf("Loading public hostnames for tunnel: %s", tunnel.Name)
-				cmds = append(cmds, m.loadTunnelHostnames(tunnel.ID))
+				cmds = append(cmds, m.openTunnelHostnames())
 			}
 
 		case "o":
@@ -551,17 +1401,201 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		}
 
+	case configDiffMsg:
+		m.configDiff = &msg
+		m.statusMessage = fmt.Sprintf("Review %d ingress changes before they are applied", len(msg.changes))
+
+	case taskQueuedMsg:
+		cmds = append(cmds, m.enqueueTask(msg.task))
+
+	case taskDoneMsg:
+		cmds = append(cmds, m.finishTask(msg.tunnelID))
+		if msg.result != nil {
+			updated, cmd := m.Update(msg.result)
+			m = updated.(Model)
+			cmds = append(cmds, cmd)
+		}
+
 	case tickMsg:
-		cmds = append(cmds, tickCmd(), m.loadTunnels())
+		cmds = append(cmds, tickCmd())
+		if m.showTunnelHostnames && m.selectedTunnelID != "" {
+			cmds = append(cmds, m.loadConnectors(m.selectedTunnelID))
+		}
+		if m.activeTab == processesTab || m.config.HasProcessLimits() {
+			cmds = append(cmds, m.loadProcessStats())
+		}
 		m.lastUpdate = time.Time(msg)
+		cmds = append(cmds, m.checkPropagation())
+		if expired := m.state.ExpiredHostnames(time.Time(msg)); len(expired) > 0 && !m.expiring {
+			m.expiring = true
+			cmds = append(cmds, m.expireTemporaryHostnames(expired))
+		}
+
+	case hostnameCreatedMsg:
+		m.loading = false
+		m.statusMessage = fmt.Sprintf("Successfully created public hostname: %s", msg.hostname)
+		if msg.folder != nil {
+			m.state.AddServedFolder(*msg.folder)
+			m.state.Save()
+			m.statusMessage += fmt.Sprintf(" (serving %s while tunnelman runs)", msg.folder.Root)
+		}
+		if !msg.expiresAt.IsZero() {
+			m.state.AddTemporaryHostname(models.TemporaryHostname{
+				Hostname:  msg.hostname,
+				TunnelID:  msg.tunnelID,
+				Path:      msg.path,
+				ExpiresAt: msg.expiresAt,
+			})
+			m.state.Save()
+			m.statusMessage += fmt.Sprintf(" (expires %s)", msg.expiresAt.Format("15:04"))
+		}
+		if m.showTunnelHostnames && m.selectedTunnelID == msg.tunnelID {
+			cmds = append(cmds, m.loadTunnelHostnames(msg.tunnelID))
+		} else {
+			// Added from the tunnel list; the hostname pane refetches it
+			delete(m.hostnamePreviews, msg.tunnelID)
+		}
+		cmds = append(cmds, m.updateSingleTunnelDomainCount(msg.tunnelID))
+		m.trackPropagation(msg.hostname)
+		if smoke := m.smokeTestHostname(msg.hostname); smoke != nil {
+			m.statusMessage += " - waiting for it to go live..."
+			cmds = append(cmds, smoke)
+		}
+
+	case hostnameUpdatedMsg:
+		m.loading = false
+		m.statusMessage = fmt.Sprintf("Successfully updated public hostname: %s", msg.hostname)
+		// A blank TTL keeps the current expiry, which follows a rename
+		if previous, ok := m.state.GetTemporaryHostname(msg.previous); ok && msg.expiresAt.IsZero() {
+			msg.expiresAt = previous.ExpiresAt
+		}
+		changed := m.state.RemoveTemporaryHostname(msg.previous)
+		if !msg.expiresAt.IsZero() {
+			m.state.AddTemporaryHostname(models.TemporaryHostname{
+				Hostname:  msg.hostname,
+				TunnelID:  msg.tunnelID,
+				Path:      msg.path,
+				ExpiresAt: msg.expiresAt,
+			})
+			m.statusMessage += fmt.Sprintf(" (expires %s)", msg.expiresAt.Format("15:04"))
+			changed = true
+		}
+		if changed {
+			m.state.Save()
+		}
+		cmds = append(cmds, m.pushToast(toastSuccess, m.statusMessage))
+		if m.showTunnelHostnames && m.selectedTunnelID == msg.tunnelID {
+			cmds = append(cmds, m.loadTunnelHostnames(msg.tunnelID), m.updateSingleTunnelDomainCount(msg.tunnelID))
+		}
+
+	case hostnameSmokeTestedMsg:
+		m.handleHostnameSmokeTested(models.SmokeTestResult(msg))
+
+	case propagationCheckedMsg:
+		m.handlePropagationChecked(msg)
+
+	case dnsCheckedMsg:
+		m.handleDNSChecked(msg)
+
+	case proxyRuleLoadedMsg:
+		m.handleProxyRuleLoaded(msg)
+
+	case selfTestDoneMsg:
+		m.handleSelfTestDone(msg)
+
+	case remoteLogLineMsg:
+		cmds = append(cmds, m.handleRemoteLogLine(msg))
+
+	case remoteLogsEndedMsg:
+		m.handleRemoteLogsEnded(msg)
+
+	case connectorsLoadedMsg:
+		m.handleConnectorsLoaded(msg)
+
+	case replicaCheckMsg:
+		return m, m.handleReplicaCheck(msg)
+
+	case tunnelDeletedMsg:
+		if m.handleTunnelDeleted(msg) {
+			cmds = append(cmds, m.loadTunnels())
+		}
+
+	case hostnamesExpiredMsg:
+		m.expiring = false
+		for _, hostname := range msg.removed {
+			m.state.RemoveTemporaryHostname(hostname)
+			m.stopServedFolder(hostname)
+		}
+		m.state.Save()
+		if len(msg.removed) > 0 {
+			m.statusMessage = fmt.Sprintf("⏳ Removed expired hostnames: %s", strings.Join(msg.removed, ", "))
+			if m.showTunnelHostnames && m.selectedTunnelID != "" {
+				cmds = append(cmds, m.loadTunnelHostnames(m.selectedTunnelID))
+			}
+		}
+		for hostname, err := range msg.failed {
+			m.errorMessage = fmt.Sprintf("Failed to remove expired hostname %s: %s", hostname, err)
+		}
 
 	case tunnelsLoadedMsg:
+		// Keep the cursor on the same tunnel (or the open one) even if the
+		// refresh added, removed or reordered tunnels
+		selectedID := m.cursorTunnelID()
+		if m.showTunnelHostnames && m.selectedTunnelID != "" {
+			selectedID = m.selectedTunnelID
+		}
 		m.tunnelsList = []models.CLITunnel(msg)
+		m.sortTunnels()
+		m.reselectTunnel(selectedID)
 		m.loading = false
 		m.statusMessage = fmt.Sprintf("Loaded %d tunnels", len(m.tunnelsList))
-		// Load domain counts and statuses for each tunnel
-		cmds = append(cmds, m.loadTunnelDomainCounts())
-		cmds = append(cmds, m.loadTunnelStatuses())
+		if m.restoringUI {
+			cmds = append(cmds, m.restoreUIState())
+		}
+		// Statuses and domain counts are polled on their own schedule, so a
+		// list reload only fills them in for tunnels new to the list. The
+		// warm-start cache is replaced wholesale.
+		details := m.tunnelsMissingDetails()
+		if m.tunnelsStale {
+			details = m.tunnelsList
+		}
+		cmds = append(cmds, m.loadTunnelDomainCounts(details))
+		cmds = append(cmds, m.loadTunnelStatuses(details))
+		cmds = append(cmds, m.loadTunnelMetadata())
+		cmds = append(cmds, m.loadLocalConfigs(m.tunnelsList))
+
+	case tunnelCreatedMsg:
+		cmds = append(cmds, m.handleTunnelCreated(msg))
+
+	case localConfigsLoadedMsg:
+		m.handleLocalConfigsLoaded(msg)
+
+	case tunnelPageLoadedMsg:
+		return m.handleTunnelPageLoaded(msg)
+
+	case requestSamplesLoadedMsg:
+		if msg.hostname == m.requestSamplesHostname {
+			m.requestSamples = msg.samples
+			m.requestSamplesErr = msg.err
+			m.requestSamplesLoading = false
+		}
+
+	case ingressRulesLoadedMsg:
+		if msg.tunnelID == m.selectedTunnelID {
+			m.ingressSimRules = append([]models.TunnelConfigIngress{}, msg.rules...)
+		}
+
+	case localConfigEditedMsg:
+		m.handleLocalConfigEdited(msg)
+
+	case dnsZonesLoadedMsg:
+		cmds = append(cmds, m.handleDNSZonesLoaded(msg))
+
+	case dnsZoneCountsMsg:
+		m.handleDNSZoneCounts(msg)
+
+	case dnsZoneRecordsLoadedMsg:
+		m.handleDNSZoneRecordsLoaded(msg)
 
 	case dnsLoadedMsg:
 		m.dnsList = []models.DNSRecord(msg)
@@ -569,13 +1603,102 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMessage = fmt.Sprintf("Loaded %d DNS records", len(m.dnsList))
 
 	case tunnelHostnamesLoadedMsg:
+		selectedKey := m.cursorHostnameKey()
 		m.tunnelHostnames = []models.PublicHostname(msg)
 		m.loading = false
+		if m.selectedTunnelID != "" {
+			m.hostnamePreviews[m.selectedTunnelID] = hostnamePreview{hostnames: m.tunnelHostnames, loadedAt: time.Now()}
+		}
+		m.reselectHostname(selectedKey)
+		if m.pendingHostnameSelect != "" {
+			for i, hostname := range m.tunnelHostnames {
+				if hostname.Hostname == m.pendingHostnameSelect {
+					m.selectedHostnameIndex = i
+					break
+				}
+			}
+			m.pendingHostnameSelect = ""
+		}
 		m.statusMessage = fmt.Sprintf("Found %d public hostnames for tunnel: %s", len(m.tunnelHostnames), m.selectedTunnelName)
+		if !m.probing && len(m.tunnelHostnames) > 0 {
+			m.probing = true
+			cmds = append(cmds, m.probeOrigins(m.tunnelHostnames))
+		}
+
+	case accessClientToggledMsg:
+		m.loading = false
+		if msg.running {
+			m.statusMessage = fmt.Sprintf("Access client for %s listening on %s", msg.hostname, msg.address)
+		} else {
+			m.statusMessage = fmt.Sprintf("Stopped access client for %s", msg.hostname)
+		}
+
+	case originsProbedMsg:
+		m.probing = false
+		m.originProbes = msg
+
+	case allHostnamesLoadedMsg:
+		m.allHostnames = []models.TunnelHostname(msg)
+		if m.allHostnames == nil {
+			m.allHostnames = []models.TunnelHostname{}
+		}
+		if m.showSearch || m.showInventory {
+			m.statusMessage = fmt.Sprintf("Loaded %d hostnames across %d tunnels", len(m.allHostnames), len(m.tunnelsList))
+		}
+		if m.showInventory && len(m.allHostnames) > 0 {
+			cmds = append(cmds, m.loadInventoryDNS(m.allHostnames))
+		}
+
+	case notLoggedInMsg:
+		m.loading = false
+		m.showLoginHelp = true
+		m.statusMessage = "cloudflared is not logged in"
+
+	case cloudflaredLoginFinishedMsg:
+		cmds = append(cmds, m.handleCloudflaredLoginFinished(msg))
+
+	case auditLoadedMsg:
+		m.handleAuditLoaded(msg.report)
+
+	case inventoryDNSLoadedMsg:
+		if msg.err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to check DNS records: %v", msg.err)
+			m.inventoryDNS = map[string]models.DNSStatus{}
+		} else {
+			m.inventoryDNS = msg.statuses
+		}
+
+	case tunnelDetailsLoadedMsg:
+		if msg.tunnelID == m.selectedTunnelID {
+			m.tunnelRoutes = msg.routes
+			m.warpRoutingEnabled = msg.warpRouting
+		}
+		if msg.ingressOrder != nil {
+			m.ingressProblems[msg.tunnelID] = *msg.ingressOrder
+		}
+
+	case ingressOrderFixedMsg:
+		cmds = append(cmds, m.handleIngressOrderFixed(msg))
+
+	case warpRoutingToggledMsg:
+		m.loading = false
+		if msg.tunnelID == m.selectedTunnelID {
+			m.warpRoutingEnabled = msg.enabled
+		}
+		state := "disabled"
+		if msg.enabled {
+			state = "enabled"
+		}
+		m.statusMessage = fmt.Sprintf("WARP routing %s for tunnel: %s", state, m.selectedTunnelName)
 
 	case domainsLoadedMsg:
 		m.availableDomains = []string(msg)
+		m.domainsLoadedAt = time.Now()
 		m.selectedDomainIndex = 0
+		if m.domainsRefreshing {
+			m.domainsRefreshing = false
+			m.statusMessage = fmt.Sprintf("Refreshed zones: %d available", len(m.availableDomains))
+		}
 
 		// Check if there's a saved domain in state first
 		savedDomain := m.state.GetSelectedDomain()
@@ -606,6 +1729,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.SetSelectedDomain(selectedDomain)
 			m.client.SetSelectedDomain(selectedDomain)
 		}
+		if m.showAddHostname {
+			m.preselectTunnelDomain()
+		}
 
 	case tunnelDomainCountsLoadedMsg:
 		// Merge the new counts with existing ones
@@ -615,24 +1741,88 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		for tunnelID, count := range msg {
 			m.tunnelDomainCounts[tunnelID] = count
 		}
+		m.resortTunnels(models.TunnelSortDomains)
 
 	case tunnelStatusesLoadedMsg:
 		// Merge the new statuses with existing ones
 		if m.tunnelStatuses == nil {
 			m.tunnelStatuses = make(map[string]models.TunnelStatus)
 		}
+		if m.statusHistory == nil {
+			m.statusHistory = make(map[string]*models.StatusHistory)
+		}
+		uptimeChanged := false
 		for tunnelID, status := range msg {
+			if md, exists := m.tunnelMetadata[tunnelID]; exists {
+				status = models.ApplyRemoteStatus(status, md)
+			}
 			m.tunnelStatuses[tunnelID] = status
+
+			if m.uptimeLog != nil && m.uptimeLog.Record(tunnelID, m.tunnelName(tunnelID), status, time.Now()) {
+				uptimeChanged = true
+			}
+
+			history, exists := m.statusHistory[tunnelID]
+			if !exists {
+				history = models.NewStatusHistory(models.DefaultHistorySize)
+				m.statusHistory[tunnelID] = history
+			}
+			history.Record(status)
+		}
+		if uptimeChanged {
+			m.uptimeLog.Save()
+		}
+		m.resortTunnels(models.TunnelSortStatus)
+		if m.tunnelsStale {
+			// The first refresh is complete; keep it for the next launch
+			m.tunnelsStale = false
+			m.saveTunnelCache()
+		}
+
+	case warningMsg:
+		m.warningMessage = models.Warning(msg).String()
+
+	case toastExpiredMsg:
+		m.handleToastExpired()
+
+	case startupProbedMsg:
+		probe := models.StartupProbe(msg)
+		m.startupProbe = &probe
+
+	case serviceTunnelsDetectedMsg:
+		// Nothing to store: the tunnel manager keeps the detected services
+
+	case processStatsLoadedMsg:
+		m.processStats = msg
+		cmds = append(cmds, m.checkProcessLimits())
+
+	case processActionMsg:
+		if msg.err != nil {
+			m.errorMessage = msg.err.Error()
+		} else {
+			m.statusMessage = msg.message
+			cmds = append(cmds, m.pushToast(toastSuccess, msg.message))
 		}
+		m.selectedProcess = clampIndex(m.selectedProcess, len(m.processRows()))
+		cmds = append(cmds, m.loadProcessStats())
 
 	case errorMsg:
 		m.errorMessage = string(msg)
 		m.loading = false
 		m.statusMessage = "Error occurred"
 
+	case tunnelMetadataLoadedMsg:
+		m.tunnelMetadata = msg
+		for tunnelID, status := range m.tunnelStatuses {
+			if md, exists := msg[tunnelID]; exists {
+				m.tunnelStatuses[tunnelID] = models.ApplyRemoteStatus(status, md)
+			}
+		}
+
 	case statusMsg:
 		m.statusMessage = string(msg)
 		m.loading = false
+		cmds = append(cmds, m.pushToast(toastSuccess, string(msg)))
 		// If we just created or updated a public hostname, reload the tunnel hostnames and update domain count
 		if m.showTunnelHostnames && m.selectedTunnelName != "" && m.selectedTunnelID != "" {
 			if (len(m.statusMessage) > len("Successfully created public hostname:") &&
@@ -650,6 +1840,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case hostnameDeletedMsg:
 		m.statusMessage = msg.message
 		m.loading = false
+		m.stopServedFolder(msg.hostname)
 		// Automatically reload hostnames after deletion
 		if m.showTunnelHostnames && msg.tunnelID != "" {
 			cmds = append(cmds, m.loadTunnelHostnames(msg.tunnelID))
@@ -658,6 +1849,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case hostnameAuthToggledMsg:
+		if msg.hostname.AuthEnabled {
+			msg.hostname.AuthPassword = msg.password
+			if msg.password != "" {
+				m.state.SetAuthUsers(msg.hostname.Hostname, msg.users)
+				m.state.Save()
+			}
+		}
+
 		// Update the hostname in our local list
 		for i := range m.tunnelHostnames {
 			if m.tunnelHostnames[i].Hostname == msg.hostname.Hostname {
@@ -672,13 +1871,67 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.statusMessage = fmt.Sprintf("Authentication %s for %s", authStatus, msg.hostname.Hostname)
 		m.loading = false
+
+	case maintenanceToggledMsg:
+		m.loading = false
+		if msg.enabled {
+			m.state.SetMaintenance(msg.hostname, msg.originalService)
+			m.statusMessage = fmt.Sprintf("🚧 %s is in maintenance (serving %s)", msg.hostname, msg.service)
+		} else {
+			m.state.ClearMaintenance(msg.hostname)
+			m.statusMessage = fmt.Sprintf("%s restored to %s", msg.hostname, msg.service)
+		}
+		m.state.Save()
+
+		for i := range m.tunnelHostnames {
+			if m.tunnelHostnames[i].Hostname == msg.hostname {
+				m.tunnelHostnames[i].Service = msg.service
+				break
+			}
+		}
+
+	case notFoundToggledMsg:
+		m.handleNotFoundToggled(msg)
+
+	case browserRenderingToggledMsg:
+		for i := range m.tunnelHostnames {
+			if m.tunnelHostnames[i].Hostname == msg.hostname.Hostname {
+				m.tunnelHostnames[i] = msg.hostname
+				break
+			}
+		}
+
+		renderStatus := "disabled"
+		if msg.hostname.BrowserRendering {
+			renderStatus = "enabled (add an Access policy in the dashboard to allow users)"
+			if msg.launcherURL != "" {
+				renderStatus = fmt.Sprintf("enabled (add an Access policy, then sign in via %s)", msg.launcherURL)
+			}
+		}
+		m.statusMessage = fmt.Sprintf("Browser rendering %s for %s", renderStatus, msg.hostname.Hostname)
+
+	case pollMsg:
+		cmds = append(cmds, m.handlePoll(pollKind(msg)))
+
+	case hostnamePreviewLoadedMsg:
+		m.handleHostnamePreviewLoaded(msg)
+
+	case spinner.TickMsg:
+		cmds = append(cmds, m.handleSpinnerTick(msg))
 	}
 
+	// Loads run in the background; the status bar spins until they finish
+	cmds = append(cmds, m.keepSpinning())
+
+	// On wide terminals the hostname pane follows the tunnel cursor
+	m.scrollToTunnelCursor()
+	cmds = append(cmds, m.loadHostnamePreview())
+
 	return m, tea.Batch(cmds...)
 }
 
 func (m *Model) initializeTextInputs() {
-	m.textInputs = make([]textinput.Model, 3)
+	m.textInputs = make([]textinput.Model, 4)
 
 	// Hostname input (subdomain part only)
 	m.textInputs[0] = textinput.New()
@@ -701,26 +1954,59 @@ func (m *Model) initializeTextInputs() {
 	m.textInputs[2].CharLimit = 100
 	m.textInputs[2].Width = 40
 
+	// TTL input (optional, removes the hostname after it elapses)
+	m.textInputs[3] = newTTLInput()
+
 	m.focusIndex = 0
+	m.recentServiceIndex = -1
+	m.presetIndex = -1
+	m.serviceLimits = nil
+	m.serviceLimitsAck = ""
+}
+
+func newTTLInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "never"
+	input.CharLimit = 10
+	input.Width = 10
+	return input
+}
+
+// formHostname builds the full hostname from the form input and the selected
+// zone. Input that already ends in one of the account's zones is used as is,
+// so hostnames in other zones can be entered directly.
+func (m Model) formHostname(input string) string {
+	if _, zone := models.SplitHostname(input, m.availableDomains); zone != "" {
+		return input
+	}
+	if len(m.availableDomains) > 0 && m.selectedDomainIndex >= 0 && m.selectedDomainIndex < len(m.availableDomains) {
+		return fmt.Sprintf("%s.%s", input, m.availableDomains[m.selectedDomainIndex])
+	}
+	return input // fallback if no domain available
+}
+
+// domainFieldIndex is the focus index of the domain dropdown, which follows
+// the form's text inputs
+func (m Model) domainFieldIndex() int {
+	return len(m.textInputs)
 }
 
 func (m *Model) initializeTextInputsForEdit() {
-	m.textInputs = make([]textinput.Model, 3)
+	m.textInputs = make([]textinput.Model, 4)
 
-	// Parse hostname to separate subdomain and domain
+	// Split the hostname into the part below its zone and the zone itself
 	subdomain := ""
 	if m.selectedHostname.Hostname != "" {
-		parts := strings.SplitN(m.selectedHostname.Hostname, ".", 2)
-		if len(parts) >= 1 {
-			subdomain = parts[0]
-		}
-		if len(parts) == 2 {
-			// Find the domain in our available domains list
-			for i, domain := range m.availableDomains {
-				if domain == parts[1] {
-					m.selectedDomainIndex = i
-					break
-				}
+		var zone string
+		subdomain, zone = models.SplitHostname(m.selectedHostname.Hostname, m.availableDomains)
+		if subdomain == "" {
+			// Apex hostnames are entered in full
+			subdomain = m.selectedHostname.Hostname
+		}
+		for i, domain := range m.availableDomains {
+			if domain == zone {
+				m.selectedDomainIndex = i
+				break
 			}
 		}
 	}
@@ -748,7 +2034,14 @@ func (m *Model) initializeTextInputsForEdit() {
 	m.textInputs[2].CharLimit = 100
 	m.textInputs[2].Width = 40
 
+	// TTL input (blank keeps the current expiry)
+	m.textInputs[3] = newTTLInput()
+
 	m.focusIndex = 0
+	m.recentServiceIndex = -1
+	m.presetIndex = -1
+	m.serviceLimits = nil
+	m.serviceLimitsAck = ""
 }
 
 func (m *Model) updateFocus() {
@@ -768,15 +2061,12 @@ func (m Model) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle navigation and special keys
 	switch msg.String() {
 	case "ctrl+c":
-		return m, tea.Quit
+		return m, m.quit()
 
 	case "esc", "escape":
 		// If in form, cancel form and return to hostname list
 		if m.showAddHostname || m.showEditHostname {
-			m.showAddHostname = false
-			m.showEditHostname = false
-			m.textInputs = nil
-			m.focusIndex = 0
+			m.closeAddHostname()
 			m.statusMessage = "Cancelled"
 			return m, nil
 		}
@@ -786,15 +2076,39 @@ func (m Model) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.selectedTunnelName = ""
 			m.selectedTunnelID = ""
 			m.tunnelHostnames = nil
+			m.tunnelRoutes = nil
+			m.warpRoutingEnabled = false
 			m.statusMessage = "Returned to tunnel list"
 			return m, nil
 		}
 
+	case "ctrl+r":
+		// Refetch the zones on the domain dropdown, e.g. after adding a zone
+		if m.focusIndex == m.domainFieldIndex() {
+			m.domainsRefreshing = true
+			m.statusMessage = "Refreshing zones..."
+			return m, m.loadDomains()
+		}
+		// Cycle through recently used services when the service field is focused
+		if m.focusIndex == 2 && len(m.state.RecentServices) > 0 {
+			m.recentServiceIndex = (m.recentServiceIndex + 1) % len(m.state.RecentServices)
+			m.textInputs[2].SetValue(m.state.RecentServices[m.recentServiceIndex])
+			m.textInputs[2].CursorEnd()
+		}
+		return m, nil
+
+	case "ctrl+t":
+		// Cycle through the homelab presets
+		if m.showAddHostname {
+			m.applyNextPreset()
+		}
+		return m, nil
+
 	case "tab", "shift+tab", "enter", "up", "down":
 		s := msg.String()
 
 		// Handle form navigation and domain selection
-		if s == "enter" && m.focusIndex == 3 {
+		if s == "enter" && m.focusIndex == m.domainFieldIndex() {
 			// Submit form when focused on domain dropdown
 			hostnameInput := m.textInputs[0].Value()
 			path := m.textInputs[1].Value()
@@ -805,32 +2119,49 @@ func (m Model) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			// Construct full hostname with selected domain
-			var fullHostname string
-			if len(m.availableDomains) > 0 && m.selectedDomainIndex < len(m.availableDomains) {
-				fullHostname = fmt.Sprintf("%s.%s", hostnameInput, m.availableDomains[m.selectedDomainIndex])
-			} else {
-				fullHostname = hostnameInput // fallback if no domain available
+			var ttl time.Duration
+			if ttlInput := strings.TrimSpace(m.textInputs[3].Value()); ttlInput != "" {
+				parsed, err := time.ParseDuration(ttlInput)
+				if err != nil || parsed <= 0 {
+					m.statusMessage = "Invalid TTL - use a duration like 30m or 2h"
+					return m, nil
+				}
+				ttl = parsed
 			}
 
-			m.loading = true
+			if !m.checkServiceLimits(hostnameInput, service) {
+				return m, nil
+			}
+
+			fullHostname := m.formHostname(hostnameInput)
+
+			m.state.AddRecentService(service)
+			if _, zone := models.SplitHostname(fullHostname, m.availableDomains); zone != "" {
+				m.state.SetTunnelDomain(m.selectedTunnelID, zone)
+			}
+			m.state.Save()
+
 			if m.showEditHostname {
 				m.showEditHostname = false
 				m.statusMessage = fmt.Sprintf("Updating public hostname: %s", fullHostname)
-				cmd = m.updateTunnelHostname(fullHostname, path, service)
+				if !strings.EqualFold(fullHostname, m.selectedHostname.Hostname) {
+					// Renaming moves the CNAME to the new hostname
+					m.trackPropagation(fullHostname)
+				}
+				task := queueTask(m.selectedTunnelID, "update "+fullHostname, m.updateTunnelHostname(fullHostname, path, service, ttl))
+				cmd = m.guardHostname(m.selectedHostname.Hostname, "modify", task)
 			} else {
 				m.showAddHostname = false
 				m.statusMessage = fmt.Sprintf("Creating public hostname: %s", fullHostname)
-				cmd = m.createTunnelHostname(fullHostname, path, service)
+				cmd = queueTask(m.selectedTunnelID, "create "+fullHostname, m.createTunnelHostname(fullHostname, path, service, m.presetOriginRequest(service), ttl))
 			}
 
-			m.textInputs = nil
-			m.focusIndex = 0
+			m.closeAddHostname()
 			return m, cmd
 		}
 
 		// Handle domain dropdown navigation when focused on it
-		if m.focusIndex == 3 && len(m.availableDomains) > 0 {
+		if m.focusIndex == m.domainFieldIndex() && len(m.availableDomains) > 0 {
 			switch s {
 			case "up":
 				if m.selectedDomainIndex > 0 {
@@ -860,8 +2191,8 @@ func (m Model) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 		// Navigate between fields (including domain dropdown)
-		// We have 3 text inputs (0,1,2) and 1 domain dropdown (3) = 4 total fields
-		maxFocusIndex := 3 // domain dropdown is at index 3
+		// The text inputs are followed by the domain dropdown as the last field
+		maxFocusIndex := m.domainFieldIndex()
 		switch s {
 		case "tab", "down", "enter":
 			m.focusIndex++
@@ -888,6 +2219,16 @@ func (m Model) handleFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// tunnelName returns the display name for a tunnel ID from the loaded list
+func (m Model) tunnelName(tunnelID string) string {
+	for _, tunnel := range m.tunnelsList {
+		if tunnel.ID == tunnelID {
+			return tunnel.Name
+		}
+	}
+	return tunnelID
+}
+
 func (m Model) deleteTunnel() tea.Cmd {
 	if m.selectedTunnel >= len(m.tunnelsList) {
 		return nil
@@ -895,11 +2236,10 @@ func (m Model) deleteTunnel() tea.Cmd {
 
 	tunnel := m.tunnelsList[m.selectedTunnel]
 
-	return tea.Cmd(func() tea.Msg {
-		ctx := context.Background()
+	return m.cancellable("delete "+tunnel.Name, func(ctx context.Context) tea.Msg {
 		err := m.client.DeleteTunnel(ctx, tunnel.Name)
 		if err != nil {
-			return errorMsg(fmt.Sprintf("Failed to delete tunnel: %v", err))
+			return apiErrorMsg("delete tunnel", err)
 		}
 		return statusMsg(fmt.Sprintf("Deleted tunnel: %s", tunnel.Name))
 	})
@@ -925,8 +2265,14 @@ func (m Model) renderMainView() string {
 	var sections []string
 
 	sections = append(sections, m.renderHeader())
+	if m.startupBanner {
+		sections = append(sections, m.renderStartupBanner())
+	}
 	sections = append(sections, m.renderTabs())
 	sections = append(sections, m.renderContent())
+	if toasts := m.renderToasts(); toasts != "" {
+		sections = append(sections, toasts)
+	}
 	sections = append(sections, m.renderStatusBar())
 	sections = append(sections, m.renderFooter())
 
@@ -936,20 +2282,25 @@ func (m Model) renderMainView() string {
 func (m Model) renderHeader() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#7C3AED")).
+		Foreground(colors.accent).
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7C3AED")).
+		BorderForeground(colors.accent).
 		Padding(0, 1).
 		MarginBottom(1)
 
 	title := titleStyle.Render("🌐 Tunnelman - Cloudflare Tunnel Manager")
 
 	timeStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9CA3AF")).
+		Foreground(colors.muted).
 		Align(lipgloss.Right)
 
-	timeStr := timeStyle.Width(m.width - lipgloss.Width(title)).
-		Render(fmt.Sprintf("Last updated: %s", m.lastUpdate.Format("15:04:05")))
+	updated := fmt.Sprintf("Last updated: %s", m.lastUpdate.Format("15:04:05"))
+	if m.tunnelsStale {
+		updated = fmt.Sprintf("Cached from %s - refreshing...", m.tunnelCache.SavedAt.Local().Format("Jan 2 15:04"))
+	} else if m.config.OnDemandPolling() {
+		updated += " • on demand (r to refresh)"
+	}
+	timeStr := timeStyle.Width(m.width - lipgloss.Width(title)).Render(updated)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, title, timeStr)
 }
@@ -959,15 +2310,15 @@ func (m Model) renderTabs() string {
 
 	inactiveTabStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder(), true, true, false, true).
-		BorderForeground(lipgloss.Color("#374151")).
+		BorderForeground(colors.panel).
 		Padding(0, 1).
-		Foreground(lipgloss.Color("#9CA3AF"))
+		Foreground(colors.muted)
 
 	activeTabStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder(), true, true, false, true).
-		BorderForeground(lipgloss.Color("#7C3AED")).
-		Background(lipgloss.Color("#7C3AED")).
-		Foreground(lipgloss.Color("#FFFFFF")).
+		BorderForeground(colors.accent).
+		Background(colors.accent).
+		Foreground(colors.onColor).
 		Padding(0, 1).
 		Bold(true)
 
@@ -985,7 +2336,7 @@ func (m Model) renderTabs() string {
 
 	gap := lipgloss.NewStyle().
 		BorderBottom(true).
-		BorderForeground(lipgloss.Color("#374151")).
+		BorderForeground(colors.panel).
 		Width(m.width - lipgloss.Width(tabsRow)).
 		Render("")
 
@@ -997,17 +2348,73 @@ func (m Model) renderContent() string {
 
 	contentStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#374151")).
+		BorderForeground(colors.panel).
 		Padding(2, 3).
 		Height(contentHeight).
 		Width(m.width - 4)
 
 	var content string
 
-	if m.loading {
+	if m.configDiff != nil {
+		content = m.renderConfigDiff()
+	} else if m.confirmDialog != nil {
+		content = m.renderConfirm()
+	} else if m.showNewTunnel {
+		content = m.renderNewTunnel()
+	} else if m.showSearch {
+		content = m.renderSearch()
+	} else if m.showInventory {
+		content = m.renderInventory()
+	} else if m.showLoginHelp {
+		content = m.renderLoginHelp()
+	} else if m.showAudit {
+		content = m.renderAudit()
+	} else if m.showTypedConfirm {
+		content = m.renderTypedConfirm()
+	} else if m.pathRulesHostname != "" {
+		content = m.renderPathRules()
+	} else if m.localConfigYAML != "" {
+		content = m.renderLocalConfig()
+	} else if m.applySnippet != "" {
+		content = m.renderApplySnippet()
+	} else if m.proxySnippetHostname != "" {
+		content = m.renderProxySnippet()
+	} else if m.dnsCheckHostname != "" {
+		content = m.renderDNSCheck()
+	} else if m.selfTestTunnel != "" {
+		content = m.renderSelfTest()
+	} else if m.runArgsTunnel != "" {
+		content = m.renderRunArgs()
+	} else if m.runURLTunnel != "" {
+		content = m.renderRunURL()
+	} else if m.authUsersHostname != "" {
+		content = m.renderAuthUsers()
+	} else if m.remoteLogs != nil {
+		content = m.renderRemoteLogs()
+	} else if m.showEnvironments {
+		content = m.renderEnvironments()
+	} else if m.showPalette {
+		content = m.renderPalette()
+	} else if m.showToastHistory {
+		content = m.renderToastHistory()
+	} else if m.showIngressSim {
+		content = m.renderIngressSim()
+	} else if m.requestSamplesHostname != "" {
+		content = m.renderRequestSamples()
+	} else if m.loadingPlaceholder() {
 		content = m.renderLoading()
+	} else if m.showCleanup {
+		content = m.renderCleanup()
 	} else if m.showTunnelHostnames || m.showAddHostname || m.showEditHostname {
 		content = m.renderTunnelHostnamesView()
+	} else if m.showUptimeReport {
+		content = m.renderUptimeReport()
+	} else if m.activeTab == processesTab {
+		content = m.renderProcesses()
+	} else if m.activeTab == dnsTab {
+		content = m.renderDNS()
+	} else if m.splitPaneActive() {
+		content = m.renderSplitPane(m.renderTunnelsTab())
 	} else {
 		content = m.renderTunnelsTab()
 	}
@@ -1017,7 +2424,7 @@ func (m Model) renderContent() string {
 
 func (m Model) renderLoading() string {
 	loadingStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7C3AED")).
+		Foreground(colors.accent).
 		Bold(true).
 		Align(lipgloss.Center).
 		Width(m.width - 8)
@@ -1028,41 +2435,57 @@ func (m Model) renderLoading() string {
 func (m Model) renderTunnelsTab() string {
 	if len(m.tunnelsList) == 0 {
 		emptyStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#9CA3AF")).
+			Foreground(colors.muted).
 			Italic(true).
 			Align(lipgloss.Center).
 			Width(m.width - 8)
-		return emptyStyle.Render("No tunnels found. Press 'r' to refresh or create a new tunnel.")
+		return emptyStyle.Render(fmt.Sprintf("No tunnels found. Press 'n' to create a tunnel or '%s' to refresh.", m.keys.Bound("r")))
 	}
 
 	// Define column widths
 	nameWidth := 20
-	statusWidth := 10
+	statusWidth := 12
+	localWidth := 10
+	replicasWidth := 10
+	historyWidth := models.DefaultHistorySize + 2
 	domainsWidth := 8
 	idWidth := 15
+	// The ENV column only shows once environments are configured
+	envWidth := 0
+	if len(m.config.Environments()) > 0 {
+		envWidth = 10
+	}
 
 	// Header styles
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#F3F4F6")).
+		Foreground(colors.heading).
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderBottom(true).
-		BorderForeground(lipgloss.Color("#D1D5DB")).
+		BorderForeground(colors.border).
 		PaddingBottom(1).
 		MarginBottom(1)
 
 	// Column header styles
 	nameHeaderStyle := lipgloss.NewStyle().Width(nameWidth).Align(lipgloss.Left)
+	envHeaderStyle := lipgloss.NewStyle().Width(envWidth).Align(lipgloss.Left)
 	statusHeaderStyle := lipgloss.NewStyle().Width(statusWidth).Align(lipgloss.Center)
+	localHeaderStyle := lipgloss.NewStyle().Width(localWidth).Align(lipgloss.Center)
+	replicasHeaderStyle := lipgloss.NewStyle().Width(replicasWidth).Align(lipgloss.Center)
+	historyHeaderStyle := lipgloss.NewStyle().Width(historyWidth).Align(lipgloss.Left)
 	domainsHeaderStyle := lipgloss.NewStyle().Width(domainsWidth).Align(lipgloss.Center)
 	idHeaderStyle := lipgloss.NewStyle().Width(idWidth).Align(lipgloss.Left)
 
 	// Build header
 	headerRow := lipgloss.JoinHorizontal(lipgloss.Top,
-		nameHeaderStyle.Render("NAME"),
-		statusHeaderStyle.Render("STATUS"),
-		domainsHeaderStyle.Render("DOMAINS"),
-		idHeaderStyle.Render("ID"),
+		nameHeaderStyle.Render(m.sortHeader("NAME", models.TunnelSortName)),
+		envHeaderStyle.Render(envColumn(envWidth, "ENV")),
+		statusHeaderStyle.Render(m.sortHeader("STATUS", models.TunnelSortStatus)),
+		localHeaderStyle.Render("LOCAL"),
+		replicasHeaderStyle.Render("REPLICAS"),
+		historyHeaderStyle.Render(" HISTORY"),
+		domainsHeaderStyle.Render(m.sortHeader("DOMAINS", models.TunnelSortDomains)),
+		idHeaderStyle.Render(m.sortHeader("ID", models.TunnelSortCreated)),
 	)
 
 	var rows []string
@@ -1073,45 +2496,41 @@ func (m Model) renderTunnelsTab() string {
 		rows = append(rows, "")
 	}
 
+	var running map[string]*models.TunnelProcess
+	if m.tunnelManager != nil {
+		running = m.tunnelManager.GetRunningTunnels()
+	}
+
+	visible := m.visibleTunnelRows()
 	for i, tunnel := range m.tunnelsList {
+		if i < m.tunnelScroll || i >= m.tunnelScroll+visible {
+			continue
+		}
+
 		// Row styles
 		var baseStyle lipgloss.Style
 		if i == m.selectedTunnel {
 			baseStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#7C3AED")).
-				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(colors.accent).
+				Foreground(colors.onColor).
 				Bold(true)
 		} else {
 			baseStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#E5E7EB"))
+				Foreground(colors.text)
 		}
 
 		// Column styles for this row
 		nameStyle := baseStyle.Copy().Width(nameWidth).Align(lipgloss.Left)
+		envStyle := baseStyle.Copy().Width(envWidth).Align(lipgloss.Left)
 		statusStyle := baseStyle.Copy().Width(statusWidth).Align(lipgloss.Center)
+		localStyle := baseStyle.Copy().Width(localWidth).Align(lipgloss.Center)
+		replicasStyle := baseStyle.Copy().Width(replicasWidth).Align(lipgloss.Center)
+		historyStyle := baseStyle.Copy().Width(historyWidth).Align(lipgloss.Left)
 		domainsStyle := baseStyle.Copy().Width(domainsWidth).Align(lipgloss.Center)
 		idStyle := baseStyle.Copy().Width(idWidth).Align(lipgloss.Left)
 
 		// Get tunnel status
-		status := "DOWN"
-		statusColor := lipgloss.Color("#EF4444") // Red for DOWN
-
-		if tunnelStatus, exists := m.tunnelStatuses[tunnel.ID]; exists {
-			switch tunnelStatus {
-			case models.StatusActive:
-				status = "HEALTHY"
-				statusColor = lipgloss.Color("#10B981") // Green for HEALTHY
-			case models.StatusInactive:
-				status = "DOWN"
-				statusColor = lipgloss.Color("#EF4444") // Red for DOWN
-			case models.StatusError:
-				status = "ERROR"
-				statusColor = lipgloss.Color("#F59E0B") // Yellow for ERROR
-			case models.StatusUnknown:
-				status = "UNKNOWN"
-				statusColor = lipgloss.Color("#6B7280") // Gray for UNKNOWN
-			}
-		}
+		indicator := m.tunnelIndicator(tunnel.ID)
 
 		// Get domain count for this tunnel
 		domainCount := 0
@@ -1121,14 +2540,51 @@ func (m Model) renderTunnelsTab() string {
 
 		// Truncate long tunnel names
 		tunnelName := tunnel.Name
+		if _, ok := m.serviceConnector(tunnel); ok {
+			tunnelName = "⚙ " + tunnelName
+		}
+		if m.state.IsPinnedTunnel(tunnel.ID) {
+			tunnelName = "★ " + tunnelName
+		}
 		if len(tunnelName) > nameWidth-2 {
 			tunnelName = tunnelName[:nameWidth-5] + "..."
 		}
 
-		statusText := status
-		if i != m.selectedTunnel {
+		statusText := indicator.String()
+		if m.tunnelsStale {
+			// Cached statuses may be out of date; grey them out until refreshed
+			statusText += "?"
+			if i != m.selectedTunnel {
+				statusText = lipgloss.NewStyle().Foreground(colors.muted).Render(statusText)
+			}
+		} else if i != m.selectedTunnel {
 			// Apply color only when not selected (to avoid conflicts with selection highlight)
-			statusText = lipgloss.NewStyle().Foreground(statusColor).Bold(true).Render(status)
+			statusText = lipgloss.NewStyle().Foreground(*indicator.color).Bold(true).Render(statusText)
+		}
+
+		// Whether tunnelman runs a connector here, independent of the edge's view
+		localText := ""
+		if running[tunnel.Name] != nil {
+			localText = "▶ RUNNING"
+			if i != m.selectedTunnel {
+				localText = lipgloss.NewStyle().Foreground(colors.success).Render(localText)
+			}
+		}
+
+		// Connectors serving the tunnel, wherever they run; more than one
+		// means requests are spread across several egress points
+		replicasText := ""
+		if replicas := tunnel.Replicas(); replicas > 0 {
+			replicasText = fmt.Sprintf("%d", replicas)
+			if replicas > 1 && i != m.selectedTunnel {
+				replicasText = lipgloss.NewStyle().Foreground(colors.caution).Bold(true).Render(replicasText)
+			}
+		}
+
+		// Status history sparkline, oldest sample first
+		sparkline := ""
+		if history, exists := m.statusHistory[tunnel.ID]; exists {
+			sparkline = history.Sparkline()
 		}
 
 		// Short ID
@@ -1140,7 +2596,11 @@ func (m Model) renderTunnelsTab() string {
 		// Build row
 		row := lipgloss.JoinHorizontal(lipgloss.Top,
 			nameStyle.Render(tunnelName),
+			envStyle.Render(envColumn(envWidth, m.config.TunnelEnvironment(tunnel.Name))),
 			statusStyle.Render(statusText),
+			localStyle.Render(localText),
+			replicasStyle.Render(replicasText),
+			historyStyle.Render(" "+sparkline),
 			domainsStyle.Render(fmt.Sprintf("%d", domainCount)),
 			idStyle.Render(shortID),
 		)
@@ -1148,20 +2608,125 @@ func (m Model) renderTunnelsTab() string {
 		rows = append(rows, row)
 	}
 
+	if len(m.tunnelsList) > visible {
+		last := m.tunnelScroll + visible
+		if last > len(m.tunnelsList) {
+			last = len(m.tunnelsList)
+		}
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(colors.subtle).
+			Render(fmt.Sprintf("%d-%d of %d tunnels", m.tunnelScroll+1, last, len(m.tunnelsList))))
+	}
+
+	if m.selectedTunnel < len(m.tunnelsList) {
+		if md, exists := m.tunnelMetadata[m.tunnelsList[m.selectedTunnel].ID]; exists {
+			rows = append(rows, "", lipgloss.NewStyle().
+				Foreground(colors.muted).
+				Italic(true).
+				Render(md.Describe()))
+		}
+		if local, ok := m.localConfigs[m.tunnelsList[m.selectedTunnel].ID]; ok {
+			rows = append(rows, lipgloss.NewStyle().
+				Foreground(colors.muted).
+				Italic(true).
+				Render("📄 Local config: "+local.Path+" (L to view)"))
+		}
+		if connector, ok := m.serviceConnector(m.tunnelsList[m.selectedTunnel]); ok {
+			rows = append(rows, lipgloss.NewStyle().
+				Foreground(colors.muted).
+				Italic(true).
+				Render("⚙ "+connector.Label()+" - tunnelman won't start another connector for it"))
+		}
+	}
+
+	rows = append(rows, "", m.renderStatusLegend())
+	if colos := models.ColoDistribution(m.tunnelsList); len(colos) > 0 {
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(colors.muted).
+			Render("📡 Edge colos: "+models.FormatColoDistribution(colos)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m Model) renderUptimeReport() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.accent).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	title := titleStyle.Render("📈 Uptime Report")
+
+	if m.uptimeLog == nil {
+		return lipgloss.JoinVertical(lipgloss.Left, title, "Uptime log not available")
+	}
+
+	now := time.Now()
+	weekly := m.uptimeLog.Report(7*24*time.Hour, now)
+	monthly := make(map[string]models.UptimeReport)
+	for _, report := range m.uptimeLog.Report(30*24*time.Hour, now) {
+		monthly[report.TunnelID] = report
+	}
+
+	if len(weekly) == 0 {
+		emptyStyle := lipgloss.NewStyle().
+			Foreground(colors.muted).
+			Italic(true)
+		return lipgloss.JoinVertical(lipgloss.Left, title, emptyStyle.Render("No status history recorded yet"))
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading)
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	rows := []string{
+		title,
+		headerStyle.Render(fmt.Sprintf("%-24s %-10s %-10s %-10s %s", "TUNNEL", "7 DAYS", "30 DAYS", "OUTAGES", "LAST DOWNTIME")),
+	}
+
+	for _, report := range weekly {
+		lastDown := "never"
+		if !report.LastDowntime.IsZero() {
+			lastDown = report.LastDowntime.Format("2006-01-02 15:04")
+		}
+
+		name := report.TunnelName
+		if len(name) > 24 {
+			name = name[:21] + "..."
+		}
+
+		rows = append(rows, rowStyle.Render(fmt.Sprintf("%-24s %-10s %-10s %-10d %s",
+			name,
+			fmt.Sprintf("%.2f%%", report.UptimePercent),
+			fmt.Sprintf("%.2f%%", monthly[report.TunnelID].UptimePercent),
+			report.Downtimes,
+			lastDown)))
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 func (m Model) renderTunnelHostnamesView() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#7C3AED")).
+		Foreground(colors.accent).
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderBottom(true).
-		BorderForeground(lipgloss.Color("#7C3AED")).
+		BorderForeground(colors.accent).
 		PaddingBottom(1).
 		MarginBottom(2)
 
-	title := titleStyle.Render(fmt.Sprintf("🌐 Public Hostnames for Tunnel: %s", m.selectedTunnelName))
+	warpState := "off"
+	if m.warpRoutingEnabled {
+		warpState = "on"
+	}
+	title := titleStyle.Render(fmt.Sprintf("🌐 Public Hostnames for Tunnel: %s  •  WARP routing: %s", m.selectedTunnelName, warpState))
 
 	// Show hostname input form if adding a new hostname
 	if m.showAddHostname || m.showEditHostname {
@@ -1170,23 +2735,32 @@ func (m Model) renderTunnelHostnamesView() string {
 
 	if len(m.tunnelHostnames) == 0 {
 		emptyStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#9CA3AF")).
+			Foreground(colors.muted).
 			Italic(true).
 			Align(lipgloss.Center).
 			Width(m.width - 8)
 
 		backInfo := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#10B981")).
+			Foreground(colors.success).
 			MarginTop(2).
 			Italic(true).
 			Align(lipgloss.Center).
 			Width(m.width - 8).
-			Render("Press 'a' to add hostname • Spacebar/Escape to return to tunnels list")
+			Render(fmt.Sprintf("Press '%s' to add hostname • Spacebar/Escape to return to tunnels list", m.keys.Bound("a")))
 
-		content := lipgloss.JoinVertical(lipgloss.Center,
-			emptyStyle.Render("No public hostnames found for this tunnel"),
-			backInfo,
-		)
+		emptyParts := []string{emptyStyle.Render("No public hostnames found for this tunnel")}
+		if warning := m.renderIngressOrderWarning(); warning != "" {
+			emptyParts = append(emptyParts, warning)
+		}
+		if len(m.tunnelRoutes) > 0 {
+			emptyParts = append(emptyParts, m.renderTunnelRoutes())
+		}
+		if events := m.renderConnectorEvents(); events != "" {
+			emptyParts = append(emptyParts, events)
+		}
+		emptyParts = append(emptyParts, backInfo)
+
+		content := lipgloss.JoinVertical(lipgloss.Center, emptyParts...)
 
 		return lipgloss.JoinVertical(lipgloss.Left, title, content)
 	}
@@ -1195,23 +2769,23 @@ func (m Model) renderTunnelHostnamesView() string {
 
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#F3F4F6")).
+		Foreground(colors.heading).
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderBottom(true).
-		BorderForeground(lipgloss.Color("#D1D5DB")).
+		BorderForeground(colors.border).
 		PaddingBottom(1).
 		MarginBottom(1)
 
-	header := headerStyle.Render(fmt.Sprintf("%-30s %-10s %-40s %-8s", "HOSTNAME", "PATH", "SERVICE", "AUTH"))
+	header := headerStyle.Render(fmt.Sprintf("%-30s %-20s %-10s %-40s %-8s %-8s %s", "HOSTNAME", "ZONE", "PATH", "SERVICE", "AUTH", "ORIGIN", "EXPIRES"))
 	rows = append(rows, header)
 
 	for i, hostname := range m.tunnelHostnames {
 		style := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#E5E7EB"))
+			Foreground(colors.text)
 
 		if i == m.selectedHostnameIndex {
-			style = style.Background(lipgloss.Color("#7C3AED")).
-				Foreground(lipgloss.Color("#FFFFFF")).
+			style = style.Background(colors.accent).
+				Foreground(colors.onColor).
 				Bold(true)
 		}
 
@@ -1221,9 +2795,21 @@ func (m Model) renderTunnelHostnamesView() string {
 		}
 
 		service := hostname.Service
+		if original, inMaintenance := m.state.MaintenanceOriginal(hostname.Hostname); inMaintenance {
+			service = "🚧 maintenance (was " + original + ")"
+		}
 		if len(service) > 40 {
 			service = service[:37] + "..."
 		}
+		if folder, served := m.state.GetServedFolder(hostname.Hostname); served {
+			service = "📁 " + folder.Root
+			if len(service) > 40 {
+				service = "📁 ..." + folder.Root[len(folder.Root)-34:]
+			}
+		}
+		if m.editingServiceFor(hostname) {
+			service = fmt.Sprintf("%-40s", m.serviceEditInput.View())
+		}
 
 		// Add https:// prefix to hostname for display since all Cloudflare tunnels are HTTPS
 		displayHostname := "https://" + hostname.Hostname
@@ -1236,12 +2822,46 @@ func (m Model) renderTunnelHostnamesView() string {
 		if hostname.AuthEnabled {
 			authStatus = "🔒"
 		}
+		if hostname.BrowserRendering {
+			authStatus += " 🖥"
+		}
 
-		row := fmt.Sprintf("%-30s %-10s %-40s %-8s",
+		expires := ""
+		if temp, exists := m.state.GetTemporaryHostname(hostname.Hostname); exists {
+			expires = "⏳ " + time.Until(temp.ExpiresAt).Round(time.Minute).String()
+		}
+		if badge := m.propagationBadge(hostname.Hostname); badge != "" {
+			expires = strings.TrimSpace(expires + " " + badge)
+		}
+
+		origin := "? ..."
+		if result, probed := m.originProbes[hostname.Service]; probed {
+			switch {
+			case result.Skipped:
+				origin = "-"
+			case result.Reachable:
+				origin = "✓ up"
+			default:
+				origin = "✗ down"
+			}
+		}
+
+		_, zone := models.SplitHostname(hostname.Hostname, m.availableDomains)
+		if zone == "" {
+			zone = "-"
+		}
+		if len(zone) > 20 {
+			zone = zone[:17] + "..."
+		}
+
+		row := fmt.Sprintf("%-30s %-20s %-10s %-40s %-8s %-8s %s",
 			displayHostname,
+			zone,
 			path,
 			service,
-			authStatus)
+			authStatus,
+			origin,
+			expires)
 
 		rows = append(rows, style.Render(row))
 	}
@@ -1250,9 +2870,9 @@ func (m Model) renderTunnelHostnamesView() string {
 	var passwordInfo string
 	if len(m.tunnelHostnames) > 0 && m.selectedHostnameIndex < len(m.tunnelHostnames) {
 		selectedHostname := m.tunnelHostnames[m.selectedHostnameIndex]
-		if selectedHostname.AuthEnabled && selectedHostname.AuthPassword != "" {
+		if selectedHostname.AuthEnabled {
 			passwordStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#F59E0B")).
+				Foreground(colors.warning).
 				MarginTop(1).
 				Align(lipgloss.Center).
 				Width(m.width - 8)
@@ -1263,17 +2883,21 @@ func (m Model) renderTunnelHostnamesView() string {
 				originalService = "http://localhost:8080" // fallback
 			}
 
-			passwordInfo = passwordStyle.Render(fmt.Sprintf("🔑 Auth: tunnelman:%s | 🎯 Original Service: %s", selectedHostname.AuthPassword, originalService))
+			login := fmt.Sprintf("%d login(s), 'u' to manage", len(m.state.AuthUsersFor(selectedHostname.Hostname)))
+			if selectedHostname.AuthPassword != "" {
+				login = fmt.Sprintf("%s:%s", models.AuthUsername, selectedHostname.AuthPassword)
+			}
+			passwordInfo = passwordStyle.Render(fmt.Sprintf("🔑 Auth: %s | 🎯 Original Service: %s", login, originalService))
 		}
 	}
 
 	backInfo := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#10B981")).
+		Foreground(colors.success).
 		MarginTop(2).
 		Italic(true).
 		Align(lipgloss.Center).
 		Width(m.width - 8).
-		Render("Press 'a' to add, 'e' to edit, 'd' to delete, 'A' to toggle auth, 'o' to open • Spacebar/Escape to return")
+		Render(fmt.Sprintf("Press '%s' to add, '%s' to edit, 's' to change the service, '%s' to delete, 'A' to toggle auth, 'u' for auth users, 'B' for browser rendering, '%s' for the dashboard, 'O' to open the URL • Spacebar/Escape to return", m.keys.Bound("a"), m.keys.Bound("e"), m.keys.Bound("d"), m.keys.Bound("o")))
 
 	var contentParts []string
 	contentParts = append(contentParts, lipgloss.JoinVertical(lipgloss.Left, rows...))
@@ -1282,6 +2906,47 @@ func (m Model) renderTunnelHostnamesView() string {
 		contentParts = append(contentParts, passwordInfo)
 	}
 
+	if len(m.tunnelHostnames) > 0 && m.selectedHostnameIndex < len(m.tunnelHostnames) && m.tunnelManager != nil {
+		selected := m.tunnelHostnames[m.selectedHostnameIndex]
+		if process, running := m.tunnelManager.GetAccessProcess(selected.Hostname); running {
+			contentParts = append(contentParts, lipgloss.NewStyle().
+				Foreground(colors.success).
+				MarginTop(1).
+				Render(fmt.Sprintf("🔌 Access client: %s → %s (PID %d, up %s) • Shift+T to stop",
+					process.LocalAddress(), selected.Hostname, process.PID, process.GetUptime().Round(time.Second))))
+		}
+	}
+
+	// Explain why the selected hostname's origin is down
+	if len(m.tunnelHostnames) > 0 && m.selectedHostnameIndex < len(m.tunnelHostnames) {
+		selected := m.tunnelHostnames[m.selectedHostnameIndex]
+		if result, probed := m.originProbes[selected.Service]; probed && !result.Reachable && !result.Skipped {
+			contentParts = append(contentParts, lipgloss.NewStyle().
+				Foreground(colors.errorColor).
+				MarginTop(1).
+				Render(fmt.Sprintf("⚠ Origin %s is %s", selected.Service, result.Summary())))
+		}
+	}
+
+	if warning := m.renderIngressOrderWarning(); warning != "" {
+		contentParts = append(contentParts, warning)
+	}
+
+	if m.state.HasCustomNotFound(m.selectedTunnelID) {
+		contentParts = append(contentParts, lipgloss.NewStyle().
+			Foreground(colors.muted).
+			MarginTop(1).
+			Render(fmt.Sprintf("🪧 Catch-all: tunnelman 404 page on %s (served while tunnelman runs) • Shift+N to restore http_status:404", m.config.NotFoundService())))
+	}
+
+	if len(m.tunnelRoutes) > 0 {
+		contentParts = append(contentParts, m.renderTunnelRoutes())
+	}
+
+	if events := m.renderConnectorEvents(); events != "" {
+		contentParts = append(contentParts, events)
+	}
+
 	contentParts = append(contentParts, backInfo)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
@@ -1289,6 +2954,35 @@ func (m Model) renderTunnelHostnamesView() string {
 	return lipgloss.JoinVertical(lipgloss.Left, title, content)
 }
 
+func (m Model) renderTunnelRoutes() string {
+	sectionStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading).
+		MarginTop(2)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	rows := []string{
+		sectionStyle.Render(fmt.Sprintf("🔀 Private Network Routes (%d)", len(m.tunnelRoutes))),
+		rowStyle.Render(fmt.Sprintf("%-20s %-20s %s", "NETWORK", "VIRTUAL NETWORK", "COMMENT")),
+	}
+
+	for _, route := range m.tunnelRoutes {
+		vnet := route.VirtualNetworkName
+		if vnet == "" {
+			vnet = "default"
+		}
+		if len(vnet) > 20 {
+			vnet = vnet[:17] + "..."
+		}
+
+		rows = append(rows, rowStyle.Render(fmt.Sprintf("%-20s %-20s %s", route.Network, vnet, route.Comment)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
 func (m Model) renderAddHostnameForm(title string) string {
 	// No additional styling here since renderContent() already provides borders and padding
 	formStyle := lipgloss.NewStyle().
@@ -1296,16 +2990,16 @@ func (m Model) renderAddHostnameForm(title string) string {
 
 	labelStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#D1D5DB"))
+		Foreground(colors.border)
 
 	focusedLabelStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#7C3AED"))
+		Foreground(colors.accent)
 
 	var formContent []string
 
 	// Ensure we have text inputs initialized
-	if len(m.textInputs) < 3 {
+	if len(m.textInputs) < 4 {
 		formContent = append(formContent, "Loading form...")
 		return lipgloss.JoinVertical(lipgloss.Left, title, formStyle.Render(lipgloss.JoinVertical(lipgloss.Left, formContent...)))
 	}
@@ -1333,21 +3027,43 @@ func (m Model) renderAddHostnameForm(title string) string {
 	if m.focusIndex == 2 {
 		style = focusedLabelStyle
 	}
-	formContent = append(formContent, style.Render("Service (e.g., http://localhost:8080):"))
+	formContent = append(formContent, style.Render("Service (e.g., http://localhost:8080, or folder:~/Downloads to serve a folder):"))
 	formContent = append(formContent, m.textInputs[2].View())
+	if m.focusIndex == 2 && len(m.state.RecentServices) > 0 {
+		formContent = append(formContent, m.renderRecentServices())
+	}
+	if preset := m.renderPreset(); preset != "" {
+		formContent = append(formContent, preset)
+	}
 	formContent = append(formContent, "")
 
-	// Domain dropdown field
+	// TTL field
 	style = labelStyle
 	if m.focusIndex == 3 {
 		style = focusedLabelStyle
 	}
-	formContent = append(formContent, style.Render("Domain:"))
-	formContent = append(formContent, m.renderDomainDropdown(m.focusIndex == 3))
+	ttlLabel := "Expires after (optional, e.g. 2h):"
+	if m.showEditHostname {
+		if temp, exists := m.state.GetTemporaryHostname(m.selectedHostname.Hostname); exists {
+			ttlLabel = fmt.Sprintf("Expires after (currently %s, blank keeps it):", temp.ExpiresAt.Format("Jan 2 15:04"))
+		}
+	}
+	formContent = append(formContent, style.Render(ttlLabel))
+	formContent = append(formContent, m.textInputs[3].View())
+	formContent = append(formContent, "")
+
+	// Domain dropdown field
+	domainFocused := m.focusIndex == m.domainFieldIndex()
+	style = labelStyle
+	if domainFocused {
+		style = focusedLabelStyle
+	}
+	formContent = append(formContent, style.Render("Zone (DNS record is created here):"))
+	formContent = append(formContent, m.renderDomainDropdown(domainFocused))
 
 	// Add preview for hostname
 	previewStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#9CA3AF")).
+		Foreground(colors.muted).
 		Italic(true).
 		MarginTop(1)
 
@@ -1363,35 +3079,27 @@ func (m Model) renderAddHostnameForm(title string) string {
 		service = m.textInputs[2].Value()
 	}
 
-	// Get selected domain
-	selectedDomain := "example.com"
-	if len(m.availableDomains) > 0 && m.selectedDomainIndex >= 0 && m.selectedDomainIndex < len(m.availableDomains) {
-		selectedDomain = m.availableDomains[m.selectedDomainIndex]
+	if hostname == "" {
+		hostname = "<hostname>"
 	}
-
 	if m.showEditHostname {
-		if hostname == "" {
-			preview = fmt.Sprintf("Will update: <hostname>.%s → %s", selectedDomain, service)
-		} else {
-			preview = fmt.Sprintf("Will update: %s.%s → %s", hostname, selectedDomain, service)
-		}
+		preview = fmt.Sprintf("Will update: %s → %s", m.formHostname(hostname), service)
 	} else {
-		if hostname == "" {
-			preview = fmt.Sprintf("Will create: <hostname>.%s → %s", selectedDomain, service)
-		} else {
-			preview = fmt.Sprintf("Will create: %s.%s → %s", hostname, selectedDomain, service)
-		}
+		preview = fmt.Sprintf("Will create: %s → %s", m.formHostname(hostname), service)
 	}
 
 	previewText := previewStyle.Render(preview)
 	formContent = append(formContent, "", previewText)
+	if limits := m.renderServiceLimits(); limits != "" {
+		formContent = append(formContent, "", limits)
+	}
 
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#10B981")).
+		Foreground(colors.success).
 		MarginTop(2).
 		Italic(true)
 
-	help := helpStyle.Render("Tab: Next field • Up/Down: Select domain • Enter: Submit • Escape: Cancel")
+	help := helpStyle.Render("Tab: Next field • Ctrl+R: Recent services (zone: refresh zones) • Ctrl+T: Homelab presets • Up/Down: Select zone • Enter: Submit • Escape: Cancel • F1: Help")
 	formContent = append(formContent, help)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, formContent...)
@@ -1402,10 +3110,52 @@ func (m Model) renderAddHostnameForm(title string) string {
 	)
 }
 
+func (m Model) renderRecentServices() string {
+	itemStyle := lipgloss.NewStyle().
+		Foreground(colors.muted)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(colors.accent).
+		Bold(true)
+
+	items := []string{itemStyle.Render("Recent (Ctrl+R):")}
+	for i, service := range m.state.RecentServices {
+		if i == m.recentServiceIndex {
+			items = append(items, selectedStyle.Render("▸ "+service))
+		} else {
+			items = append(items, itemStyle.Render("  "+service))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, items...)
+}
+
+// preselectTunnelDomain selects the zone last used for the open tunnel's
+// hostnames, if it is still available. The saved default zone is left alone
+// for the other tunnels.
+func (m *Model) preselectTunnelDomain() {
+	domain := m.state.TunnelDomain(m.selectedTunnelID)
+	if domain == "" {
+		return
+	}
+	for i, candidate := range m.availableDomains {
+		if candidate == domain {
+			m.selectedDomainIndex = i
+			m.client.SetSelectedDomain(domain)
+			return
+		}
+	}
+}
+
+// domainsStale reports whether the zone list is missing or older than the
+// configured cache TTL
+func (m Model) domainsStale() bool {
+	return len(m.availableDomains) == 0 || time.Since(m.domainsLoadedAt) > m.config.DomainCacheTTL()
+}
+
 func (m Model) renderDomainDropdown(focused bool) string {
 	if len(m.availableDomains) == 0 {
 		loadingStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#9CA3AF")).
+			Foreground(colors.muted).
 			Italic(true)
 		return loadingStyle.Render("Loading domains...")
 	}
@@ -1414,13 +3164,13 @@ func (m Model) renderDomainDropdown(focused bool) string {
 	if focused {
 		style = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7C3AED")).
+			BorderForeground(colors.accent).
 			Padding(0, 1).
-			Background(lipgloss.Color("#F3F4F6"))
+			Background(colors.heading)
 	} else {
 		style = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#D1D5DB")).
+			BorderForeground(colors.border).
 			Padding(0, 1)
 	}
 
@@ -1434,23 +3184,39 @@ func (m Model) renderDomainDropdown(focused bool) string {
 		content += " ▼"
 	}
 
-	return style.Width(30).Render(content)
+	dropdown := style.Width(30).Render(content)
+	if !focused {
+		return dropdown
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+	hint := fmt.Sprintf("Zones loaded %s ago • Ctrl+R: Refresh", time.Since(m.domainsLoadedAt).Round(time.Second))
+	if m.domainsRefreshing {
+		hint = "Refreshing zones..."
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, dropdown, hintStyle.Render(hint))
 }
 
 func (m Model) renderStatusBar() string {
 	statusStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#374151")).
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(colors.panel).
+		Foreground(colors.onColor).
 		Padding(0, 1).
 		Width(m.width)
 
 	status := m.statusMessage
-	if m.errorMessage != "" {
-		errorStyle := lipgloss.NewStyle().
-			Background(lipgloss.Color("#EF4444")).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Bold(true)
-		status = errorStyle.Render("ERROR: " + m.errorMessage)
+	if m.loading {
+		status = m.spinner.View() + " " + status
+	}
+	if pending := m.renderPendingTasks(); pending != "" {
+		status += " • " + pending
+	}
+	// Errors and warnings are toasts now; point at the history once one
+	// has gone
+	if len(m.toasts) == 0 && len(m.toastHistory) > 0 {
+		status += fmt.Sprintf(" • %d error(s)/warning(s) - press ! to review", len(m.toastHistory))
 	}
 
 	return statusStyle.Render(status)
@@ -1458,72 +3224,30 @@ func (m Model) renderStatusBar() string {
 
 func (m Model) renderFooter() string {
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6B7280")).
+		Foreground(colors.subtle).
 		Italic(true).
 		Align(lipgloss.Center).
 		Width(m.width)
 
+	k := m.keys.Key
 	var help string
 	if m.showAddHostname || m.showEditHostname {
-		help = "Tab: Next field • Up/Down: Select domain • Enter: Submit • Escape: Cancel • q: Quit"
+		help = "Tab: Next field • Up/Down: Select zone • Enter: Submit • Escape: Cancel • F1: Help • Ctrl+C: Quit"
+	} else if m.showNewTunnel {
+		help = "Tab: Next field • Space: Toggle checkbox • Enter: Next field / Create • Escape: Cancel • F1: Help"
 	} else if m.showTunnelHostnames {
-		help = "a: Add hostname • e: Edit selected • d: Delete (with DNS) • Shift+A: Toggle auth • Escape: Back to tunnels • r: Refresh • c: Clear errors • h: Help • q: Quit"
+		help = fmt.Sprintf("%s: Add hostname • %s: Edit • %s: Delete (with DNS) • Shift+A: Toggle auth • y: Copy URL • Escape: Back to tunnels • %s: Refresh • Ctrl+P: Commands • h/?: Help • %s: Quit", k(models.ActionAdd), k(models.ActionEdit), k(models.ActionDelete), k(models.ActionRefresh), k(models.ActionQuit))
+	} else if m.activeTab == processesTab {
+		help = fmt.Sprintf("↑↓: Navigate • x: Stop • R: Restart • %s: Refresh stats • Tab: DNS • h: Help • %s: Quit", k(models.ActionRefresh), k(models.ActionQuit))
+	} else if m.activeTab == dnsTab {
+		help = fmt.Sprintf("↑↓: Navigate • Enter/Space: Expand/collapse zone • %s: Refresh zones • Tab: Tunnels • h: Help • %s: Quit", k(models.ActionRefresh), k(models.ActionQuit))
 	} else {
-		help = "↑↓: Navigate • Enter/Space: View hostnames • d: Delete tunnel • Escape: Close help • r: Refresh • h: Help • q: Quit"
+		help = fmt.Sprintf("↑↓: Navigate • Enter: View hostnames • Tab: Processes/DNS • n: New tunnel • %s: Add hostname • s: Start/stop locally • %s: Delete tunnel • %s: Refresh • Ctrl+P: Commands • h/?: Help • %s: Quit", k(models.ActionAdd), k(models.ActionDelete), k(models.ActionRefresh), k(models.ActionQuit))
 	}
 
-	return helpStyle.Render(help)
-}
-
-func (m Model) renderHelp() string {
-	helpStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7C3AED")).
-		Padding(2).
-		Width(m.width - 4).
-		Height(m.height - 4)
-
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#7C3AED")).
-		Align(lipgloss.Center).
-		MarginBottom(2)
+	if hint := m.inFlightHint(); hint != "" {
+		help = hint + " • " + help
+	}
 
-	keyStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#10B981"))
-
-	descStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#D1D5DB"))
-
-	content := []string{
-		titleStyle.Render("🔧 Tunnelman Help"),
-		"",
-		"NAVIGATION:",
-		fmt.Sprintf("  %s      %s", keyStyle.Render("↑/↓ or k/j"), descStyle.Render("Navigate up/down in tunnel list")),
-		"",
-		"TUNNEL OPERATIONS:",
-		fmt.Sprintf("  %s       %s", keyStyle.Render("Enter"), descStyle.Render("View public hostnames for tunnel")),
-		fmt.Sprintf("  %s       %s", keyStyle.Render("Space"), descStyle.Render("View public hostnames for tunnel")),
-		fmt.Sprintf("  %s           %s", keyStyle.Render("o"), descStyle.Render("Open tunnel configuration in browser (works in both tunnel and hostname views)")),
-		fmt.Sprintf("  %s           %s", keyStyle.Render("a"), descStyle.Render("Add public hostname (in tunnel hostname view)")),
-		fmt.Sprintf("  %s           %s", keyStyle.Render("e"), descStyle.Render("Edit public hostname (in tunnel hostname view)")),
-		fmt.Sprintf("  %s           %s", keyStyle.Render("d"), descStyle.Render("Delete selected tunnel or hostname (with confirmation + DNS cleanup)")),
-		fmt.Sprintf("  %s         %s", keyStyle.Render("Shift+A"), descStyle.Render("Toggle authentication for selected hostname (6-digit password)")),
-		"",
-		"HOSTNAME OPERATIONS:",
-		fmt.Sprintf("  %s           %s", keyStyle.Render("Tab"), descStyle.Render("Navigate between hostname form fields")),
-		fmt.Sprintf("  %s       %s", keyStyle.Render("Enter"), descStyle.Render("Submit hostname form or move to next field")),
-		"",
-		"GENERAL:",
-		fmt.Sprintf("  %s           %s", keyStyle.Render("r"), descStyle.Render("Refresh tunnels")),
-		fmt.Sprintf("  %s           %s", keyStyle.Render("c"), descStyle.Render("Clear error messages")),
-		fmt.Sprintf("  %s       %s", keyStyle.Render("h or ?"), descStyle.Render("Toggle this help")),
-		fmt.Sprintf("  %s         %s", keyStyle.Render("Escape"), descStyle.Render("Go back/cancel (close help, exit forms, return to tunnel list)")),
-		fmt.Sprintf("  %s      %s", keyStyle.Render("q or Ctrl+C"), descStyle.Render("Quit application")),
-		"",
-		descStyle.Render("Press 'h' again to close this help screen."),
-	}
-
-	return helpStyle.Render(lipgloss.JoinVertical(lipgloss.Left, content...))
+	return helpStyle.Render(help)
 }
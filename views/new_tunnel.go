@@ -0,0 +1,309 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fields of the new tunnel wizard, in focus order
+const (
+	newTunnelNameField = iota
+	newTunnelHostnameField
+	newTunnelServiceField
+	newTunnelStartField
+	newTunnelFieldCount
+)
+
+// tunnelCreatedMsg reports how far the new tunnel wizard got. The tunnel is
+// set once it exists even if configuring or starting it failed afterwards.
+type tunnelCreatedMsg struct {
+	tunnel   *models.CLITunnel
+	hostname string
+	process  *models.TunnelProcess
+	err      error
+}
+
+// openNewTunnel shows the wizard that creates a tunnel, optionally with a
+// first hostname and its connector running locally
+func (m *Model) openNewTunnel() {
+	m.newTunnelInputs = make([]textinput.Model, newTunnelStartField)
+
+	m.newTunnelInputs[newTunnelNameField] = textinput.New()
+	m.newTunnelInputs[newTunnelNameField].Placeholder = m.tunnelPrefix + "my-tunnel"
+	m.newTunnelInputs[newTunnelNameField].SetValue(m.tunnelPrefix)
+	m.newTunnelInputs[newTunnelNameField].CharLimit = 64
+	m.newTunnelInputs[newTunnelNameField].Width = 30
+
+	m.newTunnelInputs[newTunnelHostnameField] = textinput.New()
+	m.newTunnelInputs[newTunnelHostnameField].Placeholder = "app.example.com"
+	m.newTunnelInputs[newTunnelHostnameField].CharLimit = 100
+	m.newTunnelInputs[newTunnelHostnameField].Width = 40
+
+	m.newTunnelInputs[newTunnelServiceField] = textinput.New()
+	m.newTunnelInputs[newTunnelServiceField].Placeholder = "http://localhost:8080"
+	m.newTunnelInputs[newTunnelServiceField].CharLimit = 100
+	m.newTunnelInputs[newTunnelServiceField].Width = 40
+
+	m.newTunnelFocus = newTunnelNameField
+	m.newTunnelStart = m.tunnelManager != nil
+	m.showNewTunnel = true
+	m.updateNewTunnelFocus()
+	m.statusMessage = "Create a tunnel (Tab: Next field • Enter on the last field: Create)"
+}
+
+func (m *Model) updateNewTunnelFocus() {
+	for i := range m.newTunnelInputs {
+		if i == m.newTunnelFocus {
+			m.newTunnelInputs[i].Focus()
+		} else {
+			m.newTunnelInputs[i].Blur()
+		}
+	}
+}
+
+func (m *Model) closeNewTunnel() {
+	m.showNewTunnel = false
+	m.newTunnelInputs = nil
+	m.newTunnelFocus = 0
+}
+
+// submitNewTunnel validates the wizard and returns the command that creates
+// the tunnel, or nil with a status message when the input is incomplete
+func (m *Model) submitNewTunnel() tea.Cmd {
+	name := strings.TrimSpace(m.newTunnelInputs[newTunnelNameField].Value())
+	hostname := strings.ToLower(strings.TrimSpace(m.newTunnelInputs[newTunnelHostnameField].Value()))
+	service := strings.TrimSpace(m.newTunnelInputs[newTunnelServiceField].Value())
+
+	if name == "" || name == m.tunnelPrefix {
+		m.statusMessage = "Tunnel name cannot be empty"
+		return nil
+	}
+	if strings.ContainsAny(name, " \t/") {
+		m.statusMessage = "Tunnel names can't contain spaces or slashes"
+		return nil
+	}
+	for _, tunnel := range m.tunnelsList {
+		if strings.EqualFold(tunnel.Name, name) {
+			m.statusMessage = fmt.Sprintf("A tunnel named %s already exists", tunnel.Name)
+			return nil
+		}
+	}
+	if hostname != "" && !strings.Contains(hostname, ".") {
+		m.statusMessage = "Enter the full hostname, e.g. app.example.com"
+		return nil
+	}
+	if hostname != "" && service == "" {
+		service = m.newTunnelInputs[newTunnelServiceField].Placeholder
+	}
+	if hostname != "" {
+		m.state.AddRecentService(service)
+		m.state.Save()
+	}
+
+	start := m.newTunnelStart
+	m.closeNewTunnel()
+	m.statusMessage = fmt.Sprintf("Creating tunnel %s...", name)
+	return m.createTunnel(name, hostname, service, start)
+}
+
+// createTunnel creates the tunnel, adds its first hostname and starts its
+// connector, stopping at the first step that fails
+func (m Model) createTunnel(name, hostname, service string, start bool) tea.Cmd {
+	client := m.client
+	tunnelManager := m.tunnelManager
+	tunnels := m.tunnelsList
+
+	return m.cancellable("create tunnel "+name, func(ctx context.Context) tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		// Check the hostname first so a conflict doesn't leave an empty tunnel behind
+		if hostname != "" {
+			conflict, err := client.CheckHostnameConflict(ctx, hostname, "", tunnels)
+			if err != nil {
+				return apiErrorMsg("check for hostname conflicts", err)
+			}
+			if conflict != nil {
+				return errorMsg(fmt.Sprintf("Not created: %v", conflict))
+			}
+		}
+
+		tunnel, err := client.CreateTunnel(ctx, name)
+		if err != nil {
+			return apiErrorMsg("create tunnel", err)
+		}
+
+		result := tunnelCreatedMsg{tunnel: tunnel}
+		if hostname != "" {
+			if err := client.ConfigureNewTunnel(ctx, tunnel, hostname, service); err != nil {
+				result.err = err
+				return result
+			}
+			result.hostname = hostname
+		}
+
+		if start && tunnelManager != nil {
+			process, err := tunnelManager.StartTunnel(context.Background(), tunnel.Name, nil)
+			if err != nil {
+				result.err = fmt.Errorf("failed to start connector: %w", err)
+				return result
+			}
+			result.process = process
+		}
+
+		return result
+	})
+}
+
+// handleTunnelCreated reports the wizard's outcome and selects the new
+// tunnel, which the reload then keeps the cursor on
+func (m *Model) handleTunnelCreated(msg tunnelCreatedMsg) tea.Cmd {
+	parts := []string{"Created tunnel " + msg.tunnel.Name}
+	if msg.hostname != "" {
+		parts = append(parts, "routed https://"+msg.hostname)
+	}
+	if msg.process != nil {
+		parts = append(parts, fmt.Sprintf("connector running (PID %d)", msg.process.PID))
+	}
+	m.statusMessage = strings.Join(parts, " • ")
+	if msg.err != nil {
+		m.errorMessage = msg.err.Error()
+	}
+
+	m.tunnelsList = append(m.tunnelsList, *msg.tunnel)
+	m.selectedTunnel = len(m.tunnelsList) - 1
+	cmds := []tea.Cmd{m.loadTunnels()}
+	m.trackPropagation(msg.hostname)
+	// The edge can only answer once a connector is up
+	if msg.process != nil {
+		cmds = append(cmds, m.smokeTestHostname(msg.hostname))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) handleNewTunnelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.closeNewTunnel()
+		m.statusMessage = "Cancelled"
+		return m, nil
+
+	case "tab", "down":
+		m.newTunnelFocus = (m.newTunnelFocus + 1) % newTunnelFieldCount
+		m.updateNewTunnelFocus()
+		return m, nil
+
+	case "shift+tab", "up":
+		m.newTunnelFocus = (m.newTunnelFocus + newTunnelFieldCount - 1) % newTunnelFieldCount
+		m.updateNewTunnelFocus()
+		return m, nil
+
+	case "enter":
+		if m.newTunnelFocus == newTunnelStartField {
+			return m, m.submitNewTunnel()
+		}
+		m.newTunnelFocus++
+		m.updateNewTunnelFocus()
+		return m, nil
+
+	case " ":
+		if m.newTunnelFocus == newTunnelStartField {
+			if m.tunnelManager == nil {
+				m.statusMessage = "Tunnel manager not initialized - the connector can't be started from here"
+				return m, nil
+			}
+			m.newTunnelStart = !m.newTunnelStart
+			return m, nil
+		}
+	}
+
+	if m.newTunnelFocus >= len(m.newTunnelInputs) {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.newTunnelInputs[m.newTunnelFocus], cmd = m.newTunnelInputs[m.newTunnelFocus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderNewTunnel() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.border)
+
+	focusedLabelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	label := func(field int, text string) string {
+		if field == m.newTunnelFocus {
+			return focusedLabelStyle.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	lines := []string{titleStyle.Render("🚇 New Tunnel")}
+	if len(m.newTunnelInputs) < newTunnelStartField {
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	lines = append(lines,
+		label(newTunnelNameField, "Tunnel name:"),
+		m.newTunnelInputs[newTunnelNameField].View(),
+		"",
+		label(newTunnelHostnameField, "First hostname (optional, full name e.g. app.example.com):"),
+		m.newTunnelInputs[newTunnelHostnameField].View(),
+		"",
+		label(newTunnelServiceField, "Service for the hostname:"),
+		m.newTunnelInputs[newTunnelServiceField].View(),
+		"",
+	)
+
+	check := "[ ]"
+	if m.newTunnelStart {
+		check = "[x]"
+	}
+	lines = append(lines, label(newTunnelStartField, check+" Start the connector locally now"))
+
+	name := strings.TrimSpace(m.newTunnelInputs[newTunnelNameField].Value())
+	if name == "" {
+		name = "<name>"
+	}
+	steps := []string{"create " + name}
+	if hostname := strings.TrimSpace(m.newTunnelInputs[newTunnelHostnameField].Value()); hostname != "" {
+		steps = append(steps, "route https://"+hostname)
+	}
+	if m.newTunnelStart {
+		steps = append(steps, "run cloudflared tunnel run "+name)
+	}
+	lines = append(lines, "", hintStyle.Render("Will "+strings.Join(steps, " → ")))
+
+	lines = append(lines, "", hintStyle.Render(strings.Join([]string{
+		"Tab: Next field", "Space: Toggle checkbox", "Enter on the checkbox: Create", "Escape: Cancel",
+	}, " • ")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
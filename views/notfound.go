@@ -0,0 +1,72 @@
+package views
+
+import (
+	"context"
+	"fmt"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type notFoundToggledMsg struct {
+	tunnelID string
+	enabled  bool
+	service  string
+}
+
+// startNotFoundServer restarts the catch-all page for tunnels that used it in
+// a previous session, since their catch-all rule still points at it
+func (m *Model) startNotFoundServer() {
+	if m.tunnelManager == nil || len(m.state.CustomNotFound) == 0 {
+		return
+	}
+	if _, err := m.tunnelManager.StartNotFoundServer(m.config.NotFoundPort()); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to start the catch-all page server: %v", err)
+	}
+}
+
+// toggleNotFoundPage switches the tunnel's catch-all rule between
+// cloudflared's bare 404 and the page served by tunnelman
+func (m Model) toggleNotFoundPage(tunnelID string) tea.Cmd {
+	enable := !m.state.HasCustomNotFound(tunnelID)
+
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		if m.tunnelManager == nil {
+			return errorMsg("Tunnel manager not initialized")
+		}
+
+		service := models.DefaultCatchAllService
+		if enable {
+			server, err := m.tunnelManager.StartNotFoundServer(m.config.NotFoundPort())
+			if err != nil {
+				return errorMsg(fmt.Sprintf("Failed to start the catch-all page server: %v", err))
+			}
+			service = server.URL()
+		}
+
+		if err := m.client.SetCatchAllService(context.Background(), tunnelID, service); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to update the catch-all rule: %v", err))
+		}
+
+		return notFoundToggledMsg{tunnelID: tunnelID, enabled: enable, service: service}
+	})
+}
+
+func (m *Model) handleNotFoundToggled(msg notFoundToggledMsg) {
+	m.state.SetCustomNotFound(msg.tunnelID, msg.enabled)
+	m.state.Save()
+
+	if msg.enabled {
+		m.statusMessage = fmt.Sprintf("Catch-all now serves the tunnelman 404 page (%s) while tunnelman runs", msg.service)
+		return
+	}
+
+	if len(m.state.CustomNotFound) == 0 && m.tunnelManager != nil {
+		m.tunnelManager.StopStaticServer(models.NotFoundServerKey)
+	}
+	m.statusMessage = fmt.Sprintf("Catch-all restored to %s", msg.service)
+}
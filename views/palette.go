@@ -0,0 +1,198 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyMsgFor builds the key press a palette command replays
+func keyMsgFor(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// fuzzyScore matches query as a subsequence of text, ignoring case. Higher
+// scores mean matches at word starts and runs of adjacent characters.
+func fuzzyScore(query, text string) (int, bool) {
+	query = strings.ToLower(query)
+	runes := []rune(strings.ToLower(text))
+	score, pos, previous := 0, 0, -2
+	for _, q := range query {
+		if unicode.IsSpace(q) {
+			continue
+		}
+		found := false
+		for ; pos < len(runes); pos++ {
+			if runes[pos] != q {
+				continue
+			}
+			score++
+			if pos == previous+1 {
+				score += 2
+			}
+			if pos == 0 || !unicode.IsLetter(runes[pos-1]) {
+				score += 3
+			}
+			previous = pos
+			pos++
+			found = true
+			break
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+// paletteMatches lists the commands available on the current screen that
+// match the palette's query, best match first
+func (m Model) paletteMatches() []keyBinding {
+	scope := scopeTunnelList
+	if m.showTunnelHostnames {
+		scope = scopeHostnames
+	}
+
+	query := strings.TrimSpace(m.paletteInput.Value())
+	type match struct {
+		command keyBinding
+		score   int
+	}
+	var matches []match
+	for _, command := range bindingsFor(scope) {
+		if command.name == "" {
+			continue
+		}
+		score, ok := fuzzyScore(query, command.name)
+		if !ok {
+			continue
+		}
+		matches = append(matches, match{command, score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	commands := make([]keyBinding, len(matches))
+	for i, match := range matches {
+		commands[i] = match.command
+	}
+	return commands
+}
+
+func (m *Model) openPalette() {
+	m.showPalette = true
+	m.paletteIndex = 0
+	m.paletteInput = textinput.New()
+	m.paletteInput.Placeholder = "Type to search actions"
+	m.paletteInput.CharLimit = 50
+	m.paletteInput.Width = 40
+	m.paletteInput.Focus()
+	m.statusMessage = "Command palette"
+}
+
+func (m Model) handlePaletteInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.paletteMatches()
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape", "ctrl+p":
+		m.showPalette = false
+		m.statusMessage = "Closed command palette"
+		return m, nil
+
+	case "up", "ctrl+k":
+		if m.paletteIndex > 0 {
+			m.paletteIndex--
+		}
+		return m, nil
+
+	case "down", "ctrl+j":
+		if m.paletteIndex < len(matches)-1 {
+			m.paletteIndex++
+		}
+		return m, nil
+
+	case "enter":
+		if m.paletteIndex >= len(matches) {
+			return m, nil
+		}
+		m.showPalette = false
+		return m.Update(keyMsgFor(m.keys.Bound(matches[m.paletteIndex].key)))
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.paletteIndex = 0
+	return m, cmd
+}
+
+func (m Model) renderPalette() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(colors.muted)
+
+	selectedStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render("⌘ Command Palette"), m.paletteInput.View(), ""}
+
+	matches := m.paletteMatches()
+	if len(matches) == 0 {
+		lines = append(lines, hintStyle.Render("No matching actions"))
+	}
+
+	// Keep the selection in view on short terminals
+	visible := m.height - 22
+	if visible < 5 {
+		visible = 5
+	}
+	start := 0
+	if m.paletteIndex >= visible {
+		start = m.paletteIndex - visible + 1
+	}
+	for i := start; i < len(matches) && i < start+visible; i++ {
+		key := keyLabel(m.keys.Bound(matches[i].key))
+		if i == m.paletteIndex {
+			lines = append(lines, selectedStyle.Render(fmt.Sprintf("▶ %-32s %s", matches[i].name, key)))
+		} else {
+			lines = append(lines, rowStyle.Render(fmt.Sprintf("  %-32s ", matches[i].name))+keyStyle.Render(key))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render("↑↓: Select • Enter: Run • Escape: Close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,127 @@
+package views
+
+import (
+	"context"
+	"fmt"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openPathRules starts the path routing helper for the selected hostname,
+// prefilled with the rules that currently route it
+func (m *Model) openPathRules() {
+	if m.selectedHostnameIndex >= len(m.tunnelHostnames) {
+		return
+	}
+
+	hostname := m.tunnelHostnames[m.selectedHostnameIndex].Hostname
+	m.pathRulesHostname = hostname
+	m.pathRulesInput = textinput.New()
+	m.pathRulesInput.Placeholder = "/api=http://localhost:8080, /app=http://localhost:3000, *=http://localhost:80"
+	m.pathRulesInput.SetValue(models.FormatPathRules(models.GetPathRules(m.tunnelHostnames, hostname)))
+	m.pathRulesInput.CharLimit = 500
+	m.pathRulesInput.Width = 80
+	m.pathRulesInput.Focus()
+	m.pathRulesInput.CursorEnd()
+	m.statusMessage = fmt.Sprintf("Editing path rules for %s", hostname)
+}
+
+// pendingPathRules parses and orders the rules currently typed in the helper
+func (m Model) pendingPathRules() ([]models.PathRule, error) {
+	rules, err := models.ParsePathRules(m.pathRulesInput.Value())
+	if err != nil {
+		return nil, err
+	}
+	return models.OrderPathRules(rules)
+}
+
+func (m Model) handlePathRulesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.pathRulesHostname = ""
+		m.statusMessage = "Path rules unchanged"
+		return m, nil
+
+	case "enter":
+		rules, err := m.pendingPathRules()
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Invalid path rules: %v", err)
+			return m, nil
+		}
+
+		hostname := m.pathRulesHostname
+		m.pathRulesHostname = ""
+		m.statusMessage = fmt.Sprintf("Applying %d path rules for %s", len(rules), hostname)
+		task := queueTask(m.selectedTunnelID, "set path rules for "+hostname, m.setPathRules(hostname, rules))
+		return m, m.guardHostname(hostname, "modify", task)
+	}
+
+	var cmd tea.Cmd
+	m.pathRulesInput, cmd = m.pathRulesInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) setPathRules(hostname string, rules []models.PathRule) tea.Cmd {
+	tunnelID := m.selectedTunnelID
+
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		ctx := context.Background()
+		if err := m.client.SetPathRules(ctx, tunnelID, hostname, rules); err != nil {
+			return apiErrorMsg("update path rules", err)
+		}
+
+		return statusMsg(fmt.Sprintf("Successfully updated public hostname: %s (%d path rules)", hostname, len(rules)))
+	})
+}
+
+func (m Model) renderPathRules() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	ruleStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("🔀 Path rules for %s", m.pathRulesHostname)),
+		"Rules as path=service, separated by commas. Use * for the catch-all:",
+		m.pathRulesInput.View(),
+		"",
+	}
+
+	rules, err := m.pendingPathRules()
+	if err != nil {
+		lines = append(lines, errorStyle.Render("✗ "+err.Error()))
+	} else {
+		lines = append(lines, "Match order (more specific paths first, catch-all last):")
+		for i, rule := range rules {
+			lines = append(lines, ruleStyle.Render(fmt.Sprintf("  %d. %s  %s", i+1, m.pathRulesHostname, rule)))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render("Enter: Apply in this order • Escape: Cancel"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,78 @@
+package views
+
+import (
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pollKind is one of the independently polled parts of the tunnel list
+type pollKind int
+
+const (
+	pollList pollKind = iota
+	pollStatuses
+	pollDomainCounts
+)
+
+type pollMsg pollKind
+
+// schedulePoll arms the next poll of kind, or does nothing when polling is
+// on demand only
+func (m Model) schedulePoll(kind pollKind) tea.Cmd {
+	var interval time.Duration
+	switch kind {
+	case pollList:
+		interval = m.config.ListPollInterval()
+	case pollStatuses:
+		interval = m.config.StatusPollInterval()
+	case pollDomainCounts:
+		interval = m.config.DomainCountPollInterval()
+	}
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg { return pollMsg(kind) })
+}
+
+func (m Model) startPolling() tea.Cmd {
+	return tea.Batch(m.schedulePoll(pollList), m.schedulePoll(pollStatuses), m.schedulePoll(pollDomainCounts))
+}
+
+// pollPaused reports whether background reloads should wait, because a form
+// is open or writes are in flight and a reload could reset the selection out
+// from under them
+func (m Model) pollPaused() bool {
+	return m.showAddHostname || m.showEditHostname || m.serviceEdit != nil || m.pathRulesHostname != "" || m.pendingTasks() > 0
+}
+
+func (m Model) handlePoll(kind pollKind) tea.Cmd {
+	next := m.schedulePoll(kind)
+	if m.pollPaused() {
+		return next
+	}
+	switch kind {
+	case pollList:
+		return tea.Batch(next, m.loadTunnels())
+	case pollStatuses:
+		return tea.Batch(next, m.loadTunnelStatuses(m.tunnelsList))
+	default:
+		return tea.Batch(next, m.loadTunnelDomainCounts(m.tunnelsList))
+	}
+}
+
+// tunnelsMissingDetails returns the tunnels whose status or domain count
+// hasn't been loaded yet, such as ones that just appeared in the list
+func (m Model) tunnelsMissingDetails() []models.CLITunnel {
+	var missing []models.CLITunnel
+	for _, tunnel := range m.tunnelsList {
+		_, hasStatus := m.tunnelStatuses[tunnel.ID]
+		_, hasCount := m.tunnelDomainCounts[tunnel.ID]
+		if !hasStatus || !hasCount {
+			missing = append(missing, tunnel)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,74 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// applyNextPreset fills the add form's service, and the hostname while it's
+// empty or still the last preset's suggestion, from the next catalog entry
+func (m *Model) applyNextPreset() {
+	if len(m.textInputs) < 3 || len(models.ServicePresets) == 0 {
+		return
+	}
+	hostname := m.textInputs[0].Value()
+	if m.presetIndex >= 0 && hostname == models.ServicePresets[m.presetIndex].Subdomain {
+		hostname = ""
+	}
+
+	m.presetIndex = (m.presetIndex + 1) % len(models.ServicePresets)
+	preset := models.ServicePresets[m.presetIndex]
+	m.textInputs[2].SetValue(preset.Service)
+	m.textInputs[2].CursorEnd()
+	if hostname == "" {
+		m.textInputs[0].SetValue(preset.Subdomain)
+		m.textInputs[0].CursorEnd()
+	}
+	m.statusMessage = fmt.Sprintf("Preset: %s (Ctrl+T for the next one)", preset.Name)
+}
+
+// presetOriginRequest returns the selected preset's originRequest options
+// while the service still is the preset's
+func (m Model) presetOriginRequest(service string) map[string]interface{} {
+	if m.presetIndex < 0 || m.presetIndex >= len(models.ServicePresets) {
+		return nil
+	}
+	preset := models.ServicePresets[m.presetIndex]
+	if service != preset.Service {
+		return nil
+	}
+	return preset.OriginRequest
+}
+
+func (m Model) renderPreset() string {
+	if m.presetIndex < 0 || m.presetIndex >= len(models.ServicePresets) {
+		return ""
+	}
+	preset := models.ServicePresets[m.presetIndex]
+
+	nameStyle := lipgloss.NewStyle().
+		Foreground(colors.accent).
+		Bold(true)
+
+	detailStyle := lipgloss.NewStyle().
+		Foreground(colors.muted)
+
+	authStyle := lipgloss.NewStyle().
+		Foreground(colors.warning)
+
+	line := nameStyle.Render("Preset: " + preset.Name)
+	if options := m.presetOriginRequest(m.textInputs[2].Value()); len(options) > 0 {
+		keys := make([]string, 0, len(options))
+		for key, value := range options {
+			keys = append(keys, fmt.Sprintf("%s=%v", key, value))
+		}
+		sort.Strings(keys)
+		line += detailStyle.Render(" • originRequest: " + strings.Join(keys, ", "))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, line, authStyle.Render("🔐 "+preset.Auth))
+}
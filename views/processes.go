@@ -0,0 +1,298 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	tunnelsTab = iota
+	processesTab
+	dnsTab
+)
+
+type processStatsLoadedMsg map[string]models.ProcessStats
+type processActionMsg struct {
+	message string
+	err     error
+}
+
+// processRow is one entry of the Processes tab: either a process spawned by
+// the TunnelManager or a server running inside tunnelman itself
+type processRow struct {
+	key     string
+	server  bool
+	pid     int
+	name    string
+	status  string
+	uptime  time.Duration
+	command string
+}
+
+func (m Model) processRows() []processRow {
+	if m.tunnelManager == nil {
+		return nil
+	}
+
+	var rows []processRow
+	processes := m.tunnelManager.GetAllProcesses()
+	for _, key := range m.tunnelManager.ProcessKeys() {
+		process := processes[key]
+		status := "stopped"
+		if process.IsRunning() {
+			status = "running"
+		} else if process.Status == models.StatusError {
+			status = "exited"
+		}
+		if process.Supervisor != "" && process.Supervisor != models.SupervisorExec {
+			status += " (" + string(process.Supervisor) + ")"
+		}
+		rows = append(rows, processRow{
+			key:     key,
+			pid:     process.PID,
+			name:    process.Name,
+			status:  status,
+			uptime:  process.GetUptime(),
+			command: strings.Join(process.Command, " "),
+		})
+	}
+
+	servers := m.tunnelManager.GetStaticServers()
+	keys := make([]string, 0, len(servers))
+	for key := range servers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		server := servers[key]
+		command := "serve " + server.URL()
+		if server.Root != "" {
+			command += " " + server.Root
+		}
+		rows = append(rows, processRow{
+			key:     key,
+			server:  true,
+			pid:     os.Getpid(),
+			name:    key,
+			status:  "serving",
+			uptime:  time.Since(server.StartTime),
+			command: command,
+		})
+	}
+
+	return rows
+}
+
+func (m Model) loadProcessStats() tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return processStatsLoadedMsg(nil)
+		}
+		return processStatsLoadedMsg(m.tunnelManager.GetProcessStats())
+	})
+}
+
+// checkProcessLimits compares the latest stats against the configured
+// thresholds. A process is reported once it has been over a limit for
+// ProcessLimitChecks samples in a row, and restarted if configured to.
+func (m *Model) checkProcessLimits() tea.Cmd {
+	if !m.config.HasProcessLimits() || m.tunnelManager == nil {
+		return nil
+	}
+	if m.processBreaches == nil {
+		m.processBreaches = make(map[string]int)
+	}
+
+	processes := m.tunnelManager.GetAllProcesses()
+	var cmds []tea.Cmd
+	for key := range m.processBreaches {
+		if _, ok := m.processStats[key]; !ok {
+			delete(m.processBreaches, key)
+		}
+	}
+	for key, stats := range m.processStats {
+		reason := m.config.ProcessLimitExceeded(stats)
+		if reason == "" {
+			delete(m.processBreaches, key)
+			continue
+		}
+
+		m.processBreaches[key]++
+		if m.processBreaches[key] != models.ProcessLimitChecks {
+			continue
+		}
+
+		process, ok := processes[key]
+		if !ok {
+			continue
+		}
+		m.warningMessage = fmt.Sprintf("%s (PID %d): %s", process.Name, process.PID, reason)
+		if m.config.RestartOnLimit {
+			m.warningMessage += " - restarting"
+			delete(m.processBreaches, key)
+			cmds = append(cmds, m.restartManagedProcess(processRow{key: key, name: process.Name}))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (m Model) stopManagedProcess(row processRow) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return errorMsg("Tunnel manager not initialized")
+		}
+
+		var err error
+		if row.server {
+			err = m.tunnelManager.StopStaticServer(row.key)
+		} else {
+			err = m.tunnelManager.StopProcess(row.key)
+		}
+		return processActionMsg{message: fmt.Sprintf("Stopped %s", row.name), err: err}
+	})
+}
+
+func (m Model) restartManagedProcess(row processRow) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return errorMsg("Tunnel manager not initialized")
+		}
+
+		process, err := m.tunnelManager.RestartProcess(row.key)
+		if err != nil {
+			return processActionMsg{err: err}
+		}
+		return processActionMsg{message: fmt.Sprintf("Restarted %s (PID %d)", row.name, process.PID)}
+	})
+}
+
+func (m Model) handleProcessesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.processRows()
+
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "tab":
+		return m, m.openDNSTab()
+
+	case "shift+tab", "esc", "escape":
+		m.activeTab = tunnelsTab
+		m.statusMessage = "Returned to tunnel list"
+
+	case "h", "?":
+		m.showHelp = !m.showHelp
+		m.state.ToggleHelp()
+
+	case "up", "k":
+		if m.selectedProcess > 0 {
+			m.selectedProcess--
+		}
+
+	case "down", "j":
+		if m.selectedProcess < len(rows)-1 {
+			m.selectedProcess++
+		}
+
+	case "r":
+		m.statusMessage = "Refreshing process stats..."
+		return m, m.loadProcessStats()
+
+	case "x":
+		if m.selectedProcess < len(rows) {
+			row := rows[m.selectedProcess]
+			m.statusMessage = fmt.Sprintf("Stopping %s...", row.name)
+			return m, m.stopManagedProcess(row)
+		}
+
+	case "R":
+		if m.selectedProcess < len(rows) {
+			row := rows[m.selectedProcess]
+			if row.server {
+				m.statusMessage = "Built-in servers can't be restarted - recreate the hostname instead"
+				return m, nil
+			}
+			m.statusMessage = fmt.Sprintf("Restarting %s...", row.name)
+			return m, m.restartManagedProcess(row)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) renderProcesses() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	cursorStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render("⚙️  Managed Processes")}
+
+	rows := m.processRows()
+	if len(rows) == 0 {
+		lines = append(lines, hintStyle.Render("tunnelman isn't running any connectors, access clients or servers"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	format := "%-8s %-28s %-18s %-10s %-10s %-6s %s"
+	lines = append(lines, headerStyle.Render(fmt.Sprintf(format, "PID", "NAME", "STATUS", "UPTIME", "MEMORY", "CPU", "COMMAND")))
+
+	for i, row := range rows {
+		memory, cpu := "-", "-"
+		if stats, ok := m.processStats[row.key]; ok && !row.server {
+			memory = models.FormatBytes(stats.RSSBytes)
+			cpu = fmt.Sprintf("%.1f%%", stats.CPUPercent)
+		}
+
+		name := row.name
+		if len(name) > 28 {
+			name = name[:25] + "..."
+		}
+		command := row.command
+		if len(command) > 60 {
+			command = command[:57] + "..."
+		}
+
+		line := fmt.Sprintf(format, fmt.Sprint(row.pid), name, row.status, row.uptime.Round(time.Second), memory, cpu, command)
+		if i == m.selectedProcess {
+			lines = append(lines, cursorStyle.Render(line))
+		} else {
+			lines = append(lines, rowStyle.Render(line))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render(strings.Join([]string{
+		"↑↓: Navigate", "x: Stop", "R: Restart", m.keys.Bound("r") + ": Refresh stats", "Tab/Escape: Back to tunnels",
+	}, " • ")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,79 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// propagationTimeout is how long a new record is checked before the badge is
+// dropped; resolvers that still don't see it by then have a bigger problem
+const propagationTimeout = 10 * time.Minute
+
+type propagationCheckedMsg struct {
+	hostname string
+	resolved bool
+}
+
+// trackPropagation marks a hostname whose CNAME was just created or changed
+// as propagating until public resolvers see it
+func (m *Model) trackPropagation(hostname string) {
+	if hostname == "" {
+		return
+	}
+	if m.propagating == nil {
+		m.propagating = make(map[string]time.Time)
+	}
+	m.propagating[strings.ToLower(hostname)] = time.Now()
+}
+
+// checkPropagation queries 1.1.1.1 for every propagating hostname that isn't
+// already being checked, forgetting the ones past propagationTimeout
+func (m *Model) checkPropagation() tea.Cmd {
+	if m.propagationChecks == nil {
+		m.propagationChecks = make(map[string]bool)
+	}
+
+	var cmds []tea.Cmd
+	for hostname, since := range m.propagating {
+		if time.Since(since) > propagationTimeout {
+			delete(m.propagating, hostname)
+			continue
+		}
+		if m.propagationChecks[hostname] {
+			continue
+		}
+		m.propagationChecks[hostname] = true
+		hostname := hostname
+		cmds = append(cmds, func() tea.Msg {
+			resolved, _ := models.HostnamePropagated(context.Background(), hostname)
+			return propagationCheckedMsg{hostname: hostname, resolved: resolved}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) handlePropagationChecked(msg propagationCheckedMsg) {
+	delete(m.propagationChecks, msg.hostname)
+	if !msg.resolved {
+		return
+	}
+	if since, tracked := m.propagating[msg.hostname]; tracked {
+		delete(m.propagating, msg.hostname)
+		m.statusMessage = fmt.Sprintf("🌍 %s now resolves publicly (after %s)", msg.hostname, time.Since(since).Round(time.Second))
+	}
+}
+
+// propagationBadge returns the badge shown next to a hostname whose record
+// public resolvers haven't picked up yet
+func (m Model) propagationBadge(hostname string) string {
+	if _, tracked := m.propagating[strings.ToLower(hostname)]; tracked {
+		return "⧗ propagating…"
+	}
+	return ""
+}
@@ -0,0 +1,169 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type proxyRuleLoadedMsg struct {
+	hostname string
+	rule     *models.TunnelConfigIngress
+	err      error
+}
+
+// openProxySnippet loads the selected hostname's ingress rule, with its
+// origin request settings, for a Caddy or nginx snippet that matches it
+func (m *Model) openProxySnippet() tea.Cmd {
+	if m.selectedHostnameIndex >= len(m.tunnelHostnames) {
+		return nil
+	}
+	hostname := m.tunnelHostnames[m.selectedHostnameIndex]
+	if !strings.HasPrefix(hostname.Service, "http://") && !strings.HasPrefix(hostname.Service, "https://") {
+		m.statusMessage = "Proxy snippets are only available for http:// and https:// hostnames"
+		return nil
+	}
+	m.proxySnippetHostname = hostname.Hostname
+	m.proxySnippetRule = nil
+	if m.proxySnippetKind == "" {
+		m.proxySnippetKind = models.ProxyCaddy
+	}
+	m.statusMessage = fmt.Sprintf("Loading the ingress rule of %s...", hostname.Hostname)
+
+	client := m.client
+	tunnelID := m.selectedTunnelID
+	return tea.Cmd(func() tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		config, err := client.GetTunnelConfiguration(context.Background(), tunnelID)
+		if err != nil {
+			return proxyRuleLoadedMsg{hostname: hostname.Hostname, err: err}
+		}
+		for _, rule := range config.Config.Ingress {
+			if rule.Hostname == hostname.Hostname && rule.Path == hostname.Path {
+				return proxyRuleLoadedMsg{hostname: hostname.Hostname, rule: &rule}
+			}
+		}
+		return proxyRuleLoadedMsg{hostname: hostname.Hostname, err: fmt.Errorf("%s is no longer in the tunnel's ingress", hostname.Hostname)}
+	})
+}
+
+func (m *Model) handleProxyRuleLoaded(msg proxyRuleLoadedMsg) {
+	if msg.hostname != m.proxySnippetHostname {
+		return
+	}
+	if msg.err != nil {
+		m.proxySnippetHostname = ""
+		m.errorMessage = string(apiErrorMsg("load ingress rule", msg.err))
+		return
+	}
+	m.proxySnippetRule = msg.rule
+	m.statusMessage = fmt.Sprintf("%s snippet for %s (Tab: Switch proxy, w: Write to file)", proxyKindName(m.proxySnippetKind), msg.hostname)
+}
+
+// proxySnippet renders the snippet being shown
+func (m Model) proxySnippet() (string, error) {
+	return models.ReverseProxySnippet(m.proxySnippetKind, *m.proxySnippetRule, "")
+}
+
+func proxyKindName(kind string) string {
+	if kind == models.ProxyNginx {
+		return "nginx"
+	}
+	return "Caddyfile"
+}
+
+// proxySnippetPath is where w saves the snippet, in the working directory
+func (m Model) proxySnippetPath() string {
+	if m.proxySnippetKind == models.ProxyNginx {
+		return fmt.Sprintf("tunnelman-%s.nginx.conf", m.proxySnippetHostname)
+	}
+	return fmt.Sprintf("tunnelman-%s.Caddyfile", m.proxySnippetHostname)
+}
+
+func (m Model) handleProxySnippetInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "X":
+		m.proxySnippetHostname = ""
+		m.proxySnippetRule = nil
+		m.statusMessage = "Closed proxy snippet"
+
+	case "tab":
+		for i, kind := range models.ProxyKinds {
+			if kind == m.proxySnippetKind {
+				m.proxySnippetKind = models.ProxyKinds[(i+1)%len(models.ProxyKinds)]
+				break
+			}
+		}
+		m.statusMessage = fmt.Sprintf("%s snippet for %s", proxyKindName(m.proxySnippetKind), m.proxySnippetHostname)
+
+	case "w":
+		if m.proxySnippetRule == nil {
+			break
+		}
+		snippet, err := m.proxySnippet()
+		if err != nil {
+			m.errorMessage = err.Error()
+			break
+		}
+		path := m.proxySnippetPath()
+		if err := os.WriteFile(path, []byte(snippet), 0644); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to write %s: %v", path, err)
+			break
+		}
+		m.statusMessage = fmt.Sprintf("Wrote %s snippet to %s", proxyKindName(m.proxySnippetKind), path)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderProxySnippet() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	codeStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("🔀 %s snippet for %s", proxyKindName(m.proxySnippetKind), m.proxySnippetHostname))}
+
+	if m.proxySnippetRule == nil {
+		lines = append(lines, hintStyle.Render("Loading the ingress rule..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	snippet, err := m.proxySnippet()
+	if err != nil {
+		lines = append(lines, errorStyle.Render(err.Error()))
+	}
+	for _, line := range strings.Split(strings.TrimRight(snippet, "\n"), "\n") {
+		lines = append(lines, codeStyle.Render(strings.ReplaceAll(line, "\t", "    ")))
+	}
+	lines = append(lines, "",
+		hintStyle.Render(fmt.Sprintf("Forwards to %s - change it to your app's address", models.ProxyUpstream)),
+		hintStyle.Render("Tab: Caddy/nginx • w: Write to "+m.proxySnippetPath()+" • Escape: Close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,98 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startServiceEdit opens an inline editor for the selected hostname's service
+func (m *Model) startServiceEdit() {
+	if m.selectedHostnameIndex >= len(m.tunnelHostnames) {
+		return
+	}
+
+	hostname := m.tunnelHostnames[m.selectedHostnameIndex]
+	if hostname.AuthEnabled {
+		m.statusMessage = "Disable auth (Shift+A) before editing the service - it points at the auth proxy"
+		return
+	}
+	if _, inMaintenance := m.state.MaintenanceOriginal(hostname.Hostname); inMaintenance {
+		m.statusMessage = "End maintenance mode (Shift+M) before editing the service"
+		return
+	}
+
+	m.serviceEdit = &hostname
+	m.serviceEditInput = textinput.New()
+	m.serviceEditInput.SetValue(hostname.Service)
+	m.serviceEditInput.CharLimit = 100
+	m.serviceEditInput.Width = 38
+	m.serviceEditInput.Prompt = ""
+	m.serviceEditInput.Focus()
+	m.serviceEditInput.CursorEnd()
+	m.statusMessage = fmt.Sprintf("Editing service for %s - Enter to save, Escape to cancel", hostname.Hostname)
+}
+
+func (m Model) handleServiceEditInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.serviceEdit = nil
+		m.statusMessage = "Service edit cancelled"
+		return m, nil
+
+	case "enter":
+		hostname := *m.serviceEdit
+		service := strings.TrimSpace(m.serviceEditInput.Value())
+		if service == "" {
+			m.statusMessage = "Service URL cannot be empty"
+			return m, nil
+		}
+		m.serviceEdit = nil
+		if service == hostname.Service {
+			m.statusMessage = "Service unchanged"
+			return m, nil
+		}
+
+		m.state.AddRecentService(service)
+		m.state.Save()
+
+		m.statusMessage = fmt.Sprintf("Updating service for %s: %s", hostname.Hostname, service)
+		task := queueTask(m.selectedTunnelID, "update "+hostname.Hostname, m.updateHostnameService(hostname, service))
+		return m, m.guardHostname(hostname.Hostname, "modify", task)
+	}
+
+	var cmd tea.Cmd
+	m.serviceEditInput, cmd = m.serviceEditInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) updateHostnameService(hostname models.PublicHostname, service string) tea.Cmd {
+	tunnelID := m.selectedTunnelID
+
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		ctx := context.Background()
+		err := m.client.UpdatePublicHostname(ctx, tunnelID, hostname.Hostname, hostname.Hostname, hostname.Path, service)
+		if err != nil {
+			return apiErrorMsg("update public hostname", err)
+		}
+
+		return statusMsg(fmt.Sprintf("Successfully updated public hostname: %s", hostname.Hostname))
+	})
+}
+
+// editingServiceFor reports whether the inline editor is open on hostname
+func (m Model) editingServiceFor(hostname models.PublicHostname) bool {
+	return m.serviceEdit != nil && hostnameKey(*m.serviceEdit) == hostnameKey(hostname)
+}
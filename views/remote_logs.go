@@ -0,0 +1,212 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// remoteLogLimit is how many streamed lines the log viewer keeps
+const remoteLogLimit = 500
+
+// remoteLogSession is one running `cloudflared tail`. Messages carry their
+// session so lines from a stream that was closed or restarted are dropped.
+type remoteLogSession struct {
+	tunnelID   string
+	tunnelName string
+	level      string
+	lines      chan models.RemoteLogLine
+	cancel     context.CancelFunc
+}
+
+type remoteLogLineMsg struct {
+	session *remoteLogSession
+	line    models.RemoteLogLine
+}
+
+type remoteLogsEndedMsg struct {
+	session *remoteLogSession
+	err     error
+}
+
+// openRemoteLogs streams the selected tunnel's connector logs from the edge,
+// which covers connectors running on other machines too
+func (m *Model) openRemoteLogs() tea.Cmd {
+	if m.selectedTunnel >= len(m.tunnelsList) {
+		return nil
+	}
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	m.remoteLogLines = nil
+	m.remoteLogErr = ""
+	return m.startRemoteLogs(tunnel.ID, tunnel.Name, models.DefaultRemoteLogLevel)
+}
+
+func (m *Model) startRemoteLogs(tunnelID, tunnelName, level string) tea.Cmd {
+	m.stopRemoteLogs()
+	if m.client == nil {
+		m.errorMessage = "Cloudflare client not initialized - check your API credentials"
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &remoteLogSession{
+		tunnelID:   tunnelID,
+		tunnelName: tunnelName,
+		level:      level,
+		lines:      make(chan models.RemoteLogLine, 64),
+		cancel:     cancel,
+	}
+	m.remoteLogs = session
+	m.statusMessage = fmt.Sprintf("Tailing %s's connector logs at %s level...", tunnelName, level)
+
+	client := m.client
+	tail := func() tea.Msg {
+		err := client.TailTunnelLogs(ctx, tunnelID, level, session.lines)
+		return remoteLogsEndedMsg{session: session, err: err}
+	}
+	return tea.Batch(tail, waitForRemoteLog(session))
+}
+
+// waitForRemoteLog delivers the session's next line
+func waitForRemoteLog(session *remoteLogSession) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-session.lines
+		if !ok {
+			return nil
+		}
+		return remoteLogLineMsg{session: session, line: line}
+	}
+}
+
+// stopRemoteLogs ends the running tail, if any
+func (m *Model) stopRemoteLogs() {
+	if m.remoteLogs != nil {
+		m.remoteLogs.cancel()
+		m.remoteLogs = nil
+	}
+}
+
+func (m *Model) handleRemoteLogLine(msg remoteLogLineMsg) tea.Cmd {
+	if msg.session != m.remoteLogs {
+		return nil
+	}
+	m.remoteLogLines = append(m.remoteLogLines, msg.line)
+	if len(m.remoteLogLines) > remoteLogLimit {
+		m.remoteLogLines = m.remoteLogLines[len(m.remoteLogLines)-remoteLogLimit:]
+	}
+	return waitForRemoteLog(msg.session)
+}
+
+func (m *Model) handleRemoteLogsEnded(msg remoteLogsEndedMsg) {
+	if msg.session != m.remoteLogs {
+		return
+	}
+	if msg.err != nil {
+		m.remoteLogErr = string(apiErrorMsg("tail connector logs", msg.err))
+	} else {
+		m.remoteLogErr = fmt.Sprintf("The log stream ended - press %s to reconnect", m.keys.Bound("r"))
+	}
+}
+
+// nextRemoteLogLevel cycles through the levels cloudflared tail filters on
+func nextRemoteLogLevel(level string) string {
+	for i, candidate := range models.RemoteLogLevels {
+		if candidate == level {
+			return models.RemoteLogLevels[(i+1)%len(models.RemoteLogLevels)]
+		}
+	}
+	return models.DefaultRemoteLogLevel
+}
+
+func (m Model) handleRemoteLogsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	session := m.remoteLogs
+
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "l":
+		m.stopRemoteLogs()
+		m.remoteLogLines = nil
+		m.remoteLogErr = ""
+		m.statusMessage = "Stopped tailing connector logs"
+
+	case "c":
+		m.remoteLogLines = nil
+
+	case "v":
+		m.remoteLogErr = ""
+		return m, m.startRemoteLogs(session.tunnelID, session.tunnelName, nextRemoteLogLevel(session.level))
+
+	case "r":
+		m.remoteLogErr = ""
+		return m, m.startRemoteLogs(session.tunnelID, session.tunnelName, session.level)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderRemoteLogs() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	levelStyles := map[string]lipgloss.Style{
+		"debug": hintStyle,
+		"warn":  lipgloss.NewStyle().Foreground(colors.warning),
+		"error": lipgloss.NewStyle().Foreground(colors.errorColor),
+	}
+
+	session := m.remoteLogs
+	lines := []string{titleStyle.Render(fmt.Sprintf("📜 Remote logs of %s (%s and above)", session.tunnelName, session.level))}
+
+	if m.remoteLogErr != "" {
+		lines = append(lines, levelStyles["error"].Render(m.remoteLogErr))
+	}
+	if len(m.remoteLogLines) == 0 && m.remoteLogErr == "" {
+		lines = append(lines, hintStyle.Render("Waiting for log lines from the tunnel's connectors..."))
+	}
+
+	// Show the newest lines that fit, truncated to the content width
+	visible := m.height - 18
+	if visible < 5 {
+		visible = 5
+	}
+	start := len(m.remoteLogLines) - visible
+	if start < 0 {
+		start = 0
+	}
+	width := m.width - 12
+	for _, line := range m.remoteLogLines[start:] {
+		text := strings.ReplaceAll(line.String(), "\n", " ")
+		if width > 3 && len(text) > width {
+			text = text[:width-3] + "..."
+		}
+		style, ok := levelStyles[line.Level]
+		if !ok {
+			style = rowStyle
+		}
+		lines = append(lines, style.Render(text))
+	}
+
+	lines = append(lines, "", hintStyle.Render(fmt.Sprintf("v: Change level • c: Clear • %s: Reconnect • Escape: Close", m.keys.Bound("r"))))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
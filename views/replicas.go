@@ -0,0 +1,60 @@
+package views
+
+import (
+	"context"
+	"fmt"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type replicaCheckMsg struct {
+	tunnelName string
+	connectors []models.TunnelConnector
+	err        error
+	start      func(m *Model) tea.Cmd
+}
+
+// checkReplicas looks up the tunnel's connectors before start runs another
+// one locally, so a second egress point isn't added by accident
+func (m Model) checkReplicas(tunnel models.CLITunnel, start func(m *Model) tea.Cmd) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		if client == nil {
+			return replicaCheckMsg{tunnelName: tunnel.Name, start: start}
+		}
+		connectors, err := client.GetTunnelConnectors(context.Background(), tunnel.ID)
+		return replicaCheckMsg{tunnelName: tunnel.Name, connectors: connectors, err: err, start: start}
+	}
+}
+
+// handleReplicaCheck starts the connector right away when no other one is
+// healthy, and otherwise asks whether to add it as a replica. This isn't a
+// delete, so skip_delete_confirm doesn't apply.
+func (m *Model) handleReplicaCheck(msg replicaCheckMsg) tea.Cmd {
+	if msg.err != nil {
+		m.warningMessage = fmt.Sprintf("Couldn't check %s's other connectors: %v", msg.tunnelName, msg.err)
+		return msg.start(m)
+	}
+	healthy := models.HealthyConnectors(msg.connectors)
+	if len(healthy) == 0 {
+		return msg.start(m)
+	}
+
+	details := []string{fmt.Sprintf("%d connector(s) already serve it:", len(healthy))}
+	for _, connector := range healthy {
+		details = append(details, "  "+connector.Summary())
+	}
+	details = append(details, "A local connector joins them as a replica: the edge spreads requests across all of them, so each must reach the same origins.")
+
+	dialog := confirmDialog{
+		title:     fmt.Sprintf("Start another connector for %s?", msg.tunnelName),
+		details:   details,
+		cancelled: fmt.Sprintf("Not started: %s keeps its %d remote connector(s)", msg.tunnelName, len(healthy)),
+		action:    msg.start,
+	}
+	m.confirmDialog = &dialog
+	m.statusMessage = dialog.title + " (y/n)"
+	return nil
+}
@@ -0,0 +1,130 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type requestSamplesLoadedMsg struct {
+	hostname string
+	samples  []models.RequestSample
+	err      error
+}
+
+// openRequestSamples shows recent traffic for the selected hostname, so a
+// change can be checked against what actually reaches the service
+func (m *Model) openRequestSamples() tea.Cmd {
+	if m.selectedHostnameIndex >= len(m.tunnelHostnames) {
+		return nil
+	}
+
+	hostname := m.tunnelHostnames[m.selectedHostnameIndex].Hostname
+	m.requestSamplesHostname = hostname
+	m.requestSamples = nil
+	m.requestSamplesErr = nil
+	m.requestSamplesLoading = true
+	return m.loadRequestSamples(hostname)
+}
+
+func (m Model) loadRequestSamples(hostname string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		samples, err := m.client.GetRequestSamples(context.Background(), hostname, models.DefaultRequestSampleWindow)
+		return requestSamplesLoadedMsg{hostname: hostname, samples: samples, err: err}
+	})
+}
+
+func (m Model) handleRequestSamplesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "R":
+		m.requestSamplesHostname = ""
+		m.statusMessage = "Closed request samples"
+
+	case "r":
+		m.requestSamplesLoading = true
+		return m, m.loadRequestSamples(m.requestSamplesHostname)
+	}
+
+	return m, nil
+}
+
+func statusColor(status int) lipgloss.TerminalColor {
+	switch {
+	case status >= 500:
+		return colors.errorColor
+	case status >= 400:
+		return colors.warning
+	case status >= 300:
+		return colors.muted
+	default:
+		return colors.success
+	}
+}
+
+func (m Model) renderRequestSamples() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.heading)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("📊 Requests to %s (last %s)", m.requestSamplesHostname, models.DefaultRequestSampleWindow))}
+
+	switch {
+	case m.requestSamplesLoading:
+		lines = append(lines, hintStyle.Render("Loading request samples..."))
+	case m.requestSamplesErr != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(colors.errorColor).Render("✗ "+m.requestSamplesErr.Error()))
+	case len(m.requestSamples) == 0:
+		lines = append(lines, hintStyle.Render("No requests seen in this window"))
+	default:
+		summary := models.SummarizeStatuses(m.requestSamples)
+		classes := make([]string, 0, len(summary))
+		for class := range summary {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		var parts []string
+		for _, class := range classes {
+			parts = append(parts, fmt.Sprintf("%s: %d", class, summary[class]))
+		}
+		lines = append(lines, strings.Join(parts, "   "), "")
+
+		format := "%-6s %-7s %-7s %-6s %s"
+		lines = append(lines, headerStyle.Render(fmt.Sprintf(format, "TIME", "METHOD", "STATUS", "COUNT", "PATH")))
+		for _, sample := range m.requestSamples {
+			status := lipgloss.NewStyle().Foreground(statusColor(sample.Status)).Render(fmt.Sprintf("%-7d", sample.Status))
+			lines = append(lines, rowStyle.Render(fmt.Sprintf("%-6s %-7s ", sample.Time.Local().Format("15:04"), sample.Method))+
+				status+rowStyle.Render(fmt.Sprintf(" %-6d %s", sample.Count, sample.Path)))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render(m.keys.Bound("r")+": Refresh • Escape: Close"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,119 @@
+package views
+
+import (
+	"fmt"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openRunArgs edits the extra cloudflared flags of the selected tunnel
+func (m *Model) openRunArgs() {
+	if m.selectedTunnel >= len(m.tunnelsList) || m.tunnelManager == nil {
+		return
+	}
+	tunnel := m.tunnelsList[m.selectedTunnel]
+
+	m.runArgsTunnel = tunnel.Name
+	m.runArgsInput = textinput.New()
+	m.runArgsInput.Placeholder = "--edge-ip-version 4 --region us"
+	m.runArgsInput.SetValue(models.FormatRunArgs(m.tunnelManager.RunArgs(tunnel.Name)))
+	m.runArgsInput.CharLimit = 300
+	m.runArgsInput.Width = 60
+	m.runArgsInput.Focus()
+	m.runArgsInput.CursorEnd()
+	m.statusMessage = fmt.Sprintf("Editing cloudflared run arguments for %s", tunnel.Name)
+}
+
+// saveRunArgs stores the edited arguments in config.json; running
+// connectors pick them up on their next start
+func (m *Model) saveRunArgs() {
+	args, err := models.ParseRunArgs(m.runArgsInput.Value())
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Invalid arguments: %v", err)
+		return
+	}
+
+	tunnelName := m.runArgsTunnel
+	m.runArgsTunnel = ""
+	m.tunnelManager.SetRunArgs(tunnelName, args)
+
+	if m.config.TunnelRunArgs == nil {
+		m.config.TunnelRunArgs = make(map[string][]string)
+	}
+	if len(args) == 0 {
+		delete(m.config.TunnelRunArgs, tunnelName)
+	} else {
+		m.config.TunnelRunArgs[tunnelName] = args
+	}
+	if err := m.config.Save(); err != nil {
+		m.errorMessage = fmt.Sprintf("Run arguments apply for this session, but failed to save config: %v", err)
+		return
+	}
+
+	action := "Saved"
+	if len(args) == 0 {
+		action = "Cleared"
+	}
+	if _, running := m.tunnelManager.GetRunningTunnels()[tunnelName]; running {
+		m.statusMessage = fmt.Sprintf("%s run arguments for %s - they apply once its connector is stopped and started again", action, tunnelName)
+	} else {
+		m.statusMessage = fmt.Sprintf("%s run arguments for %s", action, tunnelName)
+	}
+}
+
+func (m Model) handleRunArgsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.runArgsTunnel = ""
+		m.statusMessage = "Run arguments unchanged"
+		return m, nil
+
+	case "enter":
+		m.saveRunArgs()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.runArgsInput, cmd = m.runArgsInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderRunArgs() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	command := "cloudflared tunnel <flags> run " + m.runArgsTunnel
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("⚙️  Run arguments for %s", m.runArgsTunnel)),
+		rowStyle.Render("Extra flags passed to " + command),
+		"",
+		m.runArgsInput.View(),
+		"",
+		hintStyle.Render("--config, --url and --loglevel are managed by tunnelman. Changes apply on the next start."),
+		"",
+		hintStyle.Render("Enter: Save (empty clears) • Escape: Cancel"),
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,156 @@
+package views
+
+import (
+	"context"
+	"fmt"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type allHostnamesLoadedMsg []models.TunnelHostname
+
+func (m Model) loadAllHostnames() tea.Cmd {
+	tunnels := m.tunnelsList
+
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		ctx := context.Background()
+		hostnames, _ := m.client.ListAllPublicHostnames(ctx, tunnels)
+		return allHostnamesLoadedMsg(hostnames)
+	})
+}
+
+func (m *Model) openSearch() tea.Cmd {
+	m.showSearch = true
+	m.searchInput = textinput.New()
+	m.searchInput.Placeholder = "hostname, service or tunnel"
+	m.searchInput.CharLimit = 100
+	m.searchInput.Width = 50
+	m.searchInput.SetValue(m.state.UI.FilterText)
+	m.searchInput.Focus()
+	m.selectedSearchResult = 0
+	m.statusMessage = "Searching hostnames across all tunnels..."
+	return m.loadAllHostnames()
+}
+
+func (m Model) searchResults() []models.TunnelHostname {
+	return models.FilterHostnames(m.allHostnames, m.searchInput.Value())
+}
+
+func (m Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.showSearch = false
+		m.state.SetFilter(m.searchInput.Value())
+		m.statusMessage = "Search closed"
+		return m, nil
+
+	case "up":
+		if m.selectedSearchResult > 0 {
+			m.selectedSearchResult--
+		}
+		return m, nil
+
+	case "down":
+		if m.selectedSearchResult < len(m.searchResults())-1 {
+			m.selectedSearchResult++
+		}
+		return m, nil
+
+	case "enter":
+		results := m.searchResults()
+		if m.selectedSearchResult >= len(results) {
+			return m, nil
+		}
+		m.showSearch = false
+		m.state.SetFilter(m.searchInput.Value())
+		return m, m.jumpToHostname(results[m.selectedSearchResult])
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	m.selectedSearchResult = 0
+	return m, cmd
+}
+
+// jumpToHostname opens the hostname view of the tunnel serving target and
+// selects target once the hostnames have loaded
+func (m *Model) jumpToHostname(target models.TunnelHostname) tea.Cmd {
+	for i, tunnel := range m.tunnelsList {
+		if tunnel.ID == target.TunnelID {
+			m.selectedTunnel = i
+			break
+		}
+	}
+
+	m.selectedTunnelName = target.TunnelName
+	m.selectedTunnelID = target.TunnelID
+	m.showTunnelHostnames = true
+	m.pendingHostnameSelect = target.Hostname
+	m.loading = true
+	m.statusMessage = fmt.Sprintf("Opening %s in tunnel: %s", target.Hostname, target.TunnelName)
+
+	return tea.Batch(m.loadTunnelHostnames(target.TunnelID), m.loadTunnelDetails(target.TunnelID))
+}
+
+func (m Model) renderSearch() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.accent).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	selectedStyle := lipgloss.NewStyle().
+		Background(colors.accent).
+		Foreground(colors.onColor).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	rows := []string{
+		titleStyle.Render("🔍 Search Hostnames Across All Tunnels"),
+		m.searchInput.View(),
+		"",
+	}
+
+	if m.allHostnames == nil {
+		rows = append(rows, hintStyle.Render("Loading hostnames from all tunnels..."))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	results := m.searchResults()
+	if len(results) == 0 {
+		rows = append(rows, hintStyle.Render("No matching hostnames"))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	for i, result := range results {
+		row := fmt.Sprintf("%-40s %-20s %s", result.Hostname, result.TunnelName, result.Service)
+		if i == m.selectedSearchResult {
+			rows = append(rows, selectedStyle.Render(row))
+		} else {
+			rows = append(rows, rowStyle.Render(row))
+		}
+	}
+
+	rows = append(rows, "", hintStyle.Render("↑↓: Select • Enter: Jump to hostname • Escape: Close"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,91 @@
+package views
+
+import "tunnelman/models"
+
+// cursorTunnelID returns the ID of the tunnel under the cursor, if any
+func (m Model) cursorTunnelID() string {
+	if m.selectedTunnel < len(m.tunnelsList) {
+		return m.tunnelsList[m.selectedTunnel].ID
+	}
+	return ""
+}
+
+// reselectTunnel moves the cursor back onto the tunnel with the given ID after
+// the list has been replaced. If it is gone the cursor keeps its position,
+// clamped to the new list, so it lands on a neighbour. The list scrolls so
+// the cursor stays on the same screen row.
+func (m *Model) reselectTunnel(id string) {
+	row := m.selectedTunnel - m.tunnelScroll
+	m.selectedTunnel = clampIndex(m.selectedTunnel, len(m.tunnelsList))
+	for i, tunnel := range m.tunnelsList {
+		if tunnel.ID == id {
+			m.selectedTunnel = i
+			break
+		}
+	}
+	m.tunnelScroll = m.selectedTunnel - row
+	m.scrollToTunnelCursor()
+}
+
+// visibleTunnelRows is how many tunnel rows fit in the content area, leaving
+// room for the header and the details of the selected tunnel
+func (m Model) visibleTunnelRows() int {
+	rows := m.height - 24
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+// scrollToTunnelCursor scrolls the tunnel list as little as possible to
+// keep the cursor in view, without scrolling past the end of the list
+func (m *Model) scrollToTunnelCursor() {
+	visible := m.visibleTunnelRows()
+	if m.selectedTunnel < m.tunnelScroll {
+		m.tunnelScroll = m.selectedTunnel
+	}
+	if m.selectedTunnel >= m.tunnelScroll+visible {
+		m.tunnelScroll = m.selectedTunnel - visible + 1
+	}
+	if maxScroll := len(m.tunnelsList) - visible; m.tunnelScroll > maxScroll {
+		m.tunnelScroll = maxScroll
+	}
+	if m.tunnelScroll < 0 {
+		m.tunnelScroll = 0
+	}
+}
+
+// hostnameKey identifies a hostname across reloads. The ingress ID alone is
+// only unique within a tunnel, so the hostname is included as well.
+func hostnameKey(hostname models.PublicHostname) string {
+	return hostname.ID + "|" + hostname.Hostname
+}
+
+// cursorHostnameKey returns the key of the hostname under the cursor, if any
+func (m Model) cursorHostnameKey() string {
+	if m.selectedHostnameIndex < len(m.tunnelHostnames) {
+		return hostnameKey(m.tunnelHostnames[m.selectedHostnameIndex])
+	}
+	return ""
+}
+
+// reselectHostname restores the hostname cursor by key, clamping like reselectTunnel
+func (m *Model) reselectHostname(key string) {
+	for i, hostname := range m.tunnelHostnames {
+		if hostnameKey(hostname) == key {
+			m.selectedHostnameIndex = i
+			return
+		}
+	}
+	m.selectedHostnameIndex = clampIndex(m.selectedHostnameIndex, len(m.tunnelHostnames))
+}
+
+func clampIndex(index, length int) int {
+	if index >= length {
+		index = length - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	return index
+}
@@ -0,0 +1,137 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type selfTestDoneMsg struct {
+	tunnel string
+	result *models.SelfTestResult
+	err    error
+}
+
+// openSelfTest runs the end to end self test against the selected tunnel.
+// The throwaway hostname goes in the last selected domain, or the account's
+// first zone when none was selected yet.
+func (m *Model) openSelfTest() tea.Cmd {
+	if m.selectedTunnel >= len(m.tunnelsList) {
+		return nil
+	}
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	m.selfTestTunnel = tunnel.Name
+	m.selfTest = nil
+	m.statusMessage = fmt.Sprintf("Self-testing %s through the edge...", tunnel.Name)
+
+	client := m.client
+	tunnelManager := m.tunnelManager
+	zone := m.state.GetSelectedDomain()
+	return m.cancellable("self test", func(ctx context.Context) tea.Msg {
+		if client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+		if tunnelManager == nil {
+			return errorMsg("Tunnel manager not initialized")
+		}
+		if zone == "" {
+			domains, err := client.GetAvailableDomains(ctx)
+			if err != nil {
+				return selfTestDoneMsg{tunnel: tunnel.Name, err: err}
+			}
+			if len(domains) == 0 {
+				return selfTestDoneMsg{tunnel: tunnel.Name, err: fmt.Errorf("the account has no zones to put a test hostname in")}
+			}
+			zone = domains[0]
+		}
+		result, err := client.SelfTest(ctx, tunnelManager, tunnel.ID, zone, models.DefaultSelfTestTimeout)
+		return selfTestDoneMsg{tunnel: tunnel.Name, result: result, err: err}
+	})
+}
+
+func (m *Model) handleSelfTestDone(msg selfTestDoneMsg) {
+	if msg.tunnel != m.selfTestTunnel {
+		return
+	}
+	if msg.err != nil {
+		m.selfTestTunnel = ""
+		m.errorMessage = string(apiErrorMsg("run self test", msg.err))
+		return
+	}
+	m.selfTest = msg.result
+	if msg.result.OK() {
+		m.statusMessage = fmt.Sprintf("✅ Self test passed: token, tunnel %s and DNS all work", msg.tunnel)
+	} else {
+		m.statusMessage = fmt.Sprintf("Self test of %s failed", msg.tunnel)
+	}
+}
+
+func (m Model) handleSelfTestInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "t":
+		m.selfTestTunnel = ""
+		m.selfTest = nil
+		m.statusMessage = "Closed self test"
+
+	case "r":
+		if m.selfTest != nil {
+			return m, m.openSelfTest()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) renderSelfTest() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	okStyle := lipgloss.NewStyle().
+		Foreground(colors.success)
+
+	badStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("🧪 Self test of %s", m.selfTestTunnel))}
+
+	if m.selfTest == nil {
+		lines = append(lines,
+			hintStyle.Render("Serving a token locally, routing a throwaway hostname to it and requesting it through the edge..."),
+			hintStyle.Render("This can take a minute while DNS propagates. Escape cancels and cleans up."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	for _, step := range m.selfTest.Steps {
+		switch {
+		case step.Skipped:
+			lines = append(lines, hintStyle.Render("– "+step.Name+" (skipped)"))
+		case step.Err != nil:
+			lines = append(lines, badStyle.Render("✗ "+step.Name))
+			lines = append(lines, hintStyle.Render("    "+step.Err.Error()))
+		default:
+			lines = append(lines, okStyle.Render(fmt.Sprintf("✓ %s (%s)", step.Name, step.Elapsed.Round(100*time.Millisecond))))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render(m.keys.Bound("r")+": Run again • Escape: Close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
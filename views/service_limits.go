@@ -0,0 +1,59 @@
+package views
+
+import (
+	"fmt"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// checkServiceLimits decides whether the hostname form may be submitted.
+// Blocking limitations keep the form open; warnings do until the same
+// hostname and service are submitted a second time.
+func (m *Model) checkServiceLimits(hostnameInput, service string) bool {
+	m.serviceLimits = models.CheckServiceLimitations(hostnameInput, service)
+	if len(m.serviceLimits) == 0 {
+		return true
+	}
+
+	for _, limit := range m.serviceLimits {
+		if limit.Blocking {
+			m.serviceLimitsAck = ""
+			m.statusMessage = "This hostname can't work behind Cloudflare's proxy - see below"
+			return false
+		}
+	}
+
+	key := hostnameInput + " " + service
+	if m.serviceLimitsAck == key {
+		return true
+	}
+	m.serviceLimitsAck = key
+	m.statusMessage = fmt.Sprintf("%s may not work as expected - press Enter again to save it anyway", service)
+	return false
+}
+
+func (m Model) renderServiceLimits() string {
+	if len(m.serviceLimits) == 0 {
+		return ""
+	}
+
+	blockingStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(colors.warning)
+
+	var lines []string
+	for _, limit := range m.serviceLimits {
+		if limit.Blocking {
+			lines = append(lines, blockingStyle.Render("✗ "+limit.Message))
+		} else {
+			lines = append(lines, warningStyle.Render("⚠ "+limit.Message))
+		}
+	}
+	return lipgloss.NewStyle().
+		Width(m.width - 12).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
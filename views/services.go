@@ -0,0 +1,28 @@
+package views
+
+import (
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type serviceTunnelsDetectedMsg []models.ServiceConnector
+
+// detectServiceTunnels looks for cloudflared running as a system service so
+// those tunnels are shown as service-managed rather than offered a new connector
+func (m Model) detectServiceTunnels() tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if m.tunnelManager == nil {
+			return serviceTunnelsDetectedMsg(nil)
+		}
+		return serviceTunnelsDetectedMsg(m.tunnelManager.DetectServiceTunnels())
+	})
+}
+
+// serviceConnector returns the system service running the tunnel, if any
+func (m Model) serviceConnector(tunnel models.CLITunnel) (models.ServiceConnector, bool) {
+	if m.tunnelManager == nil {
+		return models.ServiceConnector{}, false
+	}
+	return m.tunnelManager.ServiceConnectorFor(tunnel.Name, tunnel.ID)
+}
@@ -0,0 +1,176 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quit saves the UI state so the next launch reopens where this one left off
+// and stops any access clients started from the UI
+func (m Model) quit() tea.Cmd {
+	m.saveUIState()
+	m.stopRemoteLogs()
+	if m.tunnelManager != nil {
+		m.tunnelManager.StopAccessProcesses()
+		m.tunnelManager.StopStaticServers()
+	}
+	return tea.Quit
+}
+
+func (m Model) saveUIState() {
+	ui := &m.state.UI
+	ui.ShowHelp = m.showHelp
+	ui.SelectedTunnelID = ""
+	if m.selectedTunnel < len(m.tunnelsList) {
+		ui.SelectedTunnelID = m.tunnelsList[m.selectedTunnel].ID
+	}
+	ui.OpenTunnelID = ""
+	if m.showTunnelHostnames {
+		ui.OpenTunnelID = m.selectedTunnelID
+	}
+
+	m.state.Save()
+	m.saveTunnelCache()
+}
+
+// warmStart shows the tunnels and statuses cached by the last session, marked
+// stale, so the list is usable before the first refresh comes back
+func (m *Model) warmStart() {
+	if m.tunnelCache == nil || len(m.tunnelCache.Tunnels) == 0 {
+		return
+	}
+
+	m.tunnelsList = append(m.tunnelsList[:0], m.tunnelCache.Tunnels...)
+	for id, status := range m.tunnelCache.Statuses {
+		m.tunnelStatuses[id] = status
+	}
+	for id, count := range m.tunnelCache.DomainCounts {
+		m.tunnelDomainCounts[id] = count
+	}
+	m.sortTunnels()
+	m.reselectTunnel(m.state.UI.SelectedTunnelID)
+	m.tunnelsStale = true
+	m.statusMessage = fmt.Sprintf("Showing %d cached tunnels - refreshing...", len(m.tunnelsList))
+}
+
+// saveTunnelCache stores the current tunnel list for the next warm start.
+// Nothing is saved while the list still is the stale cache.
+func (m Model) saveTunnelCache() {
+	if m.tunnelCache == nil || m.tunnelsStale {
+		return
+	}
+	m.tunnelCache.Update(m.tunnelsList, m.tunnelStatuses, m.tunnelDomainCounts, time.Now())
+	m.tunnelCache.Save()
+}
+
+// restoreUIState reapplies the saved cursor and open tunnel once the first
+// tunnel list has loaded
+func (m *Model) restoreUIState() tea.Cmd {
+	m.restoringUI = false
+	ui := m.state.UI
+
+	for i, tunnel := range m.tunnelsList {
+		if tunnel.ID == ui.SelectedTunnelID {
+			m.selectedTunnel = i
+		}
+		if tunnel.ID == ui.OpenTunnelID {
+			m.selectedTunnel = i
+			return m.openTunnelHostnames()
+		}
+	}
+
+	return nil
+}
+
+// sortTunnels orders the list by the configured column, keeping the CLI order
+// when there is none, and moves pinned tunnels to the top
+func (m *Model) sortTunnels() {
+	if m.config != nil {
+		models.SortTunnels(m.tunnelsList, m.config.TunnelSortColumn, m.config.TunnelSortDescending, m.tunnelStatuses, m.tunnelDomainCounts)
+	}
+	sort.SliceStable(m.tunnelsList, func(i, j int) bool {
+		return m.state.IsPinnedTunnel(m.tunnelsList[i].ID) && !m.state.IsPinnedTunnel(m.tunnelsList[j].ID)
+	})
+}
+
+func (m *Model) togglePinnedTunnel() {
+	if m.selectedTunnel >= len(m.tunnelsList) {
+		return
+	}
+
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	if m.state.TogglePinnedTunnel(tunnel.ID) {
+		m.statusMessage = "Pinned tunnel: " + tunnel.Name
+	} else {
+		m.statusMessage = "Unpinned tunnel: " + tunnel.Name
+	}
+	m.state.Save()
+
+	m.sortTunnels()
+	m.reselectTunnel(tunnel.ID)
+}
+
+// resortTunnels re-sorts the list after the column's data changed, keeping
+// the cursor on the same tunnel
+func (m *Model) resortTunnels(column string) {
+	if m.config == nil || m.config.TunnelSortColumn != column {
+		return
+	}
+	selectedID := m.cursorTunnelID()
+	m.sortTunnels()
+	m.reselectTunnel(selectedID)
+}
+
+// sortHeader marks the column header the list is sorted by. The creation
+// date has no column of its own, so it is shown on the ID column.
+func (m Model) sortHeader(label, column string) string {
+	if m.config == nil || m.config.TunnelSortColumn != column {
+		return label
+	}
+	arrow := "▲"
+	if m.config.TunnelSortDescending {
+		arrow = "▼"
+	}
+	if column == models.TunnelSortCreated {
+		return fmt.Sprintf("%s (created%s)", label, arrow)
+	}
+	return label + arrow
+}
+
+// changeTunnelSort switches the sort column, or flips the direction when
+// reverse is set, and saves the choice to the config file
+func (m *Model) changeTunnelSort(reverse bool) tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+	if reverse {
+		m.config.TunnelSortDescending = !m.config.TunnelSortDescending
+	} else {
+		m.config.TunnelSortColumn = models.NextTunnelSortColumn(m.config.TunnelSortColumn)
+	}
+
+	if err := m.config.Save(); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save sort order: %v", err)
+	}
+
+	if m.config.TunnelSortColumn == models.TunnelSortNone {
+		// The listed order is gone once sorted, so reload it
+		m.statusMessage = "Tunnels in listed order"
+		return m.loadTunnels()
+	}
+
+	direction := "ascending"
+	if m.config.TunnelSortDescending {
+		direction = "descending"
+	}
+	m.statusMessage = fmt.Sprintf("Sorted tunnels by %s, %s", m.config.TunnelSortColumn, direction)
+	selectedID := m.cursorTunnelID()
+	m.sortTunnels()
+	m.reselectTunnel(selectedID)
+	return nil
+}
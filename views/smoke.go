@@ -0,0 +1,33 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// smokeTestHostname polls a new hostname through the edge until it answers,
+// or returns nil when smoke_test_seconds isn't set
+func (m Model) smokeTestHostname(hostname string) tea.Cmd {
+	timeout := m.config.SmokeTestTimeout()
+	if timeout == 0 || hostname == "" {
+		return nil
+	}
+
+	return tea.Cmd(func() tea.Msg {
+		return hostnameSmokeTestedMsg(models.SmokeTestHostname(context.Background(), hostname, timeout))
+	})
+}
+
+func (m *Model) handleHostnameSmokeTested(result models.SmokeTestResult) {
+	elapsed := result.Elapsed.Round(time.Second)
+	if result.Live {
+		m.statusMessage = fmt.Sprintf("✅ https://%s is live (%s after %s)", result.Hostname, result.Describe(), elapsed)
+		return
+	}
+	m.errorMessage = fmt.Sprintf("https://%s not live after %s: %s", result.Hostname, elapsed, result.Describe())
+}
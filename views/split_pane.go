@@ -0,0 +1,150 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// splitPaneMinWidth is the terminal width from which the tunnel list
+	// shows the cursor tunnel's hostnames in a pane beside it
+	splitPaneMinWidth = 170
+	// hostnamePreviewTTL is how long a tunnel's previewed hostnames are
+	// reused before they're fetched again
+	hostnamePreviewTTL = 30 * time.Second
+)
+
+// hostnamePreview is the cached hostname list of one tunnel for the split
+// pane. An entry with a zero loadedAt is still loading.
+type hostnamePreview struct {
+	hostnames []models.PublicHostname
+	err       string
+	loadedAt  time.Time
+}
+
+type hostnamePreviewLoadedMsg struct {
+	tunnelID  string
+	hostnames []models.PublicHostname
+	err       error
+}
+
+// splitPaneActive reports whether the tunnel list is wide enough to show
+// the hostname pane
+func (m Model) splitPaneActive() bool {
+	return m.width >= splitPaneMinWidth && m.activeTab == 0 && !m.showTunnelHostnames && len(m.tunnelsList) > 0
+}
+
+// loadHostnamePreview fetches the cursor tunnel's hostnames for the split
+// pane unless a fresh copy is cached or already on its way
+func (m *Model) loadHostnamePreview() tea.Cmd {
+	if !m.splitPaneActive() || m.client == nil {
+		return nil
+	}
+	tunnelID := m.cursorTunnelID()
+	if preview, cached := m.hostnamePreviews[tunnelID]; cached {
+		if preview.loadedAt.IsZero() || time.Since(preview.loadedAt) < hostnamePreviewTTL {
+			return nil
+		}
+	}
+	m.hostnamePreviews[tunnelID] = hostnamePreview{hostnames: m.hostnamePreviews[tunnelID].hostnames}
+
+	client := m.client
+	return func() tea.Msg {
+		hostnames, err := client.GetPublicHostnames(context.Background(), tunnelID)
+		return hostnamePreviewLoadedMsg{tunnelID: tunnelID, hostnames: hostnames, err: err}
+	}
+}
+
+func (m *Model) handleHostnamePreviewLoaded(msg hostnamePreviewLoadedMsg) {
+	preview := hostnamePreview{hostnames: msg.hostnames, loadedAt: time.Now()}
+	if msg.err != nil {
+		preview.hostnames = m.hostnamePreviews[msg.tunnelID].hostnames
+		preview.err = string(apiErrorMsg("load public hostnames", msg.err))
+	}
+	m.hostnamePreviews[msg.tunnelID] = preview
+}
+
+// renderSplitPane puts the cursor tunnel's hostnames to the right of the
+// tunnel list
+func (m Model) renderSplitPane(list string) string {
+	// The content box takes 12 columns for its border and padding
+	width := m.width - 12 - lipgloss.Width(list) - 3
+	if width < 30 {
+		return list
+	}
+
+	paneStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(colors.panel).
+		PaddingLeft(2).
+		MarginLeft(1).
+		Width(width)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	serviceStyle := lipgloss.NewStyle().
+		Foreground(colors.muted)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(colors.errorColor)
+
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	lines := []string{titleStyle.Render("Hostnames of " + tunnel.Name)}
+
+	preview, cached := m.hostnamePreviews[tunnel.ID]
+	switch {
+	case preview.err != "":
+		lines = append(lines, errorStyle.Render(preview.err))
+	case !cached || (preview.loadedAt.IsZero() && len(preview.hostnames) == 0):
+		lines = append(lines, hintStyle.Render("Loading hostnames..."))
+	case len(preview.hostnames) == 0:
+		lines = append(lines, hintStyle.Render("No public hostnames"))
+	}
+
+	// Leave room for the title, the hint and the content box's padding
+	visible := m.height - 20
+	if visible < 3 {
+		visible = 3
+	}
+	for i, hostname := range preview.hostnames {
+		if i == visible {
+			lines = append(lines, hintStyle.Render(fmt.Sprintf("... and %d more", len(preview.hostnames)-visible)))
+			break
+		}
+		name := hostname.Hostname + hostname.Path
+		if hostname.AuthEnabled {
+			name = "🔒 " + name
+		}
+		service := hostname.Service
+		if original, inMaintenance := m.state.MaintenanceOriginal(hostname.Hostname); inMaintenance {
+			service = "🚧 maintenance (was " + original + ")"
+		}
+		row := rowStyle.Render(name) + serviceStyle.Render(" → "+service)
+		if lipgloss.Width(row) > width-2 {
+			row = rowStyle.Render(name) + serviceStyle.Render(" → ...")
+		}
+		lines = append(lines, row)
+	}
+
+	lines = append(lines, "", hintStyle.Render("Enter/Space: Manage hostnames"))
+
+	pane := paneStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, pane)
+}
@@ -0,0 +1,90 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type startupProbedMsg models.StartupProbe
+
+// probeStartup looks up the cloudflared version and Docker for the launch
+// banner
+func (m Model) probeStartup() tea.Cmd {
+	return func() tea.Msg {
+		return startupProbedMsg(models.ProbeStartup())
+	}
+}
+
+// renderStartupBanner summarizes tunnel health and the local tools on one
+// line until the first key press, filling in as the tunnel statuses and
+// hostname counts load
+func (m Model) renderStartupBanner() string {
+	okStyle := lipgloss.NewStyle().Foreground(colors.success)
+	warnStyle := lipgloss.NewStyle().Foreground(colors.warning)
+	pendingStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	if m.loading && len(m.tunnelsList) == 0 {
+		return pendingStyle.Render("Checking tunnels, cloudflared and Docker...")
+	}
+
+	healthy, hostnames := 0, 0
+	statusesKnown, countsKnown := true, true
+	for _, tunnel := range m.tunnelsList {
+		status, known := m.tunnelStatuses[tunnel.ID]
+		if !known {
+			statusesKnown = false
+		} else if status == models.StatusActive {
+			healthy++
+		}
+		count, known := m.tunnelDomainCounts[tunnel.ID]
+		if !known {
+			countsKnown = false
+		}
+		hostnames += count
+	}
+
+	var parts []string
+	parts = append(parts, okStyle.Render(fmt.Sprintf("%d tunnels", len(m.tunnelsList))))
+
+	healthText := fmt.Sprintf("%d healthy", healthy)
+	switch {
+	case !statusesKnown:
+		parts = append(parts, pendingStyle.Render(healthText+"..."))
+	case healthy < len(m.tunnelsList):
+		parts = append(parts, warnStyle.Render(healthText))
+	default:
+		parts = append(parts, okStyle.Render(healthText))
+	}
+
+	hostnameText := fmt.Sprintf("%d hostnames", hostnames)
+	if countsKnown {
+		parts = append(parts, okStyle.Render(hostnameText))
+	} else {
+		parts = append(parts, pendingStyle.Render(hostnameText+"..."))
+	}
+
+	if m.startupProbe == nil {
+		parts = append(parts, pendingStyle.Render("cloudflared ..."), pendingStyle.Render("Docker available: ..."))
+		return strings.Join(parts, pendingStyle.Render(" • "))
+	}
+
+	if m.startupProbe.CloudflaredVersion != "" {
+		parts = append(parts, okStyle.Render("cloudflared "+m.startupProbe.CloudflaredVersion))
+	} else {
+		parts = append(parts, warnStyle.Render("cloudflared not found"))
+	}
+	if m.startupProbe.DockerAvailable {
+		parts = append(parts, okStyle.Render("Docker available: yes"))
+	} else {
+		parts = append(parts, warnStyle.Render("Docker available: no"))
+	}
+
+	return strings.Join(parts, pendingStyle.Render(" • "))
+}
@@ -0,0 +1,66 @@
+package views
+
+import (
+	"strings"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusIndicator pairs each status color with a symbol so the state stays
+// readable in monochrome terminals and for color-blind users. color points
+// into colors so indicators follow the configured theme.
+type statusIndicator struct {
+	label  string
+	symbol string
+	color  *lipgloss.TerminalColor
+}
+
+func (s statusIndicator) String() string {
+	return s.symbol + " " + s.label
+}
+
+var (
+	indicatorHealthy  = statusIndicator{"HEALTHY", "✓", &colors.success}
+	indicatorDegraded = statusIndicator{"DEGRADED", "~", &colors.caution}
+	indicatorDown     = statusIndicator{"DOWN", "✗", &colors.errorColor}
+	indicatorError    = statusIndicator{"ERROR", "!", &colors.warning}
+	indicatorUnknown  = statusIndicator{"UNKNOWN", "?", &colors.subtle}
+	indicatorDeleted  = statusIndicator{"DELETED", "-", &colors.subtle}
+)
+
+// legendIndicators are listed, in order, in the legend under the tunnel list
+var legendIndicators = []statusIndicator{indicatorHealthy, indicatorDegraded, indicatorDown, indicatorError, indicatorUnknown}
+
+// tunnelIndicator returns how a tunnel's status is displayed. Tunnels without
+// a status yet are shown as down, matching the CLI's view of them.
+func (m Model) tunnelIndicator(tunnelID string) statusIndicator {
+	if md, exists := m.tunnelMetadata[tunnelID]; exists && md.IsDeleted() {
+		return indicatorDeleted
+	}
+
+	switch m.tunnelStatuses[tunnelID] {
+	case models.StatusActive:
+		return indicatorHealthy
+	case models.StatusDegraded:
+		return indicatorDegraded
+	case models.StatusError:
+		return indicatorError
+	case models.StatusUnknown:
+		return indicatorUnknown
+	default:
+		return indicatorDown
+	}
+}
+
+func (m Model) renderStatusLegend() string {
+	var parts []string
+	for _, indicator := range legendIndicators {
+		parts = append(parts, lipgloss.NewStyle().Foreground(*indicator.color).Render(indicator.String()))
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Render("Legend: " + strings.Join(parts, "   "))
+}
@@ -0,0 +1,89 @@
+package views
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// queuedTask is a write against a single tunnel. Tasks for the same tunnel
+// run one at a time so concurrent edits never race on its configuration.
+type queuedTask struct {
+	tunnelID string
+	label    string
+	cmd      tea.Cmd
+}
+
+type taskQueuedMsg struct {
+	task queuedTask
+}
+
+// taskDoneMsg wraps the result of a finished task so the next one can start
+type taskDoneMsg struct {
+	tunnelID string
+	result   tea.Msg
+}
+
+// queueTask returns a command that adds cmd to the tunnel's write queue. The
+// task is only queued once the returned command runs, so it can be wrapped by
+// confirmation prompts like any other command.
+func queueTask(tunnelID, label string, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return taskQueuedMsg{task: queuedTask{tunnelID: tunnelID, label: label, cmd: cmd}}
+	}
+}
+
+// enqueueTask appends a task and starts it if nothing else is running for its tunnel
+func (m *Model) enqueueTask(task queuedTask) tea.Cmd {
+	queue := append(m.taskQueues[task.tunnelID], task)
+	m.taskQueues[task.tunnelID] = queue
+	if len(queue) > 1 {
+		m.statusMessage = fmt.Sprintf("Queued: %s", task.label)
+		return nil
+	}
+	return runTask(task)
+}
+
+// finishTask removes the completed task and starts the next one for the tunnel
+func (m *Model) finishTask(tunnelID string) tea.Cmd {
+	queue := m.taskQueues[tunnelID]
+	if len(queue) > 0 {
+		queue = queue[1:]
+	}
+	if len(queue) == 0 {
+		delete(m.taskQueues, tunnelID)
+		return nil
+	}
+	m.taskQueues[tunnelID] = queue
+	return runTask(queue[0])
+}
+
+func runTask(task queuedTask) tea.Cmd {
+	return func() tea.Msg {
+		var result tea.Msg
+		if task.cmd != nil {
+			result = task.cmd()
+		}
+		return taskDoneMsg{tunnelID: task.tunnelID, result: result}
+	}
+}
+
+// pendingTasks counts running and queued tasks across all tunnels
+func (m Model) pendingTasks() int {
+	count := 0
+	for _, queue := range m.taskQueues {
+		count += len(queue)
+	}
+	return count
+}
+
+func (m Model) renderPendingTasks() string {
+	switch n := m.pendingTasks(); n {
+	case 0:
+		return ""
+	case 1:
+		return "1 task pending"
+	default:
+		return fmt.Sprintf("%d tasks pending", n)
+	}
+}
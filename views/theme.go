@@ -0,0 +1,60 @@
+package views
+
+import (
+	"os"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// palette is a models.Theme as lipgloss colors
+type palette struct {
+	accent     lipgloss.TerminalColor
+	accentSoft lipgloss.TerminalColor
+	text       lipgloss.TerminalColor
+	heading    lipgloss.TerminalColor
+	muted      lipgloss.TerminalColor
+	subtle     lipgloss.TerminalColor
+	border     lipgloss.TerminalColor
+	panel      lipgloss.TerminalColor
+	onColor    lipgloss.TerminalColor
+	onWarning  lipgloss.TerminalColor
+	success    lipgloss.TerminalColor
+	warning    lipgloss.TerminalColor
+	caution    lipgloss.TerminalColor
+	errorColor lipgloss.TerminalColor
+}
+
+// colors is what every render function draws with. NewModel replaces it
+// with the configured theme.
+var colors = newPalette(models.Themes[models.DefaultThemeName])
+
+// newPalette converts theme, dropping every color when NO_COLOR is set
+// (https://no-color.org). Selections stay visible through their bold text
+// and ▶ markers.
+func newPalette(theme models.Theme) palette {
+	color := func(hex string) lipgloss.TerminalColor {
+		if os.Getenv("NO_COLOR") != "" {
+			return lipgloss.NoColor{}
+		}
+		return lipgloss.Color(hex)
+	}
+
+	return palette{
+		accent:     color(theme.Accent),
+		accentSoft: color(theme.AccentSoft),
+		text:       color(theme.Text),
+		heading:    color(theme.Heading),
+		muted:      color(theme.Muted),
+		subtle:     color(theme.Subtle),
+		border:     color(theme.Border),
+		panel:      color(theme.Panel),
+		onColor:    color(theme.OnColor),
+		onWarning:  color(theme.OnWarning),
+		success:    color(theme.Success),
+		warning:    color(theme.Warning),
+		caution:    color(theme.Caution),
+		errorColor: color(theme.Error),
+	}
+}
@@ -0,0 +1,177 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// toastLimit is how many toasts are stacked at once; older ones give way
+	toastLimit = 4
+	// toastHistoryLimit is how many errors and warnings the history keeps
+	toastHistoryLimit = 50
+)
+
+type toastKind int
+
+const (
+	toastSuccess toastKind = iota
+	toastWarning
+	toastError
+)
+
+// toastLifetimes is how long each kind stays up. Errors stay longest and
+// are kept in the history afterwards.
+var toastLifetimes = map[toastKind]time.Duration{
+	toastSuccess: 4 * time.Second,
+	toastWarning: 8 * time.Second,
+	toastError:   12 * time.Second,
+}
+
+// toast is a transient notification stacked above the status bar
+type toast struct {
+	kind    toastKind
+	text    string
+	at      time.Time
+	expires time.Time
+}
+
+type toastExpiredMsg struct{}
+
+// pushToast stacks a notification and schedules its removal. Errors and
+// warnings also go to the history.
+func (m *Model) pushToast(kind toastKind, text string) tea.Cmd {
+	now := time.Now()
+	t := toast{kind: kind, text: text, at: now, expires: now.Add(toastLifetimes[kind])}
+	m.toasts = append(m.toasts, t)
+	if len(m.toasts) > toastLimit {
+		m.toasts = m.toasts[len(m.toasts)-toastLimit:]
+	}
+	if kind != toastSuccess {
+		m.toastHistory = append(m.toastHistory, t)
+		if len(m.toastHistory) > toastHistoryLimit {
+			m.toastHistory = m.toastHistory[len(m.toastHistory)-toastHistoryLimit:]
+		}
+	}
+	return tea.Tick(toastLifetimes[kind], func(time.Time) tea.Msg { return toastExpiredMsg{} })
+}
+
+// collectToasts turns the error and warning an update left behind into
+// toasts, so the many places that set them don't each need to
+func (m *Model) collectToasts() tea.Cmd {
+	var cmds []tea.Cmd
+	if m.errorMessage != "" {
+		cmds = append(cmds, m.pushToast(toastError, m.errorMessage))
+		m.errorMessage = ""
+	}
+	if m.warningMessage != "" {
+		cmds = append(cmds, m.pushToast(toastWarning, m.warningMessage))
+		m.warningMessage = ""
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) handleToastExpired() {
+	now := time.Now()
+	var kept []toast
+	for _, t := range m.toasts {
+		if t.expires.After(now) {
+			kept = append(kept, t)
+		}
+	}
+	m.toasts = kept
+}
+
+func (m Model) handleToastHistoryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.Translate(msg.String()) {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "escape", "!":
+		m.showToastHistory = false
+		m.statusMessage = "Closed error history"
+
+	case "c":
+		m.toastHistory = nil
+		m.statusMessage = "Cleared error history"
+	}
+
+	return m, nil
+}
+
+func toastStyle(kind toastKind) (lipgloss.Style, string) {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(0, 1)
+
+	switch kind {
+	case toastError:
+		return style.BorderForeground(colors.errorColor).Foreground(colors.errorColor), "✗"
+	case toastWarning:
+		return style.BorderForeground(colors.warning).Foreground(colors.warning), "⚠"
+	}
+	return style.BorderForeground(colors.success).Foreground(colors.success), "✓"
+}
+
+// renderToasts stacks the live toasts in the bottom-right corner, newest at
+// the bottom
+func (m Model) renderToasts() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	width := m.width / 2
+	if width < 30 {
+		width = 30
+	}
+	var boxes []string
+	for _, t := range m.toasts {
+		style, icon := toastStyle(t.kind)
+		boxes = append(boxes, style.MaxWidth(width).Width(width-2).Render(icon+" "+t.text))
+	}
+	return lipgloss.PlaceHorizontal(m.width, lipgloss.Right, lipgloss.JoinVertical(lipgloss.Right, boxes...))
+}
+
+func (m Model) renderToastHistory() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("🧾 Errors and warnings (%d)", len(m.toastHistory)))}
+	if len(m.toastHistory) == 0 {
+		lines = append(lines, hintStyle.Render("Nothing has gone wrong this session"))
+	}
+
+	visible := m.height - 18
+	if visible < 5 {
+		visible = 5
+	}
+	for i := len(m.toastHistory) - 1; i >= 0 && len(m.toastHistory)-i <= visible; i-- {
+		t := m.toastHistory[i]
+		_, icon := toastStyle(t.kind)
+		color := colors.warning
+		if t.kind == toastError {
+			color = colors.errorColor
+		}
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(color).
+			Width(m.width-12).
+			Render(fmt.Sprintf("%s %s %s", t.at.Format("15:04:05"), icon, t.text)))
+	}
+
+	lines = append(lines, "", hintStyle.Render("c: Clear • Escape: Close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
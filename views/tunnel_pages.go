@@ -0,0 +1,124 @@
+package views
+
+import (
+	"context"
+	"fmt"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tunnelPageLoadedMsg carries tunnels listed page by page through the API.
+// Page 1 replaces the list (and may span every page already loaded); later
+// pages are appended.
+type tunnelPageLoadedMsg struct {
+	page    int
+	tunnels []models.CLITunnel
+	more    bool
+}
+
+// loadTunnelPage fetches one page of tunnels. A refresh reloads every page
+// shown so far as a single larger first page so the cursor keeps its place.
+func (m Model) loadTunnelPage(page int) tea.Cmd {
+	opts := models.TunnelListOptions{
+		NamePrefix: m.tunnelPrefix,
+		Page:       page,
+		PerPage:    m.config.TunnelListPageSize(),
+	}
+	if page == 1 && m.tunnelPages > 1 {
+		opts.PerPage *= m.tunnelPages
+	}
+
+	return tea.Cmd(func() tea.Msg {
+		if m.client == nil {
+			return errorMsg("Cloudflare client not initialized - check your API credentials")
+		}
+
+		tunnels, more, err := m.client.ListTunnelsPage(context.Background(), opts)
+		if err != nil {
+			return apiErrorMsg("load tunnels", err)
+		}
+		return tunnelPageLoadedMsg{page: page, tunnels: tunnels, more: more}
+	})
+}
+
+// usePagedTunnels reports whether the tunnel list is loaded page by page
+func (m Model) usePagedTunnels() bool {
+	return m.config.UsePagedTunnelList() || m.tunnelPrefix != ""
+}
+
+func (m Model) handleTunnelPageLoaded(msg tunnelPageLoadedMsg) (tea.Model, tea.Cmd) {
+	m.moreTunnels = msg.more
+	m.loadingMoreTunnels = false
+	if msg.page == 1 {
+		if m.tunnelPages == 0 {
+			m.tunnelPages = 1
+		}
+		updated, cmd := m.Update(tunnelsLoadedMsg(msg.tunnels))
+		m = updated.(Model)
+		if m.moreTunnels {
+			m.statusMessage += " (more available - scroll to the end to load them)"
+		}
+		return m, cmd
+	}
+
+	m.tunnelPages = msg.page
+	selectedID := m.cursorTunnelID()
+	m.tunnelsList = append(m.tunnelsList, msg.tunnels...)
+	m.sortTunnels()
+	m.reselectTunnel(selectedID)
+	m.statusMessage = fmt.Sprintf("Loaded %d tunnels", len(m.tunnelsList))
+	return m, tea.Batch(m.loadTunnelDomainCounts(msg.tunnels), m.loadTunnelStatuses(msg.tunnels), m.loadTunnelMetadata())
+}
+
+// loadMoreTunnels fetches the next page once the cursor reaches the end of the list
+func (m *Model) loadMoreTunnels() tea.Cmd {
+	if !m.usePagedTunnels() || !m.moreTunnels || m.loadingMoreTunnels {
+		return nil
+	}
+	m.loadingMoreTunnels = true
+	m.statusMessage = "Loading more tunnels..."
+	return m.loadTunnelPage(m.tunnelPages + 1)
+}
+
+// openTunnelFilter prompts for a server-side tunnel name prefix
+func (m *Model) openTunnelFilter() {
+	m.showTunnelFilter = true
+	m.tunnelFilterInput = textinput.New()
+	m.tunnelFilterInput.Placeholder = "name prefix, e.g. prod-"
+	m.tunnelFilterInput.SetValue(m.tunnelPrefix)
+	m.tunnelFilterInput.CharLimit = 100
+	m.tunnelFilterInput.Width = 40
+	m.tunnelFilterInput.Focus()
+	m.tunnelFilterInput.CursorEnd()
+	m.statusMessage = "Filter tunnels by name prefix (Enter: Apply, Escape: Cancel, empty: Show all)"
+}
+
+func (m Model) handleTunnelFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.showTunnelFilter = false
+		m.statusMessage = "Filter unchanged"
+		return m, nil
+
+	case "enter":
+		m.showTunnelFilter = false
+		m.tunnelPrefix = m.tunnelFilterInput.Value()
+		m.tunnelPages = 0
+		m.selectedTunnel = 0
+		m.statusMessage = "Loading tunnels..."
+		if m.tunnelPrefix != "" {
+			m.statusMessage = fmt.Sprintf("Loading tunnels starting with %q...", m.tunnelPrefix)
+		}
+		return m, m.loadTunnels()
+	}
+
+	var cmd tea.Cmd
+	m.tunnelFilterInput, cmd = m.tunnelFilterInput.Update(msg)
+	return m, cmd
+}
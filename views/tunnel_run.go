@@ -0,0 +1,151 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tunnelman/models"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// runningLocally reports whether tunnelman runs a connector for the tunnel
+func (m Model) runningLocally(tunnelName string) bool {
+	if m.tunnelManager == nil {
+		return false
+	}
+	_, running := m.tunnelManager.GetRunningTunnels()[tunnelName]
+	return running
+}
+
+// toggleTunnelRun stops the selected tunnel's local connector, or starts
+// one, asking first when other connectors already serve the tunnel
+func (m *Model) toggleTunnelRun() tea.Cmd {
+	if m.selectedTunnel >= len(m.tunnelsList) {
+		return nil
+	}
+	if m.tunnelManager == nil {
+		m.errorMessage = "Tunnel manager not initialized"
+		return nil
+	}
+	tunnel := m.tunnelsList[m.selectedTunnel]
+	tunnelManager := m.tunnelManager
+
+	if m.runningLocally(tunnel.Name) {
+		m.statusMessage = fmt.Sprintf("Stopping %s...", tunnel.Name)
+		return func() tea.Msg {
+			if err := tunnelManager.StopTunnel(tunnel.Name); err != nil {
+				return processActionMsg{err: err}
+			}
+			return processActionMsg{message: fmt.Sprintf("Stopped local connector for %s", tunnel.Name)}
+		}
+	}
+
+	m.statusMessage = fmt.Sprintf("Checking %s's connectors...", tunnel.Name)
+	return m.checkReplicas(tunnel, func(m *Model) tea.Cmd { return m.startSelectedTunnel(tunnel) })
+}
+
+// startSelectedTunnel starts a local connector for tunnel: from its saved
+// local config if there is one, on the remote ingress if it has hostnames,
+// otherwise after asking which URL to serve
+func (m *Model) startSelectedTunnel(tunnel models.CLITunnel) tea.Cmd {
+	tunnelManager := m.tunnelManager
+	if config, err := tunnelManager.LoadTunnelConfig(tunnel.Name); err == nil {
+		m.statusMessage = fmt.Sprintf("Starting %s from its local config...", tunnel.Name)
+		return m.startTunnel(tunnel.Name, config, "")
+	}
+	if m.tunnelDomainCounts[tunnel.ID] > 0 {
+		m.statusMessage = fmt.Sprintf("Starting %s...", tunnel.Name)
+		return m.startTunnel(tunnel.Name, nil, "")
+	}
+
+	m.runURLTunnel = tunnel.Name
+	m.runURLInput = textinput.New()
+	m.runURLInput.Placeholder = "http://localhost:8080"
+	if recent := m.state.RecentServices; len(recent) > 0 && strings.HasPrefix(recent[0], "http") {
+		m.runURLInput.SetValue(recent[0])
+	}
+	m.runURLInput.CharLimit = 100
+	m.runURLInput.Width = 40
+	m.runURLInput.Focus()
+	m.runURLInput.CursorEnd()
+	m.statusMessage = fmt.Sprintf("%s has no hostnames or local config - enter a URL to serve with --url", tunnel.Name)
+	return nil
+}
+
+// startTunnel runs a connector with config, or with --url serviceURL when set
+func (m Model) startTunnel(tunnelName string, config *models.TunnelConfigFile, serviceURL string) tea.Cmd {
+	tunnelManager := m.tunnelManager
+	return func() tea.Msg {
+		// The connector outlives this command, so it can't share a cancellable context
+		var process *models.TunnelProcess
+		var err error
+		if serviceURL != "" {
+			process, err = tunnelManager.StartTunnelWithURL(context.Background(), tunnelName, serviceURL)
+		} else {
+			process, err = tunnelManager.StartTunnel(context.Background(), tunnelName, config)
+		}
+		if err != nil {
+			return processActionMsg{err: fmt.Errorf("failed to start %s: %w", tunnelName, err)}
+		}
+		return processActionMsg{message: fmt.Sprintf("Started %s locally (PID %d)", tunnelName, process.PID)}
+	}
+}
+
+func (m Model) handleRunURLInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+
+	case "esc", "escape":
+		m.runURLTunnel = ""
+		m.statusMessage = "Not started"
+		return m, nil
+
+	case "enter":
+		serviceURL := strings.TrimSpace(m.runURLInput.Value())
+		if serviceURL == "" {
+			serviceURL = m.runURLInput.Placeholder
+		}
+		tunnelName := m.runURLTunnel
+		m.runURLTunnel = ""
+		m.statusMessage = fmt.Sprintf("Starting %s with --url %s...", tunnelName, serviceURL)
+		return m, m.startTunnel(tunnelName, nil, serviceURL)
+	}
+
+	var cmd tea.Cmd
+	m.runURLInput, cmd = m.runURLInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderRunURL() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.accent).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(colors.border).
+		PaddingBottom(1).
+		MarginBottom(1)
+
+	rowStyle := lipgloss.NewStyle().
+		Foreground(colors.text)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(colors.muted).
+		Italic(true)
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("▶ Start %s", m.runURLTunnel)),
+		rowStyle.Render("The tunnel has no hostnames or local config. Serve this URL on the tunnel's cfargotunnel.com address:"),
+		"",
+		m.runURLInput.View(),
+		"",
+		hintStyle.Render("Enter: Start with --url • Escape: Cancel"),
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,18 @@
+package views
+
+import (
+	"tunnelman/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// warningMsg carries a warning raised by the models layer into the program
+type warningMsg models.Warning
+
+// WarningReporter returns a reporter that shows client warnings in the status bar
+// of the running program instead of writing them over the alt-screen
+func WarningReporter(p *tea.Program) models.WarningReporter {
+	return func(w models.Warning) {
+		go p.Send(warningMsg(w))
+	}
+}